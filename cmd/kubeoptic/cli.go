@@ -0,0 +1,184 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"k8s.io/client-go/kubernetes"
+
+	"kubeoptic/internal/services"
+)
+
+// command is a subcommand, run for scripts instead of the TUI. It returns
+// the exit code.
+type command struct {
+	name    string
+	summary string
+	run     func(args []string) int
+}
+
+// commands are the subcommands, in the order the usage lists them
+var commands = []command{
+	{name: "logs", summary: "stream the logs of a pod, selector or workload to stdout", run: runLogs},
+	{name: "wait-for-log", summary: "wait until a selector's pods log a success or failure pattern", run: runWaitForLog},
+}
+
+// findCommand returns the subcommand of a name, nil for none
+func findCommand(name string) *command {
+	for i := range commands {
+		if commands[i].name == name {
+			return &commands[i]
+		}
+	}
+	return nil
+}
+
+// stringSliceFlag collects a flag that may be given multiple times
+type stringSliceFlag []string
+
+func (s *stringSliceFlag) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringSliceFlag) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
+// connectionFlags are the kubeconfig path and the kubectl-compatible
+// connection flags, taken by the TUI and every command
+type connectionFlags struct {
+	configPath string
+	asGroups   stringSliceFlag
+	options    services.ConnectionOptions
+}
+
+// register adds the flags to a flag set
+func (c *connectionFlags) register(flags *flag.FlagSet) {
+	flags.StringVar(&c.configPath, "config", "", "path to kubeconfig file")
+	flags.StringVar(&c.options.Impersonate, "as", "", "username to impersonate for the operation")
+	flags.Var(&c.asGroups, "as-group", "group to impersonate for the operation, can be repeated")
+	flags.StringVar(&c.options.RequestTimeout, "request-timeout", "0", "time to wait before giving up on a server request (e.g. 1s, 2m); 0 means no timeout. Log streams, exec, port-forwards and watches are not cut off")
+	flags.BoolVar(&c.options.InsecureSkipTLSVerify, "insecure-skip-tls-verify", false, "do not verify the server's certificate (insecure)")
+	flags.StringVar(&c.options.CertificateAuthority, "certificate-authority", "", "path to a CA certificate file for the server")
+	flags.StringVar(&c.options.Server, "server", "", "address and port of the Kubernetes API server")
+	flags.StringVar(&c.options.Token, "token", "", "bearer token for authentication to the API server")
+	flags.StringVar(&c.options.ProxyURL, "proxy-url", "", "proxy URL to use for requests to the API server (http, https or socks5)")
+}
+
+// load returns the config service applying the flags and the kubeconfig to
+// read, discovered unless given. It explains on stderr where it looked when
+// none is found.
+func (c *connectionFlags) load() (services.ConfigService, string, error) {
+	c.options.ImpersonateGroups = c.asGroups
+	configSvc, err := services.NewConfigServiceWithOptions(c.options)
+	if err != nil {
+		return nil, "", err
+	}
+	if c.configPath != "" {
+		return configSvc, c.configPath, nil
+	}
+
+	discoveredPath, err := configSvc.DiscoverConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "No kubeconfig found. Please specify:\n")
+		fmt.Fprintf(os.Stderr, "  kubeoptic --config /path/to/kubeconfig\n\n")
+		fmt.Fprintf(os.Stderr, "Searched:\n")
+		fmt.Fprintf(os.Stderr, "  - $KUBECONFIG environment variable\n")
+		fmt.Fprintf(os.Stderr, "  - ~/.kube/config\n")
+		fmt.Fprintf(os.Stderr, "  - In-cluster config\n")
+		return nil, "", err
+	}
+	return configSvc, discoveredPath, nil
+}
+
+// connect builds a client for a context, the kubeconfig's current one when
+// empty, and returns it with the namespace the context selects
+func (c *connectionFlags) connect(contextName string) (*kubernetes.Clientset, string, error) {
+	configSvc, configPath, err := c.load()
+	if err != nil {
+		return nil, "", err
+	}
+
+	_, currentContext, client, err := configSvc.LoadContexts(configPath)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	if contextName != "" && contextName != currentContext {
+		if client, err = configSvc.ClientForContext(configPath, contextName); err != nil {
+			return nil, "", err
+		}
+	} else {
+		contextName = currentContext
+	}
+
+	namespace, err := configSvc.NamespaceForContext(configPath, contextName)
+	if err != nil {
+		return nil, "", err
+	}
+	return client, namespace, nil
+}
+
+// parseArgs parses a command's flags wherever they are given among its
+// arguments, as kubectl allows, and returns the arguments that aren't
+// flags. Everything after -- is an argument.
+func parseArgs(flags *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		rest, dashes := args, -1
+		for i, arg := range args {
+			if arg == "--" {
+				rest, dashes = args[:i], i
+				break
+			}
+		}
+		if err := flags.Parse(rest); err != nil {
+			return nil, err
+		}
+		if flags.NArg() == 0 {
+			if dashes >= 0 {
+				positional = append(positional, args[dashes+1:]...)
+			}
+			return positional, nil
+		}
+		positional = append(positional, flags.Arg(0))
+		next := append([]string{}, flags.Args()[1:]...)
+		args = append(next, args[len(rest):]...)
+	}
+}
+
+// commandFlags creates the flag set of a command, whose usage starts with
+// the command's synopsis and description
+func commandFlags(name, synopsis, description string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.Usage = func() {
+		out := flags.Output()
+		fmt.Fprintf(out, "Usage: kubeoptic %s %s\n\n%s\n\nFlags:\n", name, synopsis, description)
+		flags.PrintDefaults()
+	}
+	return flags
+}
+
+// usage lists the commands after the TUI's flags
+func usage() {
+	out := flag.CommandLine.Output()
+	fmt.Fprintf(out, "Usage: kubeoptic [flags]\n       kubeoptic <command> [flags] [args]\n\nCommands:\n")
+	for _, cmd := range commands {
+		fmt.Fprintf(out, "  %-14s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintf(out, "\nRun kubeoptic <command> -h for the flags of a command.\n\nFlags:\n")
+	flag.PrintDefaults()
+}
+
+// flagsExitCode returns the exit code of a command that failed to parse
+// its flags, the flag package having said why: asking for help is not a
+// failure
+func flagsExitCode(err error) int {
+	if errors.Is(err, flag.ErrHelp) {
+		return 0
+	}
+	return 1
+}
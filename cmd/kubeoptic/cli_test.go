@@ -0,0 +1,83 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestParseArgs(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		want       []string
+		wantNS     string
+		wantFollow bool
+		wantErr    error
+	}{
+		{name: "no arguments"},
+		{name: "flags first", args: []string{"-n", "team", "api"}, want: []string{"api"}, wantNS: "team"},
+		{name: "flags last", args: []string{"api", "-n", "team"}, want: []string{"api"}, wantNS: "team"},
+		{name: "flags between", args: []string{"api", "--namespace=team", "worker", "-f"}, want: []string{"api", "worker"}, wantNS: "team", wantFollow: true},
+		{name: "only arguments", args: []string{"api", "worker"}, want: []string{"api", "worker"}},
+		{name: "arguments after dashes", args: []string{"--", "-n", "team"}, want: []string{"-n", "team"}},
+		{name: "flags before dashes", args: []string{"api", "-f", "--", "-n"}, want: []string{"api", "-n"}, wantFollow: true},
+		{name: "dashes end flags before their value", args: []string{"-n", "--", "api"}, wantErr: errors.New("flag needs an argument: -n")},
+		{name: "help", args: []string{"api", "-h"}, wantErr: flag.ErrHelp},
+		{name: "unknown flag", args: []string{"api", "--bogus"}, wantErr: errors.New("flag provided but not defined: -bogus")},
+		{name: "missing value", args: []string{"api", "-n"}, wantErr: errors.New("flag needs an argument: -n")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := flag.NewFlagSet("test", flag.ContinueOnError)
+			flags.SetOutput(io.Discard)
+			namespace := flags.String("namespace", "", "")
+			flags.StringVar(namespace, "n", "", "")
+			follow := flags.Bool("f", false, "")
+
+			got, err := parseArgs(flags, tt.args)
+			if tt.wantErr != nil {
+				if err == nil || err.Error() != tt.wantErr.Error() {
+					t.Fatalf("parseArgs(%q) error = %v, want %v", tt.args, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseArgs(%q) error = %v", tt.args, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseArgs(%q) = %q, want %q", tt.args, got, tt.want)
+			}
+			if *namespace != tt.wantNS {
+				t.Errorf("namespace = %q, want %q", *namespace, tt.wantNS)
+			}
+			if *follow != tt.wantFollow {
+				t.Errorf("follow = %v, want %v", *follow, tt.wantFollow)
+			}
+		})
+	}
+}
+
+func TestParseArgsKeepsArguments(t *testing.T) {
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.Bool("f", false, "")
+	args := []string{"api", "-f", "worker", "--", "job"}
+	if _, err := parseArgs(flags, args); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"api", "-f", "worker", "--", "job"}; !reflect.DeepEqual(args, want) {
+		t.Errorf("parseArgs changed its arguments to %q", args)
+	}
+}
+
+func TestFlagsExitCode(t *testing.T) {
+	if code := flagsExitCode(flag.ErrHelp); code != 0 {
+		t.Errorf("flagsExitCode(ErrHelp) = %d, want 0", code)
+	}
+	if code := flagsExitCode(errors.New("flag provided but not defined: -x")); code != 1 {
+		t.Errorf("flagsExitCode(error) = %d, want 1", code)
+	}
+}
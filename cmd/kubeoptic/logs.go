@@ -0,0 +1,334 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/charmbracelet/lipgloss"
+	"golang.org/x/term"
+	"k8s.io/client-go/kubernetes"
+
+	"kubeoptic/internal/services"
+	"kubeoptic/internal/tui/styles"
+)
+
+// Output templates of the logs command
+const (
+	outputRaw      = "raw"
+	outputPrefixed = "prefixed"
+	outputJSON     = "json"
+)
+
+// workloadKinds maps the kinds a TYPE/NAME argument may name, with the
+// short names kubectl accepts, to workload kinds
+var workloadKinds = map[string]string{
+	"deployment": services.KindDeployment, "deploy": services.KindDeployment,
+	"statefulset": services.KindStatefulSet, "sts": services.KindStatefulSet,
+	"daemonset": services.KindDaemonSet, "ds": services.KindDaemonSet,
+	"replicaset": services.KindReplicaSet, "rs": services.KindReplicaSet,
+	"job":     services.KindJob,
+	"cronjob": services.KindCronJob, "cj": services.KindCronJob,
+}
+
+// logTarget is what a log command follows: a pod, a workload's pods or the
+// pods matching a query, and the container of those pods when they have
+// several
+type logTarget struct {
+	namespace string
+	pod       string
+	kind      string
+	name      string
+	selector  string
+	container string
+}
+
+// logTargetFlags are the flags choosing the pods a log command follows
+type logTargetFlags struct {
+	connection connectionFlags
+	context    string
+	namespace  string
+	selector   string
+	container  string
+}
+
+// register adds the flags to a flag set, with kubectl's short names
+func (t *logTargetFlags) register(flags *flag.FlagSet) {
+	t.connection.register(flags)
+	flags.StringVar(&t.context, "context", "", "kubeconfig context to use, the current one by default")
+	flags.StringVar(&t.namespace, "namespace", "", "namespace of the pods, the context's by default")
+	flags.StringVar(&t.namespace, "n", "", "shorthand for --namespace")
+	flags.StringVar(&t.selector, "selector", "", "pod query as typed in the TUI, e.g. 'app=api status.phase=Running'")
+	flags.StringVar(&t.selector, "l", "", "shorthand for --selector")
+	flags.StringVar(&t.container, "container", "", "container of the pods to follow, needed for pods with several")
+	flags.StringVar(&t.container, "c", "", "shorthand for --container")
+}
+
+// resolve connects to the cluster and returns the target of the arguments,
+// in the namespace of the context unless --namespace is given
+func (t *logTargetFlags) resolve(args []string) (*kubernetes.Clientset, logTarget, error) {
+	target, err := t.target(args)
+	if err != nil {
+		return nil, target, err
+	}
+
+	client, namespace, err := t.connection.connect(t.context)
+	if err != nil {
+		return nil, target, err
+	}
+	if target.namespace == "" {
+		target.namespace = namespace
+	}
+	return client, target, nil
+}
+
+// target returns the target of the arguments: a pod name or TYPE/NAME, or
+// none with a selector
+func (t *logTargetFlags) target(args []string) (logTarget, error) {
+	target := logTarget{namespace: t.namespace, container: t.container}
+	switch {
+	case t.selector != "" && len(args) > 0:
+		return target, fmt.Errorf("give either a pod, a workload or --selector, not both")
+	case len(args) > 1:
+		return target, fmt.Errorf("give one pod or workload, not %d", len(args))
+	case t.selector != "":
+		target.selector = t.selector
+	case len(args) == 1:
+		kind, name, found := strings.Cut(args[0], "/")
+		switch {
+		case !found:
+			target.pod = args[0]
+		case strings.EqualFold(kind, "pod") || strings.EqualFold(kind, "po"):
+			target.pod = name
+		case workloadKinds[strings.ToLower(kind)] != "":
+			target.kind = workloadKinds[strings.ToLower(kind)]
+			target.name = name
+		default:
+			return target, fmt.Errorf("unknown kind %q: give a pod, or one of deployment, statefulset, daemonset, replicaset, job or cronjob", kind)
+		}
+		if found && name == "" {
+			return target, fmt.Errorf("give the name of the %s, as in %s/NAME", strings.ToLower(kind), kind)
+		}
+	default:
+		return target, fmt.Errorf("give a pod, TYPE/NAME of a workload, or --selector")
+	}
+	return target, nil
+}
+
+// pods finds the pods of a target
+func (target logTarget) pods(ctx context.Context, client kubernetes.Interface) ([]services.Pod, error) {
+	podSvc := services.NewPodService(client)
+	if target.pod != "" {
+		return []services.Pod{{Name: target.pod, Namespace: target.namespace}}, nil
+	}
+	if target.selector != "" {
+		pods, err := podSvc.SearchPods(ctx, target.namespace, target.selector)
+		if err == nil && len(pods) == 0 {
+			err = fmt.Errorf("no pods match %q in namespace %s", target.selector, target.namespace)
+		}
+		return pods, err
+	}
+
+	workloads, err := services.NewWorkloadService(client).ListWorkloadsOfKind(ctx, target.namespace, target.kind)
+	if err != nil {
+		return nil, err
+	}
+	for _, workload := range workloads {
+		if workload.Name == target.name {
+			return workloadPods(ctx, podSvc, workload)
+		}
+	}
+	return nil, fmt.Errorf("%s %s not found in namespace %s", strings.ToLower(target.kind), target.name, target.namespace)
+}
+
+// workloadPods lists the pods of a workload by searching for its selector,
+// as the workload view does
+func workloadPods(ctx context.Context, podSvc services.PodService, workload services.Workload) ([]services.Pod, error) {
+	var pods []services.Pod
+	var err error
+	if workload.Selector != "" {
+		pods, err = podSvc.SearchPods(ctx, workload.Namespace, workload.Selector)
+	}
+	if err == nil && len(pods) == 0 {
+		err = fmt.Errorf("%s %s has no pods", strings.ToLower(workload.Kind), workload.Name)
+	}
+	return pods, err
+}
+
+// followLogs follows the logs of pods as the log view does, merged and
+// split into lines, handing each to handle until the pods' logs end, the
+// context is done or handle fails
+func followLogs(ctx context.Context, client kubernetes.Interface, pods []services.Pod, options services.LogOptions, handle func(services.LogLine) error) error {
+	stream, err := services.OpenMergedLogs(ctx, services.NewPodService(client), pods, options)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	// Closing the stream ends the read waiting for the next line
+	go func() {
+		<-ctx.Done()
+		stream.Close()
+	}()
+
+	reader := bufio.NewReader(stream)
+	for {
+		line, err := reader.ReadString('\n')
+		if line != "" {
+			if err := handle(services.ParseMergedLogLine(strings.TrimSuffix(line, "\n"))); err != nil {
+				return err
+			}
+		}
+		if err != nil {
+			if ctx.Err() != nil || err == io.EOF || err == io.ErrClosedPipe {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// logPrinter writes log lines in an output template, colouring levels and
+// pods on a terminal like the log view does
+type logPrinter struct {
+	out       io.Writer
+	output    string
+	namespace string
+	color     bool
+	renderer  *lipgloss.Renderer
+	levels    map[string]lipgloss.Style
+}
+
+// podColors tell pods apart in prefixed output
+var podColors = []lipgloss.Color{"39", "170", "114", "214", "75", "204", "148", "141"}
+
+// newLogPrinter creates a printer writing to out, in colour when out is a
+// terminal
+func newLogPrinter(out io.Writer, output, namespace string) *logPrinter {
+	renderer := lipgloss.NewRenderer(out)
+	theme := styles.DefaultTheme()
+	file, isFile := out.(*os.File)
+	return &logPrinter{
+		out:       out,
+		output:    output,
+		namespace: namespace,
+		color:     isFile && term.IsTerminal(int(file.Fd())),
+		renderer:  renderer,
+		levels: map[string]lipgloss.Style{
+			services.LogLevelError: renderer.NewStyle().Foreground(theme.Error),
+			services.LogLevelWarn:  renderer.NewStyle().Foreground(theme.Warning),
+			services.LogLevelInfo:  renderer.NewStyle().Foreground(theme.Info),
+			services.LogLevelDebug: renderer.NewStyle().Foreground(styles.Gray),
+		},
+	}
+}
+
+// print writes a line
+func (p *logPrinter) print(line services.LogLine) error {
+	if p.output == outputJSON {
+		data, err := json.Marshal(struct {
+			Namespace string `json:"namespace"`
+			Pod       string `json:"pod"`
+			Level     string `json:"level,omitempty"`
+			Message   string `json:"message"`
+		}{p.namespace, line.Pod, line.Level, line.Text})
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(p.out, "%s\n", data)
+		return err
+	}
+
+	text := line.Text
+	if style, ok := p.levels[line.Level]; ok && p.color {
+		text = style.Render(text)
+	}
+	if p.output == outputPrefixed {
+		prefix := "[" + line.Pod + "]"
+		if p.color {
+			prefix = p.renderer.NewStyle().Foreground(podColor(line.Pod)).Render(prefix)
+		}
+		text = prefix + " " + text
+	}
+	_, err := fmt.Fprintln(p.out, text)
+	return err
+}
+
+// podColor picks the same colour for a pod on every line
+func podColor(pod string) lipgloss.Color {
+	hash := fnv.New32a()
+	hash.Write([]byte(pod))
+	return podColors[hash.Sum32()%uint32(len(podColors))]
+}
+
+// interruptContext is done when the command is interrupted or terminated
+func interruptContext() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+}
+
+func runLogs(args []string) int {
+	flags := commandFlags("logs", "[flags] POD | TYPE/NAME | --selector QUERY",
+		"Follow the logs of a pod, of a workload's pods or of the pods matching a query,\n"+
+			"writing them to stdout until they end or the command is interrupted. Lines\n"+
+			"are coloured by level when stdout is a terminal.")
+	var target logTargetFlags
+	target.register(flags)
+	grep := flags.String("grep", "", "only lines containing this text, ignoring case, as the log view's search")
+	var output string
+	flags.StringVar(&output, "output", "", "output template: raw, prefixed or json; prefixed for several pods and raw for one by default")
+	flags.StringVar(&output, "o", "", "shorthand for --output")
+
+	positional, err := parseArgs(flags, args)
+	if err != nil {
+		return flagsExitCode(err)
+	}
+	switch output {
+	case "", outputRaw, outputPrefixed, outputJSON:
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unknown output %q: use raw, prefixed or json\n", output)
+		return 1
+	}
+
+	client, logTarget, err := target.resolve(positional)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	ctx, stop := interruptContext()
+	defer stop()
+
+	pods, err := logTarget.pods(ctx, client)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	if output == "" {
+		output = outputRaw
+		if len(pods) > 1 {
+			output = outputPrefixed
+		}
+	}
+
+	printer := newLogPrinter(os.Stdout, output, logTarget.namespace)
+	filter := services.LogFilter{Query: *grep}
+	err = followLogs(ctx, client, pods, services.LogOptions{Container: logTarget.container}, func(line services.LogLine) error {
+		if !filter.Matches(line.Text) {
+			return nil
+		}
+		return printer.print(line)
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
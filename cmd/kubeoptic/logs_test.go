@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"kubeoptic/internal/services"
+)
+
+func TestLogTargetFlagsTarget(t *testing.T) {
+	tests := []struct {
+		name    string
+		flags   logTargetFlags
+		args    []string
+		want    logTarget
+		wantErr string
+	}{
+		{name: "pod", args: []string{"api-7d9f"}, want: logTarget{pod: "api-7d9f"}},
+		{name: "pod kind", args: []string{"pod/api-7d9f"}, want: logTarget{pod: "api-7d9f"}},
+		{name: "pod short kind", args: []string{"po/api-7d9f"}, want: logTarget{pod: "api-7d9f"}},
+		{name: "kind ignores case", args: []string{"Pod/api-7d9f"}, want: logTarget{pod: "api-7d9f"}},
+		{name: "deployment", args: []string{"deployment/api"}, want: logTarget{kind: services.KindDeployment, name: "api"}},
+		{name: "deployment short kind", args: []string{"deploy/api"}, want: logTarget{kind: services.KindDeployment, name: "api"}},
+		{name: "statefulset short kind", args: []string{"sts/db"}, want: logTarget{kind: services.KindStatefulSet, name: "db"}},
+		{name: "daemonset short kind", args: []string{"ds/agent"}, want: logTarget{kind: services.KindDaemonSet, name: "agent"}},
+		{name: "replicaset short kind", args: []string{"rs/api-7d9f"}, want: logTarget{kind: services.KindReplicaSet, name: "api-7d9f"}},
+		{name: "job", args: []string{"job/migrate"}, want: logTarget{kind: services.KindJob, name: "migrate"}},
+		{name: "cronjob short kind", args: []string{"cj/backup"}, want: logTarget{kind: services.KindCronJob, name: "backup"}},
+		{name: "selector", flags: logTargetFlags{selector: "app=api"}, want: logTarget{selector: "app=api"}},
+		{
+			name:  "namespace and container",
+			flags: logTargetFlags{namespace: "team", container: "sidecar"},
+			args:  []string{"api-7d9f"},
+			want:  logTarget{namespace: "team", pod: "api-7d9f", container: "sidecar"},
+		},
+		{name: "nothing", wantErr: "give a pod, TYPE/NAME of a workload, or --selector"},
+		{name: "pod and selector", flags: logTargetFlags{selector: "app=api"}, args: []string{"api"}, wantErr: "not both"},
+		{name: "several pods", args: []string{"api", "worker"}, wantErr: "give one pod or workload, not 2"},
+		{name: "unknown kind", args: []string{"service/api"}, wantErr: `unknown kind "service"`},
+		{name: "no name", args: []string{"deploy/"}, wantErr: "give the name of the deploy"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.flags.target(tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("target(%q) error = %v, want one containing %q", tt.args, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("target(%q) error = %v", tt.args, err)
+			}
+			if got != tt.want {
+				t.Errorf("target(%q) = %+v, want %+v", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLogPrinterPrint(t *testing.T) {
+	lines := []services.LogLine{
+		services.ParseMergedLogLine("[api-7d9f] ERROR connection refused"),
+		services.ParseMergedLogLine("[worker-x2k4] started"),
+	}
+
+	tests := []struct {
+		output string
+		want   string
+	}{
+		{outputRaw, "ERROR connection refused\nstarted\n"},
+		{outputPrefixed, "[api-7d9f] ERROR connection refused\n[worker-x2k4] started\n"},
+		{outputJSON, `{"namespace":"team","pod":"api-7d9f","level":"error","message":"ERROR connection refused"}` + "\n" +
+			`{"namespace":"team","pod":"worker-x2k4","message":"started"}` + "\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.output, func(t *testing.T) {
+			var out bytes.Buffer
+			printer := newLogPrinter(&out, tt.output, "team")
+			if printer.color {
+				t.Error("Expected no colour when not writing to a terminal")
+			}
+			for _, line := range lines {
+				if err := printer.print(line); err != nil {
+					t.Fatalf("print() error = %v", err)
+				}
+			}
+			if out.String() != tt.want {
+				t.Errorf("print() wrote %q, want %q", out.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestLogPrinterJSONEscapes(t *testing.T) {
+	var out bytes.Buffer
+	printer := newLogPrinter(&out, outputJSON, "team")
+	text := `{"msg": "quoted \"value\""}` + "\t"
+	if err := printer.print(services.LogLine{Pod: "api", Text: text}); err != nil {
+		t.Fatal(err)
+	}
+
+	var got struct{ Message string }
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("print() wrote invalid JSON %q: %v", out.String(), err)
+	}
+	if got.Message != text {
+		t.Errorf("message = %q, want %q", got.Message, text)
+	}
+}
+
+func TestPodColor(t *testing.T) {
+	if podColor("api-7d9f") != podColor("api-7d9f") {
+		t.Error("Expected a pod to keep its colour")
+	}
+	seen := make(map[string]bool)
+	for _, pod := range []string{"api-1", "api-2", "api-3", "api-4", "api-5", "api-6"} {
+		seen[string(podColor(pod))] = true
+	}
+	if len(seen) < 2 {
+		t.Error("Expected pods to get different colours")
+	}
+}
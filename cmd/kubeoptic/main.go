@@ -13,33 +13,30 @@ import (
 	"kubeoptic/internal/tui"
 	"kubeoptic/internal/tui/components"
 	"kubeoptic/internal/tui/styles"
+	"kubeoptic/pkg/config"
 )
 
 func main() {
+	// A command runs instead of the TUI
+	if len(os.Args) > 1 {
+		if cmd := findCommand(os.Args[1]); cmd != nil {
+			os.Exit(cmd.run(os.Args[2:]))
+		}
+	}
+
 	// Parse command line flags
-	configPath := flag.String("config", "", "path to kubeconfig file")
+	var connection connectionFlags
+	connection.register(flag.CommandLine)
 	debug := flag.Bool("debug", false, "enable debug mode (skip TUI)")
+	readOnly := flag.Bool("read-only", false, "disable every action that changes the cluster")
+	flag.Usage = usage
 	flag.Parse()
 
-	// Initialize services
-	configSvc := services.NewConfigService()
-
-	// Auto-discover config if not provided
-	var kubeConfigPath string
-	if *configPath != "" {
-		kubeConfigPath = *configPath
-	} else {
-		discoveredPath, err := configSvc.DiscoverConfig()
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: No kubeconfig found. Please specify:\n")
-			fmt.Fprintf(os.Stderr, "  kubeoptic --config /path/to/kubeconfig\n\n")
-			fmt.Fprintf(os.Stderr, "Searched:\n")
-			fmt.Fprintf(os.Stderr, "  - $KUBECONFIG environment variable\n")
-			fmt.Fprintf(os.Stderr, "  - ~/.kube/config\n")
-			fmt.Fprintf(os.Stderr, "  - In-cluster config\n")
-			os.Exit(1)
-		}
-		kubeConfigPath = discoveredPath
+	// Initialize services, auto-discovering the config if not provided
+	configSvc, kubeConfigPath, err := connection.load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
 	}
 
 	// Create kubeoptic coordinator
@@ -50,7 +47,7 @@ func main() {
 	)
 
 	// Load kubernetes configuration
-	err := kubeoptic.LoadContexts(kubeConfigPath)
+	err = kubeoptic.LoadContexts(kubeConfigPath)
 	if err != nil {
 		log.Fatalf("Failed to load kubeconfig: %v", err)
 	}
@@ -97,8 +94,28 @@ func main() {
 	// Create namespace list
 	namespaceList := components.NewNamespaceList(kubeoptic)
 
+	// Load user preferences; a broken config file shouldn't stop the TUI
+	settings := config.Default()
+	settingsPath, err := config.DefaultPath()
+	if err == nil {
+		if settings, err = config.Load(settingsPath); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v, using defaults\n", err)
+			settings = config.Default()
+			settingsPath = ""
+		}
+	}
+	app.SetSettings(settings, settingsPath)
+
+	// Read-only mode from the flag adds to the config's, without saving it
+	kubeoptic.SetSafety(settings.Safety)
+	if *readOnly {
+		kubeoptic.SetReadOnly(true)
+	}
+
 	// Create pod list (initially empty, will be populated when namespace is selected)
 	podList := components.NewPodList([]services.Pod{}, 0, 0)
+	podList.SetTableConfig(settings.PodTable)
+	podList.SetDebugImage(settings.Debug.ImageOrDefault())
 
 	// Create log view
 	logView := components.NewLogViewer(kubeoptic, 0, 0)
@@ -108,6 +125,15 @@ func main() {
 
 	// Connect components to the app
 	app.SetComponents(contextList, namespaceList, podList, logView, statusBar)
+	app.SetPodPalette(components.NewPodPalette(0, 0))
+	app.SetWorkloadList(components.NewWorkloadList(0, 0))
+	app.SetEventList(components.NewEventList(0, 0))
+	app.SetPortForwardList(components.NewPortForwardList(0, 0))
+	app.SetPodDescribe(components.NewPodDescribe(0, 0))
+	app.SetManifestViewer(components.NewManifestViewer(0, 0))
+	app.SetRolloutHistory(components.NewRolloutHistory(0, 0))
+	app.SetFileBrowser(components.NewFileBrowser(0, 0))
+	app.SetEditReview(components.NewEditReview(0, 0))
 
 	// Create and run the Bubble Tea program
 	program := tea.NewProgram(
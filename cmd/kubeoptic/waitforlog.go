@@ -0,0 +1,209 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/kubernetes"
+
+	"kubeoptic/internal/services"
+)
+
+// Exit codes of the wait-for-log command
+const (
+	waitSucceeded = 0
+	waitFailed    = 1
+	waitTimedOut  = 2
+)
+
+// waitPodInterval is how often wait-for-log looks for pods to follow, such
+// as pods a rollout starts after it began waiting
+var waitPodInterval = 2 * time.Second
+
+// logFollower follows the logs of pods as followLogs does, handing each line
+// to handle until the logs end, the context is done or handle fails
+type logFollower func(ctx context.Context, pods []services.Pod, handle func(services.LogLine) error) error
+
+// podLogsEnded tells that the logs of a pod stopped being followed, and why
+// when they failed. The pod is followed again when next found, so the logs
+// of a container that restarted, such as after a crash, are read too.
+type podLogsEnded struct {
+	pod string
+	err error
+}
+
+// waitForLog follows the logs of a target's pods, those found while waiting
+// included, until a line matches success or failure, handing that line to
+// matched. It returns the exit code, with an error saying why when no line
+// decided it.
+func waitForLog(ctx context.Context, client kubernetes.Interface, target logTarget, followPods logFollower, success, failure *regexp.Regexp, matched func(services.LogLine) error) (int, error) {
+	lines := make(chan services.LogLine)
+	ended := make(chan podLogsEnded)
+	followed := make(map[string]bool)
+
+	follow := func(pod services.Pod) {
+		err := followPods(ctx, []services.Pod{pod}, func(line services.LogLine) error {
+			select {
+			case lines <- line:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+		select {
+		case ended <- podLogsEnded{pod: pod.Name, err: err}:
+		case <-ctx.Done():
+		}
+	}
+
+	// Pods not found yet, such as those of a workload being created, are
+	// looked for again until the timeout
+	var lastErr error
+	findPods := func() {
+		pods, err := target.pods(ctx, client)
+		if err != nil {
+			lastErr = err
+		}
+		for _, pod := range pods {
+			if !followed[pod.Name] {
+				followed[pod.Name] = true
+				go follow(pod)
+			}
+		}
+	}
+
+	ticker := time.NewTicker(waitPodInterval)
+	defer ticker.Stop()
+	findPods()
+	for {
+		select {
+		case line := <-lines:
+			switch {
+			case failure != nil && failure.MatchString(line.Text):
+				return waitFailed, matched(line)
+			case success.MatchString(line.Text):
+				return waitSucceeded, matched(line)
+			}
+
+		case end := <-ended:
+			if end.err != nil {
+				if !retryableLogError(end.err, target) {
+					return waitFailed, end.err
+				}
+				lastErr = end.err
+			}
+			delete(followed, end.pod)
+
+		case <-ticker.C:
+			findPods()
+
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				if lastErr != nil {
+					return waitTimedOut, fmt.Errorf("timed out waiting for a matching line: %w", lastErr)
+				}
+				return waitTimedOut, fmt.Errorf("timed out waiting for a matching line")
+			}
+			return waitFailed, fmt.Errorf("interrupted while waiting for a matching line")
+		}
+	}
+}
+
+// retryableLogError reports whether following a pod's logs may succeed when
+// tried again, as for a pod still starting. Being forbidden, a bad request
+// such as naming a container the pod doesn't have, or a named pod that
+// doesn't exist fail the wait at once.
+func retryableLogError(err error, target logTarget) bool {
+	switch {
+	case apierrors.IsForbidden(err):
+		return false
+	case apierrors.IsBadRequest(err):
+		// The API server answers a bad request for pods not running yet
+		message := err.Error()
+		return strings.Contains(message, "is waiting to start") || strings.Contains(message, "does not have a host assigned")
+	case apierrors.IsNotFound(err):
+		return target.pod == ""
+	}
+	return true
+}
+
+func runWaitForLog(args []string) int {
+	flags := commandFlags("wait-for-log", "[flags] --selector QUERY --success REGEX",
+		"Follow the logs of the pods matching a query, or of a pod or workload, until a\n"+
+			"line matches --success or --failure, and print that line. Only lines logged\n"+
+			"since the wait started count, unless --since reaches back further. Pods that\n"+
+			"start while waiting are followed too. Exits 0 on success, 1 on failure or\n"+
+			"error and 2 when --timeout passes first.")
+	var target logTargetFlags
+	target.register(flags)
+	successPattern := flags.String("success", "", "regular expression of the line to wait for")
+	failurePattern := flags.String("failure", "", "regular expression of a line that fails the wait at once")
+	timeout := flags.Duration("timeout", 5*time.Minute, "how long to wait for a matching line")
+	since := flags.Duration("since", 0, "also match lines logged this long before the wait started, e.g. 5m; only later lines by default")
+	var output string
+	flags.StringVar(&output, "output", outputPrefixed, "output template of the matching line: raw, prefixed or json")
+	flags.StringVar(&output, "o", outputPrefixed, "shorthand for --output")
+
+	positional, err := parseArgs(flags, args)
+	if err != nil {
+		return flagsExitCode(err)
+	}
+	switch output {
+	case outputRaw, outputPrefixed, outputJSON:
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unknown output %q: use raw, prefixed or json\n", output)
+		return waitFailed
+	}
+	if *successPattern == "" {
+		fmt.Fprintf(os.Stderr, "Error: --success is required\n")
+		return waitFailed
+	}
+	success, err := regexp.Compile(*successPattern)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid --success: %v\n", err)
+		return waitFailed
+	}
+	var failure *regexp.Regexp
+	if *failurePattern != "" {
+		if failure, err = regexp.Compile(*failurePattern); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid --failure: %v\n", err)
+			return waitFailed
+		}
+	}
+
+	if *since < 0 {
+		fmt.Fprintf(os.Stderr, "Error: --since must not be negative\n")
+		return waitFailed
+	}
+	// Lines logged before the wait, such as an earlier pod's "ready", don't
+	// decide it
+	started := time.Now()
+
+	client, logTarget, err := target.resolve(positional)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return waitFailed
+	}
+
+	ctx, stop := interruptContext()
+	defer stop()
+	ctx, cancel := context.WithTimeout(ctx, *timeout)
+	defer cancel()
+
+	options := services.LogOptions{Container: logTarget.container, SinceTime: started.Add(-*since)}
+	follow := func(ctx context.Context, pods []services.Pod, handle func(services.LogLine) error) error {
+		return followLogs(ctx, client, pods, options, handle)
+	}
+	printer := newLogPrinter(os.Stdout, output, logTarget.namespace)
+	code, err := waitForLog(ctx, client, logTarget, follow, success, failure, printer.print)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	}
+	return code
+}
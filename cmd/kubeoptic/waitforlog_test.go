@@ -0,0 +1,281 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"kubeoptic/internal/services"
+)
+
+// fakeLogs follows pods by their scripts: the lines each follow of a pod
+// logs, then the error it ends with. A follow without an error stays open
+// until the wait ends, as the logs of a running pod do, unless it ends as
+// those of a container that exited do.
+type fakeLogs struct {
+	mu      sync.Mutex
+	scripts map[string][]fakeFollow
+	follows map[string]int
+}
+
+type fakeFollow struct {
+	lines []string
+	err   error
+	end   bool
+}
+
+func (f *fakeLogs) follow(ctx context.Context, pods []services.Pod, handle func(services.LogLine) error) error {
+	pod := pods[0].Name
+	f.mu.Lock()
+	scripts := f.scripts[pod]
+	script := scripts[min(f.follows[pod], len(scripts)-1)]
+	f.follows[pod]++
+	f.mu.Unlock()
+
+	for _, line := range script.lines {
+		if err := handle(services.LogLine{Pod: pod, Text: line, Level: services.LogLevelOf(line)}); err != nil {
+			return err
+		}
+	}
+	if script.err != nil || script.end {
+		return script.err
+	}
+	<-ctx.Done()
+	return nil
+}
+
+func testPod(name string) *corev1.Pod {
+	return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team", Labels: map[string]string{"app": "api"}}}
+}
+
+func TestWaitForLog(t *testing.T) {
+	defer func(interval time.Duration) { waitPodInterval = interval }(waitPodInterval)
+	waitPodInterval = 10 * time.Millisecond
+
+	podsResource := schema.GroupResource{Resource: "pods"}
+	selector := logTarget{namespace: "team", selector: "app=api"}
+	named := logTarget{namespace: "team", pod: "api-1"}
+
+	tests := []struct {
+		name        string
+		target      logTarget
+		pods        []string
+		scripts     map[string][]fakeFollow
+		createLater string
+		interrupt   bool
+		wantCode    int
+		wantLine    string
+		wantErr     string
+	}{
+		{
+			name:     "success",
+			target:   selector,
+			pods:     []string{"api-1"},
+			scripts:  map[string][]fakeFollow{"api-1": {{lines: []string{"starting", "server ready"}}}},
+			wantCode: waitSucceeded,
+			wantLine: "server ready",
+		},
+		{
+			name:     "failure",
+			target:   selector,
+			pods:     []string{"api-1", "api-2"},
+			scripts:  map[string][]fakeFollow{"api-1": {{lines: []string{"starting"}}}, "api-2": {{lines: []string{"FATAL bad config"}}}},
+			wantCode: waitFailed,
+			wantLine: "FATAL bad config",
+		},
+		{
+			name:     "failure wins over success on a line",
+			target:   selector,
+			pods:     []string{"api-1"},
+			scripts:  map[string][]fakeFollow{"api-1": {{lines: []string{"ready but FATAL"}}}},
+			wantCode: waitFailed,
+			wantLine: "ready but FATAL",
+		},
+		{
+			name:     "timeout",
+			target:   selector,
+			pods:     []string{"api-1"},
+			scripts:  map[string][]fakeFollow{"api-1": {{lines: []string{"starting"}}}},
+			wantCode: waitTimedOut,
+			wantErr:  "timed out waiting for a matching line",
+		},
+		{
+			name:      "interrupt",
+			target:    selector,
+			pods:      []string{"api-1"},
+			scripts:   map[string][]fakeFollow{"api-1": {{lines: []string{"starting"}}}},
+			interrupt: true,
+			wantCode:  waitFailed,
+			wantErr:   "interrupted",
+		},
+		{
+			name:        "pod appearing while waiting",
+			target:      selector,
+			scripts:     map[string][]fakeFollow{"api-2": {{lines: []string{"server ready"}}}},
+			createLater: "api-2",
+			wantCode:    waitSucceeded,
+			wantLine:    "server ready",
+		},
+		{
+			name:     "timeout without pods says why",
+			target:   selector,
+			wantCode: waitTimedOut,
+			wantErr:  `no pods match "app=api"`,
+		},
+		{
+			name:   "pod still starting is followed again",
+			target: selector,
+			pods:   []string{"api-1"},
+			scripts: map[string][]fakeFollow{"api-1": {
+				{err: apierrors.NewBadRequest(`container "api" in pod "api-1" is waiting to start: ContainerCreating`)},
+				{lines: []string{"server ready"}},
+			}},
+			wantCode: waitSucceeded,
+			wantLine: "server ready",
+		},
+		{
+			name:   "logs ending early are followed again",
+			target: selector,
+			pods:   []string{"api-1"},
+			scripts: map[string][]fakeFollow{"api-1": {
+				{lines: []string{"starting"}, err: errors.New("connection reset")},
+				{lines: []string{"starting", "server ready"}},
+			}},
+			wantCode: waitSucceeded,
+			wantLine: "server ready",
+		},
+		{
+			name:   "restarted container is followed again",
+			target: selector,
+			pods:   []string{"api-1"},
+			scripts: map[string][]fakeFollow{"api-1": {
+				{lines: []string{"starting", "panic: nil map"}, end: true},
+				{lines: []string{"starting", "server ready"}},
+			}},
+			wantCode: waitSucceeded,
+			wantLine: "server ready",
+		},
+		{
+			name:     "forbidden",
+			target:   selector,
+			pods:     []string{"api-1"},
+			scripts:  map[string][]fakeFollow{"api-1": {{err: apierrors.NewForbidden(podsResource, "api-1", errors.New("cannot get pods/log"))}}},
+			wantCode: waitFailed,
+			wantErr:  "forbidden",
+		},
+		{
+			name:     "bad request",
+			target:   selector,
+			pods:     []string{"api-1"},
+			scripts:  map[string][]fakeFollow{"api-1": {{err: apierrors.NewBadRequest(`a container name must be specified for pod api-1`)}}},
+			wantCode: waitFailed,
+			wantErr:  "a container name must be specified",
+		},
+		{
+			name:     "named pod not found",
+			target:   named,
+			scripts:  map[string][]fakeFollow{"api-1": {{err: apierrors.NewNotFound(podsResource, "api-1")}}},
+			wantCode: waitFailed,
+			wantErr:  "not found",
+		},
+		{
+			name:     "selected pod gone",
+			target:   selector,
+			pods:     []string{"api-1"},
+			scripts:  map[string][]fakeFollow{"api-1": {{err: apierrors.NewNotFound(podsResource, "api-1")}}},
+			wantCode: waitTimedOut,
+			wantErr:  "not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewClientset()
+			for _, name := range tt.pods {
+				if _, err := client.CoreV1().Pods("team").Create(context.Background(), testPod(name), metav1.CreateOptions{}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			logs := &fakeLogs{scripts: tt.scripts, follows: make(map[string]int)}
+
+			timeout := 5 * time.Second
+			if tt.wantCode == waitTimedOut {
+				timeout = 100 * time.Millisecond
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+			if tt.interrupt {
+				time.AfterFunc(50*time.Millisecond, cancel)
+			}
+			if tt.createLater != "" {
+				time.AfterFunc(30*time.Millisecond, func() {
+					client.CoreV1().Pods("team").Create(context.Background(), testPod(tt.createLater), metav1.CreateOptions{})
+				})
+			}
+
+			var matched []services.LogLine
+			code, err := waitForLog(ctx, client, tt.target, logs.follow, regexp.MustCompile("ready"), regexp.MustCompile("FATAL"), func(line services.LogLine) error {
+				matched = append(matched, line)
+				return nil
+			})
+
+			if code != tt.wantCode {
+				t.Errorf("waitForLog() = %d, want %d (error %v)", code, tt.wantCode, err)
+			}
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("waitForLog() error = %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("waitForLog() error = %v, want one containing %q", err, tt.wantErr)
+			}
+			switch {
+			case tt.wantLine == "" && len(matched) > 0:
+				t.Errorf("Expected no line to match, got %+v", matched)
+			case tt.wantLine != "" && (len(matched) != 1 || matched[0].Text != tt.wantLine):
+				t.Errorf("Expected %q to match, got %+v", tt.wantLine, matched)
+			}
+		})
+	}
+}
+
+func TestRetryableLogError(t *testing.T) {
+	pods := schema.GroupResource{Resource: "pods"}
+	selector := logTarget{selector: "app=api"}
+	named := logTarget{pod: "api-1"}
+
+	tests := []struct {
+		name   string
+		err    error
+		target logTarget
+		want   bool
+	}{
+		{"network error", errors.New("connection refused"), named, true},
+		{"wrapped forbidden", wrap(apierrors.NewForbidden(pods, "api-1", errors.New("no"))), selector, false},
+		{"waiting to start", apierrors.NewBadRequest(`container "api" in pod "api-1" is waiting to start: PodInitializing`), named, true},
+		{"not scheduled", apierrors.NewBadRequest(`pod api-1 does not have a host assigned`), named, true},
+		{"no such container", apierrors.NewBadRequest(`container sidecar is not valid for pod api-1`), selector, false},
+		{"named pod not found", wrap(apierrors.NewNotFound(pods, "api-1")), named, false},
+		{"selected pod not found", apierrors.NewNotFound(pods, "api-1"), selector, true},
+	}
+	for _, tt := range tests {
+		if got := retryableLogError(tt.err, tt.target); got != tt.want {
+			t.Errorf("%s: retryableLogError() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// wrap wraps an error as the pod service does
+func wrap(err error) error {
+	return fmt.Errorf("failed to stream logs for pod team/api-1: %w", err)
+}
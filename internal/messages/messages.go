@@ -1,6 +1,10 @@
 package messages
 
 import (
+	"io"
+
+	"k8s.io/client-go/kubernetes"
+
 	"kubeoptic/internal/services"
 )
 
@@ -30,12 +34,53 @@ type ContextsLoadedMsg struct {
 
 type NamespacesLoadedMsg struct {
 	Namespaces []string
+	Detailed   []services.Namespace
 	Error      error
+	RequestID  int
 }
 
 type PodsLoadedMsg struct {
-	Pods  []services.Pod
-	Error error
+	Pods      []services.Pod
+	Namespace string
+	Query     string
+	Error     error
+	RequestID int
+}
+
+// PodTableChangedMsg reports new pod table preferences to be saved
+type PodTableChangedMsg struct {
+	Columns         []string
+	SortBy          string
+	SortDescending  bool
+	GroupByWorkload bool
+}
+
+// KubeconfigReloadedMsg reports the result of reloading a changed kubeconfig
+type KubeconfigReloadedMsg struct {
+	Contexts       []services.Context
+	CurrentContext string
+	ClientRebuilt  bool
+
+	// ContextChanged tells that the active context was removed from the
+	// kubeconfig and the current one taken instead
+	ContextChanged bool
+	Error          error
+}
+
+// KubeconfigLoadedMsg carries a kubeconfig re-read in the background after
+// a check found it changed; Changed is false when it was not. Context is the
+// context that was active when it was read. Client is built for it when its
+// credentials changed, or for SwitchTo, the kubeconfig's current context,
+// when Context was removed; Fingerprint identifies the credentials of Client.
+type KubeconfigLoadedMsg struct {
+	Changed        bool
+	Contexts       []services.Context
+	CurrentContext string
+	Context        string
+	SwitchTo       string
+	Client         *kubernetes.Clientset
+	Fingerprint    string
+	Error          error
 }
 
 type ContextSelectedMsg struct {
@@ -46,15 +91,321 @@ type PodSelectedMsg struct {
 	Pod *services.Pod
 }
 
+// WorkloadsLoadedMsg carries the workloads owning a namespace's pods
+type WorkloadsLoadedMsg struct {
+	Workloads []services.Workload
+	Namespace string
+	Error     error
+	RequestID int
+}
+
+// WorkloadSelectedMsg tails the logs of every pod of a workload
+type WorkloadSelectedMsg struct {
+	Workload *services.Workload
+	Pods     []services.Pod
+}
+
+// WorkloadListLoadedMsg carries the workloads of one kind for the workload view
+type WorkloadListLoadedMsg struct {
+	Kind      string
+	Namespace string
+	Workloads []services.Workload
+	Error     error
+	RequestID int
+}
+
+// WorkloadKindSelectedMsg switches the workload view to another kind
+type WorkloadKindSelectedMsg struct {
+	Kind string
+}
+
+// WorkloadDrillDownMsg shows the pods of a workload
+type WorkloadDrillDownMsg struct {
+	Workload *services.Workload
+}
+
+// EventsLoadedMsg carries the events of a namespace, oldest first
+type EventsLoadedMsg struct {
+	Namespace string
+	Events    []services.Event
+	Error     error
+	RequestID int
+}
+
+// EventsRequestedMsg opens the events panel, for one pod or, when Pod is
+// nil, the whole namespace
+type EventsRequestedMsg struct {
+	Pod *services.Pod
+}
+
+// LogEventsToggledMsg reports whether pod events are interleaved into the logs
+type LogEventsToggledMsg struct {
+	Enabled bool
+}
+
+// DescribeRequestedMsg opens the describe view of a pod, showing its
+// details or its timeline
+type DescribeRequestedMsg struct {
+	Pod      *services.Pod
+	Timeline bool
+}
+
+// PodDescribedMsg carries the details of the described pod
+type PodDescribedMsg struct {
+	Description *services.PodDescription
+	Error       error
+	RequestID   int
+}
+
+// ManifestRequestedMsg opens the YAML view of a pod or workload
+type ManifestRequestedMsg struct {
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+// ManifestLoadedMsg carries the YAML of the resource in the YAML view
+type ManifestLoadedMsg struct {
+	Manifest  *services.Manifest
+	Error     error
+	RequestID int
+}
+
+// EditRequestedMsg fetches the YAML of a pod or workload and opens it in
+// the user's editor
+type EditRequestedMsg struct {
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+// EditorRequestedMsg opens an edit in the user's editor, with the YAML as
+// last edited or, the first time, as fetched
+type EditorRequestedMsg struct {
+	Edit *services.ManifestEdit
+}
+
+// EditorClosedMsg reports that the editor exited and the UI is back, with
+// the saved YAML in the edit
+type EditorClosedMsg struct {
+	Edit  *services.ManifestEdit
+	Error error
+}
+
+// EditCheckedMsg carries the server-side dry run of an edit, or the
+// validation or admission error it failed with
+type EditCheckedMsg struct {
+	Edit      *services.ManifestEdit
+	Error     error
+	RequestID int
+}
+
+// EditAppliedMsg reports that a confirmed edit was saved, or why not
+type EditAppliedMsg struct {
+	Edit  *services.ManifestEdit
+	Error error
+}
+
+// MetricsLoadedMsg carries what pods currently use. Error is
+// services.ErrMetricsUnavailable when the cluster serves no metrics.
+type MetricsLoadedMsg struct {
+	Metrics   []services.PodMetrics
+	Error     error
+	RequestID int
+}
+
+// ExecRequestedMsg opens a shell in a container of a pod; Shell is one of
+// the services.Shell constants
+type ExecRequestedMsg struct {
+	Pod       *services.Pod
+	Container string
+	Shell     string
+}
+
+// DebugContainerStartedMsg reports that an ephemeral debug container of a
+// pod runs, ready to be attached to
+type DebugContainerStartedMsg struct {
+	Namespace string
+	Pod       string
+	Container string
+}
+
+// ExecFinishedMsg reports that a shell or debug session ended and the UI is
+// back
+type ExecFinishedMsg struct {
+	Pod       string
+	Container string
+	Error     error
+}
+
+// Actions that change the cluster, each carried out only once confirmed
+const (
+	ActionDeletePod      = "delete"
+	ActionForceDeletePod = "force-delete"
+	ActionRestart        = "restart"
+	ActionScale          = "scale"
+	ActionRollback       = "rollback"
+	ActionDebug          = "debug"
+	ActionEdit           = "edit"
+)
+
+// ActionRequestedMsg asks to change a resource: Kind and Name are the pod
+// to delete or debug, the workload to restart, scale or roll back, or the
+// resource edited. A restart asked for from a pod may name its controller,
+// such as a ReplicaSet, which is resolved to the workload before asking for
+// confirmation. Replicas is the count to scale to and Revision the revision
+// to roll back to. A debug container runs Image, seeing the processes of
+// the pod's Container. An edit replaces the resource with the Manifest YAML.
+type ActionRequestedMsg struct {
+	Action    string
+	Namespace string
+	Kind      string
+	Name      string
+	Replicas  int32
+	Revision  int64
+	Container string
+	Image     string
+	Manifest  string
+}
+
+// RolloutStartedMsg reports that a confirmed action set a workload rolling
+// out, which is followed until it settles
+type RolloutStartedMsg struct {
+	Namespace string
+	Kind      string
+	Name      string
+	Message   string
+}
+
+// RolloutStatusMsg carries how far a followed rollout has got
+type RolloutStatusMsg struct {
+	Namespace string
+	Kind      string
+	Name      string
+	Status    services.RolloutStatus
+	Error     error
+}
+
+// RolloutHistoryRequestedMsg opens the revision history of a workload
+type RolloutHistoryRequestedMsg struct {
+	Workload *services.Workload
+}
+
+// RolloutHistoryLoadedMsg carries the revisions of the workload in the
+// history view, oldest first
+type RolloutHistoryLoadedMsg struct {
+	Revisions []services.Revision
+	Error     error
+	RequestID int
+}
+
+// PortForwardRequestedMsg starts forwarding a local port to a port of a pod,
+// or of a ready pod backing a Service when Service is set instead. A zero
+// LocalPort picks any free port, and a zero RemotePort the Service's first.
+type PortForwardRequestedMsg struct {
+	Namespace  string
+	Pod        string
+	Service    string
+	LocalPort  int
+	RemotePort int
+}
+
+// PortForwardStartedMsg reports a forward listening, or failing to start
+type PortForwardStartedMsg struct {
+	Forward *services.PortForward
+	Error   error
+}
+
+// PortForwardEndedMsg reports a forward ending by itself, as when its pod goes away
+type PortForwardEndedMsg struct {
+	Forward *services.PortForward
+}
+
+// PortForwardStopMsg stops a forward and drops it from the list
+type PortForwardStopMsg struct {
+	Forward *services.PortForward
+}
+
+// PortForwardsChangedMsg carries the forwards the port-forward panel lists,
+// and the namespace new forwards go to unless another is given
+type PortForwardsChangedMsg struct {
+	Forwards  []*services.PortForward
+	Namespace string
+}
+
+// FilesRequestedMsg opens the file browser on a directory of a container
+// of a pod, or moves it to another directory or container
+type FilesRequestedMsg struct {
+	Pod       *services.Pod
+	Container string
+	Path      string
+}
+
+// FilesLoadedMsg carries the entries of the directory the file browser shows
+type FilesLoadedMsg struct {
+	Path      string
+	Files     []services.RemoteFile
+	Error     error
+	RequestID int
+}
+
+// DownloadRequestedMsg starts copying a file or directory out of a container
+type DownloadRequestedMsg struct {
+	Request services.DownloadRequest
+}
+
+// DownloadStartedMsg reports a download started, or why it couldn't start
+type DownloadStartedMsg struct {
+	Download *services.Download
+	Error    error
+}
+
+// DownloadEndedMsg reports that a download finished, failed or was cancelled
+type DownloadEndedMsg struct {
+	Download *services.Download
+}
+
+// DownloadCancelMsg cancels a running download
+type DownloadCancelMsg struct {
+	Download *services.Download
+}
+
+// DownloadsChangedMsg carries the downloads the file browser shows
+type DownloadsChangedMsg struct {
+	Downloads []*services.Download
+}
+
+// AllPodsLoadedMsg carries the pods of every namespace, for the jump palette
+type AllPodsLoadedMsg struct {
+	Pods      []services.Pod
+	Error     error
+	RequestID int
+}
+
+// PaletteOpenedMsg resets the jump palette when it is shown
+type PaletteOpenedMsg struct{}
+
+// PaletteClosedMsg closes the jump palette without jumping
+type PaletteClosedMsg struct{}
+
+// PodJumpMsg jumps to a pod in any namespace and opens its logs
+type PodJumpMsg struct {
+	Pod *services.Pod
+}
+
 // Log Messages
 type LogChunkMsg struct {
-	Data  string
-	EOF   bool
-	Error error
+	Data      string
+	EOF       bool
+	Error     error
+	RequestID int
 }
 
 type LogStreamStartedMsg struct {
-	Pod *services.Pod
+	Pod       *services.Pod
+	Stream    io.ReadCloser
+	Error     error
+	RequestID int
 }
 
 type LogStreamStoppedMsg struct {
@@ -111,6 +462,28 @@ type ShutdownMsg struct{}
 
 type RefreshDataMsg struct{}
 
+// ResourcesChangedMsg signals that watched namespaces or pods changed in the cluster
+type ResourcesChangedMsg struct{}
+
+// KubeconfigTickMsg triggers a check of the kubeconfig file(s) for changes
+type KubeconfigTickMsg struct{}
+
+// PortForwardTickMsg refreshes the traffic counts of the port-forward panel
+type PortForwardTickMsg struct{}
+
+// MetricsTickMsg triggers a refresh of pod resource usage
+type MetricsTickMsg struct{}
+
+// DownloadTickMsg refreshes the progress of running downloads
+type DownloadTickMsg struct{}
+
+// RolloutTickMsg triggers the next check of a followed rollout
+type RolloutTickMsg struct {
+	Namespace string
+	Kind      string
+	Name      string
+}
+
 // Loading state messages
 type LoadingStartedMsg struct {
 	Component string
@@ -120,6 +493,23 @@ type LoadingCompletedMsg struct {
 	Component string
 }
 
+// Components reported by the loading state messages
+const (
+	LoadingNamespaces   = "namespaces"
+	LoadingPods         = "pods"
+	LoadingLogs         = "logs"
+	LoadingAllPods      = "allPods"
+	LoadingWorkloads    = "workloads"
+	LoadingWorkloadList = "workloadList"
+	LoadingEvents       = "events"
+	LoadingDescribe     = "describe"
+	LoadingManifest     = "manifest"
+	LoadingMetrics      = "metrics"
+	LoadingRollout      = "rollout"
+	LoadingFiles        = "files"
+	LoadingEdit         = "edit"
+)
+
 // StatusType represents the type of status message
 type StatusType int
 
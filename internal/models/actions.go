@@ -0,0 +1,133 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"kubeoptic/internal/messages"
+	"kubeoptic/internal/services"
+	"kubeoptic/pkg/config"
+)
+
+// errReadOnly refuses changes to the cluster in read-only mode
+var errReadOnly = errors.New("read-only mode: nothing in the cluster can be changed")
+
+// SetSafety sets the guards against changing clusters by mistake: global
+// and per-context read-only mode, and which contexts are production ones
+func (k *Kubeoptic) SetSafety(safety config.Safety) {
+	k.safety = safety
+}
+
+// SetReadOnly turns global read-only mode on or off. In read-only mode no
+// action changes the cluster.
+func (k *Kubeoptic) SetReadOnly(readOnly bool) {
+	k.safety.ReadOnly = readOnly
+}
+
+// IsReadOnly reports whether changes to the selected context are refused
+func (k *Kubeoptic) IsReadOnly() bool {
+	return k.safety.IsReadOnly(k.selectedContext)
+}
+
+// IsProduction reports whether the selected context is a production one,
+// where changes need the context's name typed to confirm them
+func (k *Kubeoptic) IsProduction() bool {
+	return k.safety.IsProduction(k.selectedContext)
+}
+
+// ResolveActionCmd looks up the workload a restart asked for from a pod
+// acts on, answering with the request naming it, ready to confirm
+func (k *Kubeoptic) ResolveActionCmd(msg messages.ActionRequestedMsg) tea.Cmd {
+	if k.actionSvc == nil {
+		return statusCmd(fmt.Errorf("no cluster connection"))
+	}
+	svc := k.actionSvc
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), loadTimeout)
+		defer cancel()
+
+		workload, err := svc.WorkloadOf(ctx, msg.Namespace, services.OwnerRef{Kind: msg.Kind, Name: msg.Name})
+		if err != nil {
+			return actionStatus(loadError("workload", err), "")
+		}
+		msg.Kind, msg.Name = workload.Kind, workload.Name
+		return msg
+	}
+}
+
+// RunActionCmd carries out a confirmed action, reporting how it went in
+// the status bar. Watched pods show its effect as it happens; actions on a
+// workload answer with the rollout they started, to be followed, a debug
+// container is attached to once it runs, and an edit answers with whether
+// it was saved.
+func (k *Kubeoptic) RunActionCmd(msg messages.ActionRequestedMsg) tea.Cmd {
+	if k.IsReadOnly() {
+		return statusCmd(errReadOnly)
+	}
+	if k.actionSvc == nil {
+		return statusCmd(fmt.Errorf("no cluster connection"))
+	}
+	if msg.Action == messages.ActionDebug {
+		return k.debugCmd(msg)
+	}
+	svc := k.actionSvc
+	editSvc := k.editSvc
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), loadTimeout)
+		defer cancel()
+
+		target := fmt.Sprintf("%s %s/%s", strings.ToLower(msg.Kind), msg.Namespace, msg.Name)
+		workload := services.OwnerRef{Kind: msg.Kind, Name: msg.Name}
+		switch msg.Action {
+		case messages.ActionDeletePod:
+			err := svc.DeletePod(ctx, msg.Namespace, msg.Name, false)
+			return actionStatus(err, "Deleted "+target)
+		case messages.ActionForceDeletePod:
+			err := svc.DeletePod(ctx, msg.Namespace, msg.Name, true)
+			return actionStatus(err, "Force-deleted "+target)
+		case messages.ActionRestart:
+			err := svc.RestartWorkload(ctx, msg.Namespace, workload)
+			return rolloutStarted(msg, err, "Restarting "+target)
+		case messages.ActionScale:
+			err := svc.ScaleWorkload(ctx, msg.Namespace, workload, msg.Replicas)
+			return rolloutStarted(msg, err, fmt.Sprintf("Scaling %s to %d", target, msg.Replicas))
+		case messages.ActionRollback:
+			err := svc.RollbackWorkload(ctx, msg.Namespace, workload, msg.Revision)
+			return rolloutStarted(msg, err, fmt.Sprintf("Rolling back %s to revision %d", target, msg.Revision))
+		case messages.ActionEdit:
+			return applyEdit(ctx, editSvc, msg)
+		default:
+			return actionStatus(fmt.Errorf("unknown action %q", msg.Action), "")
+		}
+	}
+}
+
+// rolloutStarted reports a workload action that sets it rolling out, for
+// its progress to be followed, or the error it failed with
+func rolloutStarted(msg messages.ActionRequestedMsg, err error, started string) tea.Msg {
+	if err != nil {
+		return actionStatus(err, "")
+	}
+	return messages.RolloutStartedMsg{Namespace: msg.Namespace, Kind: msg.Kind, Name: msg.Name, Message: started}
+}
+
+// actionStatus reports an action's outcome for the status bar
+func actionStatus(err error, done string) messages.StatusMsg {
+	if err != nil {
+		return messages.StatusMsg{Message: err.Error(), Type: messages.StatusError}
+	}
+	return messages.StatusMsg{Message: done, Type: messages.StatusSuccess}
+}
+
+// statusCmd reports an error in the status bar
+func statusCmd(err error) tea.Cmd {
+	return func() tea.Msg {
+		return actionStatus(err, "")
+	}
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"kubeoptic/internal/messages"
+	"kubeoptic/internal/services"
+)
+
+// debugStartTimeout bounds adding a debug container and waiting for it to
+// run, which includes pulling its image
+const debugStartTimeout = 2 * time.Minute
+
+// debugCmd adds an ephemeral debug container to a pod and waits for it to
+// run, answering with the container to attach to
+func (k *Kubeoptic) debugCmd(msg messages.ActionRequestedMsg) tea.Cmd {
+	svc := k.actionSvc
+	req := services.DebugRequest{Namespace: msg.Namespace, Pod: msg.Name, Target: msg.Container, Image: msg.Image}
+
+	started := func() tea.Msg {
+		return messages.StatusMsg{Message: fmt.Sprintf("Starting %s in pod %s/%s", req.Image, req.Namespace, req.Pod), Type: messages.StatusInfo}
+	}
+	debug := func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), debugStartTimeout)
+		defer cancel()
+
+		container, err := svc.AddDebugContainer(ctx, req)
+		if err == nil {
+			err = svc.WaitForEphemeralContainer(ctx, req.Namespace, req.Pod, container)
+		}
+		if err != nil {
+			return actionStatus(err, "")
+		}
+		return messages.DebugContainerStartedMsg{Namespace: req.Namespace, Pod: req.Pod, Container: container}
+	}
+	return tea.Batch(started, debug)
+}
+
+// AttachCmd suspends the UI and attaches the terminal to a debug container,
+// bringing the UI back as it was when the container's shell exits
+func (k *Kubeoptic) AttachCmd(namespace, pod, container string) tea.Cmd {
+	config, err := k.restConfig()
+	if err != nil {
+		return errorCmd(err, "attaching to debug container")
+	}
+
+	session := &shellSession{
+		svc: services.NewExecService(k.client, config),
+		req: services.ExecRequest{
+			Namespace: namespace,
+			Pod:       pod,
+			Container: container,
+			TTY:       true,
+		},
+		attach: true,
+	}
+	return tea.Exec(session, func(err error) tea.Msg {
+		return messages.ExecFinishedMsg{Pod: pod, Container: container, Error: err}
+	})
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"kubeoptic/internal/messages"
+	"kubeoptic/internal/services"
+)
+
+// DescribePodCmd switches to the describe view of a pod and loads its details
+func (k *Kubeoptic) DescribePodCmd(pod services.Pod) tea.Cmd {
+	k.describedPod = &pod
+	k.focusedView = DescribeView
+	return k.RefreshDescriptionCmd()
+}
+
+// RefreshDescriptionCmd reloads the details of the described pod
+func (k *Kubeoptic) RefreshDescriptionCmd() tea.Cmd {
+	if k.describeSvc == nil {
+		return errorCmd(fmt.Errorf("no cluster connection"), "describing pod")
+	}
+	if k.describedPod == nil {
+		return nil
+	}
+
+	ctx, id := k.begin(messages.LoadingDescribe)
+	svc := k.describeSvc
+	pod := *k.describedPod
+
+	fetch := func() tea.Msg {
+		ctx, cancel := context.WithTimeout(ctx, loadTimeout)
+		defer cancel()
+
+		description, err := svc.DescribePod(ctx, pod.Namespace, pod.Name)
+		return messages.PodDescribedMsg{Description: description, Error: loadError("pod details", err), RequestID: id}
+	}
+
+	return withLoading(messages.LoadingDescribe, fetch)
+}
+
+// ApplyPodDescription accepts loaded pod details. It returns false for
+// results of superseded or cancelled loads, which callers should discard.
+func (k *Kubeoptic) ApplyPodDescription(msg messages.PodDescribedMsg) bool {
+	op := k.finish(messages.LoadingDescribe, msg.RequestID)
+	if op == nil {
+		return false
+	}
+	op.cancel()
+	return true
+}
+
+// HideDescription leaves the describe view for the pods of the namespace,
+// abandoning a pending load
+func (k *Kubeoptic) HideDescription() {
+	k.CancelLoad(messages.LoadingDescribe)
+	k.focusedView = PodView
+}
+
+// GetDescribedPod returns the pod shown in the describe view
+func (k *Kubeoptic) GetDescribedPod() *services.Pod {
+	return k.describedPod
+}
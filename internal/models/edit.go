@@ -0,0 +1,149 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"kubeoptic/internal/messages"
+	"kubeoptic/internal/services"
+)
+
+// EditCmd fetches the YAML of a pod or workload to edit, answering with the
+// edit to open in the editor. Managed fields are left out, as kubectl edit
+// does.
+func (k *Kubeoptic) EditCmd(kind, namespace, name string) tea.Cmd {
+	if k.IsReadOnly() {
+		return statusCmd(errReadOnly)
+	}
+	if k.manifestSvc == nil {
+		return statusCmd(fmt.Errorf("no cluster connection"))
+	}
+	svc := k.manifestSvc
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), loadTimeout)
+		defer cancel()
+
+		manifest, err := svc.GetManifest(ctx, kind, namespace, name)
+		if err != nil {
+			return actionStatus(loadError("manifest", err), "")
+		}
+		edit := &services.ManifestEdit{Kind: kind, Namespace: namespace, Name: name, Original: manifest.YAML}
+		return messages.EditorRequestedMsg{Edit: edit}
+	}
+}
+
+// OpenEditorCmd suspends the UI and opens the edit's YAML in the user's
+// editor, from a temporary file that is removed once the editor exits. An
+// edit opened again starts from the YAML as last saved.
+func (k *Kubeoptic) OpenEditorCmd(edit services.ManifestEdit) tea.Cmd {
+	text := edit.Edited
+	if text == "" {
+		text = edit.Original
+	}
+
+	file, err := os.CreateTemp("", fmt.Sprintf("kubeoptic-%s-%s-*.yaml", strings.ToLower(edit.Kind), edit.Name))
+	if err != nil {
+		return statusCmd(fmt.Errorf("failed to create the file to edit: %w", err))
+	}
+	path := file.Name()
+	_, err = file.WriteString(text)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return statusCmd(fmt.Errorf("failed to write the file to edit: %w", err))
+	}
+
+	return tea.ExecProcess(editorCommand(path), func(err error) tea.Msg {
+		defer os.Remove(path)
+		if err != nil {
+			return messages.EditorClosedMsg{Edit: &edit, Error: fmt.Errorf("editor failed: %w", err)}
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return messages.EditorClosedMsg{Edit: &edit, Error: fmt.Errorf("failed to read the edited file: %w", err)}
+		}
+		edit.Edited = string(data)
+		return messages.EditorClosedMsg{Edit: &edit}
+	})
+}
+
+// editorCommand runs the user's editor on a file: $KUBE_EDITOR or $EDITOR
+// as kubectl edit does, which may carry arguments such as code --wait, or
+// vi when neither is set
+func editorCommand(path string) *exec.Cmd {
+	editor := os.Getenv("KUBE_EDITOR")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	fields := strings.Fields(editor)
+	if len(fields) == 0 {
+		fields = []string{"vi"}
+	}
+	return exec.Command(fields[0], append(fields[1:], path)...)
+}
+
+// CheckEditCmd switches to the review of an edit and runs it on the server
+// as a dry run, to be diffed before it is applied
+func (k *Kubeoptic) CheckEditCmd(edit services.ManifestEdit) tea.Cmd {
+	if k.editSvc == nil {
+		return errorCmd(fmt.Errorf("no cluster connection"), "checking edit")
+	}
+
+	// Checking the edit again keeps the way back
+	if k.focusedView != EditView {
+		k.editReturn = k.focusedView
+	}
+	k.focusedView = EditView
+
+	ctx, id := k.begin(messages.LoadingEdit)
+	svc := k.editSvc
+
+	check := func() tea.Msg {
+		ctx, cancel := context.WithTimeout(ctx, loadTimeout)
+		defer cancel()
+
+		result, err := svc.DryRun(ctx, edit.Kind, edit.Namespace, edit.Name, edit.Edited)
+		if err != nil {
+			return messages.EditCheckedMsg{Edit: &edit, Error: loadError("dry run", err), RequestID: id}
+		}
+		edit.Result = result.YAML
+		edit.Diff = services.DiffLines(edit.Original, edit.Result)
+		return messages.EditCheckedMsg{Edit: &edit, RequestID: id}
+	}
+
+	return withLoading(messages.LoadingEdit, check)
+}
+
+// ApplyEditCheck accepts the dry run of an edit. It returns false for
+// results of superseded or cancelled checks, which callers should discard.
+func (k *Kubeoptic) ApplyEditCheck(msg messages.EditCheckedMsg) bool {
+	op := k.finish(messages.LoadingEdit, msg.RequestID)
+	if op == nil {
+		return false
+	}
+	op.cancel()
+	return true
+}
+
+// HideEdit leaves the edit review for the view it was opened from,
+// abandoning a pending dry run, and returns that view
+func (k *Kubeoptic) HideEdit() ViewType {
+	k.CancelLoad(messages.LoadingEdit)
+	k.focusedView = k.editReturn
+	return k.focusedView
+}
+
+// applyEdit saves a confirmed edit, answering with how it went
+func applyEdit(ctx context.Context, svc services.EditService, msg messages.ActionRequestedMsg) tea.Msg {
+	edit := &services.ManifestEdit{Kind: msg.Kind, Namespace: msg.Namespace, Name: msg.Name, Edited: msg.Manifest}
+	err := svc.Apply(ctx, msg.Kind, msg.Namespace, msg.Name, msg.Manifest)
+	return messages.EditAppliedMsg{Edit: edit, Error: err}
+}
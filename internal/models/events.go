@@ -0,0 +1,70 @@
+package models
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"kubeoptic/internal/messages"
+	"kubeoptic/internal/services"
+)
+
+// ShowEventsCmd switches to the events view of the selected namespace
+func (k *Kubeoptic) ShowEventsCmd() tea.Cmd {
+	k.focusedView = EventView
+	return k.LoadEventsCmd()
+}
+
+// HideEvents leaves the events view for the pods of the namespace,
+// abandoning a pending events load
+func (k *Kubeoptic) HideEvents() {
+	k.CancelLoad(messages.LoadingEvents)
+	k.focusedView = PodView
+}
+
+// LoadEventsCmd fetches the events of the selected namespace and starts
+// watching them, so new events show up as they happen
+func (k *Kubeoptic) LoadEventsCmd() tea.Cmd {
+	if k.eventSvc == nil {
+		return errorCmd(fmt.Errorf("no cluster connection"), "loading events")
+	}
+
+	ctx, id := k.begin(messages.LoadingEvents)
+	svc := k.eventSvc
+	namespace := k.selectedNamespace
+	k.watchEvents()
+
+	fetch := func() tea.Msg {
+		ctx, cancel := context.WithTimeout(ctx, loadTimeout)
+		defer cancel()
+
+		events, err := svc.ListEvents(ctx, namespace)
+		return messages.EventsLoadedMsg{Namespace: namespace, Events: events, Error: loadError("events", err), RequestID: id}
+	}
+
+	return withLoading(messages.LoadingEvents, fetch)
+}
+
+// ApplyEvents stores loaded events. It returns false for results of
+// superseded or cancelled loads, which callers should discard.
+func (k *Kubeoptic) ApplyEvents(msg messages.EventsLoadedMsg) bool {
+	op := k.finish(messages.LoadingEvents, msg.RequestID)
+	if op == nil {
+		return false
+	}
+	op.cancel()
+	if msg.Error == nil && msg.Namespace == k.selectedNamespace {
+		k.events = msg.Events
+		k.eventsNamespace = msg.Namespace
+	}
+	return true
+}
+
+// GetEvents returns the events of the selected namespace, oldest first
+func (k *Kubeoptic) GetEvents() []services.Event {
+	if k.eventsNamespace != k.selectedNamespace {
+		return nil
+	}
+	return k.events
+}
@@ -0,0 +1,138 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/muesli/cancelreader"
+	"golang.org/x/term"
+	"k8s.io/client-go/rest"
+
+	"kubeoptic/internal/messages"
+	"kubeoptic/internal/services"
+)
+
+// terminalSizePollInterval is how often an exec session checks whether the
+// terminal was resized
+const terminalSizePollInterval = 250 * time.Millisecond
+
+// ShellCmd suspends the UI and opens an interactive shell in a container of
+// a pod, bringing the UI back as it was when the shell exits
+func (k *Kubeoptic) ShellCmd(pod services.Pod, container, shell string) tea.Cmd {
+	config, err := k.restConfig()
+	if err != nil {
+		return errorCmd(err, "opening shell")
+	}
+
+	session := &shellSession{
+		svc: services.NewExecService(k.client, config),
+		req: services.ExecRequest{
+			Namespace: pod.Namespace,
+			Pod:       pod.Name,
+			Container: container,
+			Command:   services.ShellCommand(shell),
+			TTY:       true,
+		},
+	}
+	return tea.Exec(session, func(err error) tea.Msg {
+		return messages.ExecFinishedMsg{Pod: pod.Name, Container: container, Error: err}
+	})
+}
+
+// restConfig returns the REST config of the selected context, which
+// streaming connections such as exec sessions and port-forwards need
+func (k *Kubeoptic) restConfig() (*rest.Config, error) {
+	if k.client == nil {
+		return nil, fmt.Errorf("no cluster connection")
+	}
+	return k.configSvc.RESTConfigForContext(k.configPath, k.selectedContext)
+}
+
+// shellSession is an exec session attached to the terminal, which Bubble Tea
+// runs while the UI is suspended. An attach session connects to the
+// container's own process instead of running a command.
+type shellSession struct {
+	svc    services.ExecService
+	req    services.ExecRequest
+	attach bool
+}
+
+func (s *shellSession) SetStdin(r io.Reader)  { s.req.Stdin = r }
+func (s *shellSession) SetStdout(w io.Writer) { s.req.Stdout = w }
+func (s *shellSession) SetStderr(w io.Writer) { s.req.Stderr = w }
+
+// Run connects the terminal to the shell until it exits
+func (s *shellSession) Run() error {
+	// A raw terminal passes keys such as ctrl+c and tab on to the shell
+	if f, ok := s.req.Stdin.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		state, err := term.MakeRaw(int(f.Fd()))
+		if err != nil {
+			return fmt.Errorf("failed to set up the terminal: %w", err)
+		}
+		defer term.Restore(int(f.Fd()), state)
+	}
+
+	// Reading stdin is cancelled when the shell exits, so the key pressed
+	// next goes to the UI rather than to the finished session
+	if f, ok := s.req.Stdin.(*os.File); ok {
+		stdin, err := cancelreader.NewReader(f)
+		if err != nil {
+			return fmt.Errorf("failed to set up the terminal: %w", err)
+		}
+		defer stdin.Close()
+		defer stdin.Cancel()
+		s.req.Stdin = stdin
+	}
+
+	stop := make(chan struct{})
+	defer close(stop)
+	s.req.Resize = watchTerminalSize(s.req.Stdout, stop)
+
+	if s.attach {
+		// The process printed its prompt before anyone was attached
+		fmt.Fprint(s.req.Stdout, "If you don't see a command prompt, try pressing enter.\r\n")
+		return s.svc.Attach(context.Background(), s.req)
+	}
+	return s.svc.Exec(context.Background(), s.req)
+}
+
+// watchTerminalSize reports the size of the terminal an output writes to,
+// and again whenever it changes, until stopped. It polls, which works the
+// same on every platform.
+func watchTerminalSize(out io.Writer, stop <-chan struct{}) <-chan services.TerminalSize {
+	f, ok := out.(*os.File)
+	if !ok || !term.IsTerminal(int(f.Fd())) {
+		return nil
+	}
+
+	sizes := make(chan services.TerminalSize, 1)
+	go func() {
+		defer close(sizes)
+		var last services.TerminalSize
+		ticker := time.NewTicker(terminalSizePollInterval)
+		defer ticker.Stop()
+		for {
+			if width, height, err := term.GetSize(int(f.Fd())); err == nil {
+				size := services.TerminalSize{Width: uint16(width), Height: uint16(height)}
+				if size != last {
+					last = size
+					select {
+					case sizes <- size:
+					case <-stop:
+						return
+					}
+				}
+			}
+			select {
+			case <-ticker.C:
+			case <-stop:
+				return
+			}
+		}
+	}()
+	return sizes
+}
@@ -0,0 +1,103 @@
+package models
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"kubeoptic/internal/messages"
+	"kubeoptic/internal/services"
+)
+
+// ShowFilesCmd switches to the file browser and lists a directory of a
+// container of a pod
+func (k *Kubeoptic) ShowFilesCmd(pod services.Pod, container, dir string) tea.Cmd {
+	config, err := k.restConfig()
+	if err != nil {
+		return errorCmd(err, "browsing files")
+	}
+	svc := services.NewFileService(k.client, config)
+
+	// Moving to another directory keeps the way back
+	if k.focusedView != FilesView {
+		k.filesReturn = k.focusedView
+	}
+	k.focusedView = FilesView
+
+	ctx, id := k.begin(messages.LoadingFiles)
+
+	fetch := func() tea.Msg {
+		ctx, cancel := context.WithTimeout(ctx, loadTimeout)
+		defer cancel()
+
+		files, err := svc.List(ctx, pod.Namespace, pod.Name, container, dir)
+		return messages.FilesLoadedMsg{Path: dir, Files: files, Error: loadError("files", err), RequestID: id}
+	}
+
+	return withLoading(messages.LoadingFiles, fetch)
+}
+
+// ApplyFiles accepts a listed directory. It returns false for results of
+// superseded or cancelled loads, which callers should discard.
+func (k *Kubeoptic) ApplyFiles(msg messages.FilesLoadedMsg) bool {
+	op := k.finish(messages.LoadingFiles, msg.RequestID)
+	if op == nil {
+		return false
+	}
+	op.cancel()
+	return true
+}
+
+// HideFiles leaves the file browser for the view it was opened from,
+// abandoning a pending listing, and returns that view. Downloads go on.
+func (k *Kubeoptic) HideFiles() ViewType {
+	k.CancelLoad(messages.LoadingFiles)
+	k.focusedView = k.filesReturn
+	return k.focusedView
+}
+
+// StartDownloadCmd starts copying a file or directory out of a container.
+// Downloads keep running while other views are shown.
+func (k *Kubeoptic) StartDownloadCmd(req services.DownloadRequest) tea.Cmd {
+	config, err := k.restConfig()
+	if err != nil {
+		return errorCmd(err, "downloading")
+	}
+	svc := services.NewFileService(k.client, config)
+
+	return func() tea.Msg {
+		return messages.DownloadStartedMsg{Download: svc.Download(req)}
+	}
+}
+
+// ApplyDownload keeps a started download, returning a command that reports
+// when it ends
+func (k *Kubeoptic) ApplyDownload(msg messages.DownloadStartedMsg) tea.Cmd {
+	if msg.Error != nil || msg.Download == nil {
+		return nil
+	}
+	k.downloads = append(k.downloads, msg.Download)
+
+	download := msg.Download
+	return func() tea.Msg {
+		<-download.Done()
+		return messages.DownloadEndedMsg{Download: download}
+	}
+}
+
+// GetDownloads returns the downloads started, including those that ended
+func (k *Kubeoptic) GetDownloads() []*services.Download {
+	return k.downloads
+}
+
+// DownloadsRunning reports whether any download is still copying
+func (k *Kubeoptic) DownloadsRunning() bool {
+	for _, download := range k.downloads {
+		select {
+		case <-download.Done():
+		default:
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"kubeoptic/internal/messages"
+	"kubeoptic/internal/services"
+)
+
+// LoadAllPodsCmd fetches the pods of every namespace of the selected context
+// that the user can list, for jumping to a pod without knowing its namespace
+func (k *Kubeoptic) LoadAllPodsCmd() tea.Cmd {
+	if k.podSvc == nil {
+		return errorCmd(fmt.Errorf("no cluster connection"), "loading pods")
+	}
+
+	ctx, id := k.begin(messages.LoadingAllPods)
+	svc := k.podSvc
+	namespaces := make([]string, len(k.namespaces))
+	for i, ns := range k.namespaces {
+		namespaces[i] = ns.Name
+	}
+
+	fetch := func() tea.Msg {
+		ctx, cancel := context.WithTimeout(ctx, loadTimeout)
+		defer cancel()
+
+		pods, err := listAllPods(ctx, svc, namespaces)
+		return messages.AllPodsLoadedMsg{Pods: pods, Error: loadError("pods", err), RequestID: id}
+	}
+
+	return withLoading(messages.LoadingAllPods, fetch)
+}
+
+// ApplyAllPods claims the result of LoadAllPodsCmd. It returns false for
+// results of superseded or cancelled loads, which callers should discard.
+func (k *Kubeoptic) ApplyAllPods(msg messages.AllPodsLoadedMsg) bool {
+	op := k.finish(messages.LoadingAllPods, msg.RequestID)
+	if op == nil {
+		return false
+	}
+	op.cancel()
+	return true
+}
+
+// JumpToPodCmd switches to a pod's namespace and streams its logs, loading
+// the namespace's pods alongside so going back lands on them
+func (k *Kubeoptic) JumpToPodCmd(pod services.Pod) tea.Cmd {
+	k.CancelLoad(messages.LoadingLogs)
+	k.resetSearch()
+	if pod.Namespace != k.selectedNamespace {
+		k.workloads = nil
+		k.workloadList = nil
+	}
+	k.drillDown = nil
+	k.selectedNamespace = pod.Namespace
+	return tea.Batch(k.LoadPodsCmd(), k.SelectPodCmd(pod))
+}
+
+// listAllPods lists pods across all namespaces. Users who may not do that
+// get the pods of each known namespace they can list instead.
+func listAllPods(ctx context.Context, svc services.PodService, namespaces []string) ([]services.Pod, error) {
+	pods, err := svc.ListPods(ctx, metav1.NamespaceAll)
+	if err == nil || !apierrors.IsForbidden(err) {
+		return pods, err
+	}
+
+	pods = nil
+	for _, namespace := range namespaces {
+		nsPods, err := svc.ListPods(ctx, namespace)
+		if apierrors.IsForbidden(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		pods = append(pods, nsPods...)
+	}
+	return pods, nil
+}
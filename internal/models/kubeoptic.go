@@ -5,7 +5,12 @@ import (
 	"fmt"
 	"io"
 
+	tea "github.com/charmbracelet/bubbletea"
+	"k8s.io/client-go/kubernetes"
+
+	"kubeoptic/internal/messages"
 	"kubeoptic/internal/services"
+	"kubeoptic/pkg/config"
 )
 
 type ViewType int
@@ -15,6 +20,14 @@ const (
 	NamespaceView
 	PodView
 	LogView
+	WorkloadView
+	EventView
+	DescribeView
+	ManifestView
+	PortForwardView
+	RolloutView
+	FilesView
+	EditView
 )
 
 type Kubeoptic struct {
@@ -22,6 +35,22 @@ type Kubeoptic struct {
 	configSvc    services.ConfigService
 	podSvc       services.PodService
 	namespaceSvc services.NamespaceService
+	workloadSvc  services.WorkloadService
+	eventSvc     services.EventService
+	describeSvc  services.DescribeService
+	manifestSvc  services.ManifestService
+	metricsSvc   services.MetricsService
+	actionSvc    services.ActionService
+	rolloutSvc   services.RolloutService
+	editSvc      services.EditService
+
+	// Client the services were built with, for connections they don't make
+	client *kubernetes.Clientset
+
+	// Kubeconfig tracking
+	configPath        string
+	configWatcher     *services.KubeconfigWatcher
+	clientFingerprint string
 
 	// Navigation state
 	focusedView  ViewType
@@ -29,6 +58,45 @@ type Kubeoptic struct {
 	namespaces   []services.Namespace
 	pods         []services.Pod
 	filteredPods []services.Pod
+	workloads    []services.Workload
+
+	// Workload view: the kind shown, its workloads, and the workload whose
+	// pods are shown after drilling down
+	workloadKind string
+	workloadList []services.Workload
+	drillDown    *services.Workload
+
+	// Events of the namespace they were loaded for, kept live once loaded
+	events          []services.Event
+	eventsNamespace string
+
+	// Pod shown in the describe view
+	describedPod *services.Pod
+
+	// Resource usage of pods, nil when the cluster serves no metrics
+	podMetrics []services.PodMetrics
+
+	// Running port-forwards, which outlive views and context switches, and
+	// the view the port-forward panel was opened from
+	portForwards      []*services.PortForward
+	portForwardReturn ViewType
+
+	// View the YAML view was opened from, which closing it returns to
+	manifestReturn ViewType
+
+	// View the revision history was opened from, which closing it returns to
+	rolloutReturn ViewType
+
+	// Downloads out of containers, which outlive views, and the view the
+	// file browser was opened from
+	downloads   []*services.Download
+	filesReturn ViewType
+
+	// View the edit review was opened from, which closing it returns to
+	editReturn ViewType
+
+	// Guards against changing clusters by mistake
+	safety config.Safety
 
 	// Current selections
 	selectedContext   string
@@ -37,12 +105,23 @@ type Kubeoptic struct {
 
 	// Search state
 	podSearchQuery string
+	podQuery       services.PodQuery
 	showingXofY    string
 
-	// Log streaming
+	// Log streaming; logPods are the pods streamed together for a workload
+	logPods     []services.Pod
 	logBuffer   []string
 	isFollowing bool
 	logStream   io.ReadCloser
+	logCancel   context.CancelFunc
+
+	// In-flight background loads, keyed by loading component
+	operations map[string]*operation
+	requestSeq int
+
+	// Live namespace and pod caches for the selected context
+	watcher         *services.ResourceWatcher
+	resourceChanges chan struct{}
 }
 
 func NewKubeoptic(configSvc services.ConfigService, podSvc services.PodService, namespaceSvc services.NamespaceService) *Kubeoptic {
@@ -52,55 +131,15 @@ func NewKubeoptic(configSvc services.ConfigService, podSvc services.PodService,
 		namespaceSvc:      namespaceSvc,
 		focusedView:       ContextView,
 		selectedNamespace: "default",
+		operations:        make(map[string]*operation),
+		resourceChanges:   make(chan struct{}, 1),
 	}
 }
 
-// Navigation methods
-func (k *Kubeoptic) SelectContext(contextName string) error {
-	for _, ctx := range k.contexts {
-		if ctx.Name == contextName {
-			k.selectedContext = contextName
-			k.focusedView = NamespaceView
-			return k.refreshNamespaces()
-		}
-	}
-	return fmt.Errorf("context %s not found", contextName)
-}
-
-func (k *Kubeoptic) SelectNamespace(namespace string) error {
-	k.selectedNamespace = namespace
-	k.focusedView = PodView
-	return k.refreshPods()
-}
-
-func (k *Kubeoptic) SelectPod(podName string) error {
-	for _, pod := range k.filteredPods {
-		if pod.Name == podName {
-			k.selectedPod = &pod
-			k.focusedView = LogView
-			return k.startLogStream()
-		}
-	}
-	return fmt.Errorf("pod %s not found", podName)
-}
-
 // Search methods
-func (k *Kubeoptic) SearchPods(query string) error {
-	k.podSearchQuery = query
-	ctx := context.Background()
-
-	filteredPods, err := k.podSvc.SearchPods(ctx, k.selectedNamespace, query)
-	if err != nil {
-		return fmt.Errorf("failed to search pods: %w", err)
-	}
-
-	k.filteredPods = filteredPods
-	k.updatePodCount()
-	return nil
-}
 
 func (k *Kubeoptic) ClearSearch() error {
-	k.podSearchQuery = ""
+	k.resetSearch()
 	k.filteredPods = k.pods
 	k.updatePodCount()
 	return nil
@@ -115,59 +154,203 @@ func (k *Kubeoptic) LoadContexts(configPath string) error {
 
 	k.contexts = contexts
 	k.selectedContext = currentContext
+	k.configPath = configPath
+	k.configWatcher = services.NewKubeconfigWatcher(configPath)
 
-	// Update services with the new client
-	k.podSvc = services.NewPodService(client)
-	k.namespaceSvc = services.NewNamespaceService(client)
+	// Remember the credentials the client was built from
+	fingerprint, err := k.configSvc.CredentialFingerprint(configPath, currentContext)
+	if err != nil {
+		return fmt.Errorf("failed to load contexts: %w", err)
+	}
+	k.clientFingerprint = fingerprint
 
-	return k.refreshNamespaces()
-}
+	// Update services with the new client
+	k.setClient(client)
 
-func (k *Kubeoptic) refreshNamespaces() error {
-	ctx := context.Background()
+	// This runs before the TUI starts, so waiting here holds nothing up
+	ctx, cancel := context.WithTimeout(context.Background(), loadTimeout)
+	defer cancel()
 	namespaces, err := k.namespaceSvc.ListNamespacesDetailed(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to refresh namespaces: %w", err)
+		return fmt.Errorf("failed to load namespaces: %w", loadError("namespaces", err))
 	}
-
 	k.namespaces = namespaces
+	k.watchNamespaces()
 	return nil
 }
 
-func (k *Kubeoptic) refreshPods() error {
-	ctx := context.Background()
-	pods, err := k.podSvc.ListPods(ctx, k.selectedNamespace)
-	if err != nil {
-		return fmt.Errorf("failed to refresh pods: %w", err)
+// CheckConfigCmd looks for changes to the kubeconfig file(s) in the
+// background and re-reads them when there are, building a client for the
+// active context only when its credentials changed. When the active context
+// was removed, a client for the kubeconfig's current one is built instead.
+// ApplyConfig takes the result.
+func (k *Kubeoptic) CheckConfigCmd() tea.Cmd {
+	if k.configWatcher == nil {
+		return nil
 	}
+	watcher := k.configWatcher
+	svc := k.configSvc
+	configPath := k.configPath
+	active := k.selectedContext
+	oldFingerprint := k.clientFingerprint
+
+	return func() tea.Msg {
+		if !watcher.Changed() {
+			return messages.KubeconfigLoadedMsg{}
+		}
+		reloadError := func(err error) tea.Msg {
+			return messages.KubeconfigLoadedMsg{Changed: true, Error: fmt.Errorf("failed to reload kubeconfig: %w", err)}
+		}
 
-	k.pods = pods
-	k.filteredPods = pods
-	k.updatePodCount()
-	return nil
+		contexts, currentContext, currentClient, err := svc.LoadContexts(configPath)
+		if err != nil {
+			return reloadError(err)
+		}
+		msg := messages.KubeconfigLoadedMsg{Changed: true, Contexts: contexts, CurrentContext: currentContext, Context: active}
+
+		kept := false
+		for _, ctx := range contexts {
+			kept = kept || ctx.Name == active
+		}
+		if !kept {
+			if msg.Fingerprint, err = svc.CredentialFingerprint(configPath, currentContext); err != nil {
+				return reloadError(err)
+			}
+			msg.SwitchTo = currentContext
+			msg.Client = currentClient
+			return msg
+		}
+
+		fingerprint, err := svc.CredentialFingerprint(configPath, active)
+		if err != nil {
+			return reloadError(err)
+		}
+		if fingerprint != oldFingerprint {
+			client, err := svc.ClientForContext(configPath, active)
+			if err != nil {
+				return messages.KubeconfigLoadedMsg{Changed: true, Error: fmt.Errorf("failed to rebuild client: %w", err)}
+			}
+			msg.Fingerprint = fingerprint
+			msg.Client = client
+		}
+		return msg
+	}
 }
 
-func (k *Kubeoptic) startLogStream() error {
-	if k.selectedPod == nil {
-		return fmt.Errorf("no pod selected")
+// ApplyConfig takes a kubeconfig CheckConfigCmd re-read, refreshing the
+// context list while keeping the active context, with the new client when
+// its credentials changed. When the active context was removed, the
+// kubeconfig's current one is switched to, dropping what was loaded from the
+// old cluster as a switch by hand does. A context chosen by hand while the
+// kubeconfig was read is kept as it is. It reports whether the client was
+// replaced, in which case callers should reload namespaces with
+// LoadNamespacesCmd, and whether the context was switched.
+func (k *Kubeoptic) ApplyConfig(msg messages.KubeconfigLoadedMsg) (rebuilt, switched bool, err error) {
+	if msg.Error != nil {
+		return false, false, msg.Error
+	}
+	if !msg.Changed {
+		return false, false, nil
 	}
 
-	// Close existing stream
-	if k.logStream != nil {
-		k.logStream.Close()
+	k.contexts = msg.Contexts
+	if msg.Client == nil || msg.Context != k.selectedContext {
+		return false, false, nil
 	}
+	if msg.SwitchTo != "" {
+		k.enterContext(msg.SwitchTo, msg.Client, msg.Fingerprint)
+		return true, true, nil
+	}
+	k.clientFingerprint = msg.Fingerprint
+	k.setClient(msg.Client)
+	return true, false, nil
+}
 
-	ctx := context.Background()
-	stream, err := k.podSvc.GetPodLogs(ctx, k.selectedPod.Name, k.selectedNamespace)
-	if err != nil {
-		return fmt.Errorf("failed to start log stream: %w", err)
+func (k *Kubeoptic) hasContext(contextName string) bool {
+	for _, ctx := range k.contexts {
+		if ctx.Name == contextName {
+			return true
+		}
 	}
+	return false
+}
 
-	k.logStream = stream
-	k.isFollowing = true
+// setClient points the services and resource watches at a new Kubernetes client
+func (k *Kubeoptic) setClient(client *kubernetes.Clientset) {
+	k.client = client
+	k.podSvc = services.NewPodService(client)
+	k.namespaceSvc = services.NewNamespaceService(client)
+	k.workloadSvc = services.NewWorkloadService(client)
+	k.eventSvc = services.NewEventService(client)
+	k.describeSvc = services.NewDescribeService(client)
+	k.manifestSvc = services.NewManifestService(client)
+	k.metricsSvc = services.NewMetricsService(client)
+	k.actionSvc = services.NewActionService(client)
+	k.rolloutSvc = services.NewRolloutService(client)
+	k.editSvc = services.NewEditService(client)
+
+	if client == nil {
+		k.setWatcher(nil)
+		return
+	}
+	k.setWatcher(services.NewResourceWatcher(client, k.notifyResourceChange))
+}
+
+// switchContext makes a context active, dropping state from the previous one
+// and pointing the services at a client for the new context
+func (k *Kubeoptic) switchContext(contextName string) error {
+	if k.configPath == "" || contextName == k.selectedContext {
+		k.enterContext(contextName, nil, "")
+		return nil
+	}
+
+	fingerprint, err := k.configSvc.CredentialFingerprint(k.configPath, contextName)
+	if err != nil {
+		return fmt.Errorf("failed to switch context: %w", err)
+	}
+	client, err := k.configSvc.ClientForContext(k.configPath, contextName)
+	if err != nil {
+		return fmt.Errorf("failed to switch context: %w", err)
+	}
+	k.enterContext(contextName, client, fingerprint)
 	return nil
 }
 
+// enterContext makes a context active, cancelling the loads of the previous
+// one. Given a client built for the context, it drops what was loaded with
+// the previous client and points the services at the new one.
+func (k *Kubeoptic) enterContext(contextName string, client *kubernetes.Clientset, fingerprint string) {
+	for _, name := range []string{messages.LoadingNamespaces, messages.LoadingPods, messages.LoadingLogs, messages.LoadingWorkloads, messages.LoadingWorkloadList, messages.LoadingEvents, messages.LoadingDescribe, messages.LoadingManifest, messages.LoadingMetrics, messages.LoadingAllPods, messages.LoadingRollout, messages.LoadingFiles, messages.LoadingEdit} {
+		k.CancelLoad(name)
+	}
+
+	if client != nil {
+		k.clientFingerprint = fingerprint
+		k.setClient(client)
+
+		k.pods = nil
+		k.filteredPods = nil
+		k.workloads = nil
+		k.workloadList = nil
+		k.drillDown = nil
+		k.events = nil
+		k.eventsNamespace = ""
+		k.describedPod = nil
+		k.podMetrics = nil
+		k.selectedPod = nil
+		k.selectedNamespace = "default"
+		k.updatePodCount()
+	}
+
+	k.selectedContext = contextName
+	k.focusedView = NamespaceView
+}
+
+func (k *Kubeoptic) resetSearch() {
+	k.podSearchQuery = ""
+	k.podQuery = services.PodQuery{}
+}
+
 func (k *Kubeoptic) updatePodCount() {
 	if k.podSearchQuery == "" {
 		k.showingXofY = fmt.Sprintf("%d pods", len(k.pods))
@@ -0,0 +1,188 @@
+package models
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"kubeoptic/internal/messages"
+	"kubeoptic/internal/services"
+)
+
+// kubeconfig renders a kubeconfig with a context per token, each with its
+// own cluster and user
+func kubeconfig(tokens map[string]string, current string) string {
+	config := "apiVersion: v1\nkind: Config\ncurrent-context: " + current + "\nclusters:\n"
+	for name := range tokens {
+		config += fmt.Sprintf("- name: %s\n  cluster:\n    server: https://%s.example:6443\n", name, name)
+	}
+	config += "users:\n"
+	for name, token := range tokens {
+		config += fmt.Sprintf("- name: %s\n  user:\n    token: %s\n", name, token)
+	}
+	config += "contexts:\n"
+	for name := range tokens {
+		config += fmt.Sprintf("- name: %s\n  context:\n    cluster: %s\n    user: %s\n", name, name, name)
+	}
+	return config
+}
+
+// loadedKubeoptic returns a model with a kubeconfig loaded, as LoadContexts
+// leaves it but without listing namespaces
+func loadedKubeoptic(t *testing.T, path string) *Kubeoptic {
+	t.Helper()
+	configSvc := services.NewConfigService()
+	k := NewKubeoptic(configSvc, nil, nil)
+
+	contexts, currentContext, client, err := configSvc.LoadContexts(path)
+	if err != nil {
+		t.Fatalf("LoadContexts() error = %v", err)
+	}
+	fingerprint, err := configSvc.CredentialFingerprint(path, currentContext)
+	if err != nil {
+		t.Fatalf("CredentialFingerprint() error = %v", err)
+	}
+	k.contexts = contexts
+	k.selectedContext = currentContext
+	k.configPath = path
+	k.configWatcher = services.NewKubeconfigWatcher(path)
+	k.clientFingerprint = fingerprint
+	k.setClient(client)
+	return k
+}
+
+func TestCheckAndApplyConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		reloaded    map[string]string
+		current     string
+		wantRebuilt bool
+		wantContext string
+	}{
+		{
+			name:        "unchanged",
+			reloaded:    map[string]string{"dev": "dev-token", "prod": "prod-token"},
+			current:     "dev",
+			wantContext: "dev",
+		},
+		{
+			name:        "other context changed",
+			reloaded:    map[string]string{"dev": "dev-token", "prod": "new-prod-token", "staging": "staging-token"},
+			current:     "prod",
+			wantContext: "dev",
+		},
+		{
+			name:        "active context's token changed",
+			reloaded:    map[string]string{"dev": "new-dev-token", "prod": "prod-token"},
+			current:     "dev",
+			wantRebuilt: true,
+			wantContext: "dev",
+		},
+		{
+			name:        "active context removed",
+			reloaded:    map[string]string{"prod": "prod-token"},
+			current:     "prod",
+			wantRebuilt: true,
+			wantContext: "prod",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config")
+			write := func(config string) {
+				if err := os.WriteFile(path, []byte(config), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			write(kubeconfig(map[string]string{"dev": "dev-token", "prod": "prod-token"}, "dev"))
+			k := loadedKubeoptic(t, path)
+			client := k.client
+			k.selectedNamespace = "team"
+			k.pods = []services.Pod{{Name: "api", Namespace: "team"}}
+
+			write(kubeconfig(tt.reloaded, tt.current))
+			msg, ok := k.CheckConfigCmd()().(messages.KubeconfigLoadedMsg)
+			if !ok {
+				t.Fatal("Expected CheckConfigCmd to load the kubeconfig")
+			}
+			rebuilt, switched, err := k.ApplyConfig(msg)
+			if err != nil {
+				t.Fatalf("ApplyConfig() error = %v", err)
+			}
+			if rebuilt != tt.wantRebuilt {
+				t.Errorf("ApplyConfig() rebuilt = %v, want %v", rebuilt, tt.wantRebuilt)
+			}
+			if wantSwitched := tt.wantContext != "dev"; switched != wantSwitched {
+				t.Errorf("ApplyConfig() switched = %v, want %v", switched, wantSwitched)
+			}
+			if (k.client != client) != tt.wantRebuilt {
+				t.Errorf("client replaced = %v, want %v", k.client != client, tt.wantRebuilt)
+			}
+			if got := k.GetSelectedContext(); got != tt.wantContext {
+				t.Errorf("selected context = %q, want %q", got, tt.wantContext)
+			}
+			if len(k.GetContexts()) != len(tt.reloaded) {
+				t.Errorf("got %d contexts, want %d", len(k.GetContexts()), len(tt.reloaded))
+			}
+
+			// What was loaded from a removed context's cluster is dropped
+			if (k.pods == nil) != switched {
+				t.Errorf("pods dropped = %v, want %v", k.pods == nil, switched)
+			}
+			wantNamespace := "team"
+			if switched {
+				wantNamespace = "default"
+			}
+			if got := k.GetSelectedNamespace(); got != wantNamespace {
+				t.Errorf("selected namespace = %q, want %q", got, wantNamespace)
+			}
+		})
+	}
+}
+
+func TestCheckConfigUnchanged(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(path, []byte(kubeconfig(map[string]string{"dev": "dev-token"}, "dev")), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	k := loadedKubeoptic(t, path)
+
+	msg := k.CheckConfigCmd()().(messages.KubeconfigLoadedMsg)
+	if msg.Changed || msg.Client != nil {
+		t.Errorf("Expected nothing to reload for an unchanged kubeconfig, got %+v", msg)
+	}
+	if NewKubeoptic(services.NewConfigService(), nil, nil).CheckConfigCmd() != nil {
+		t.Error("Expected no check without a kubeconfig")
+	}
+}
+
+func TestApplyConfigKeepsContextChosenWhileReading(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	write := func(config string) {
+		if err := os.WriteFile(path, []byte(config), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(kubeconfig(map[string]string{"dev": "dev-token", "prod": "prod-token"}, "dev"))
+	k := loadedKubeoptic(t, path)
+
+	write(kubeconfig(map[string]string{"dev": "new-dev-token", "prod": "prod-token", "staging": "staging-token"}, "dev"))
+	check := k.CheckConfigCmd()
+	if err := k.switchContext("prod"); err != nil {
+		t.Fatal(err)
+	}
+	client := k.client
+
+	rebuilt, switched, err := k.ApplyConfig(check().(messages.KubeconfigLoadedMsg))
+	if err != nil || rebuilt || switched {
+		t.Errorf("ApplyConfig() = %v, %v, %v; want the chosen context kept", rebuilt, switched, err)
+	}
+	if k.client != client || k.GetSelectedContext() != "prod" {
+		t.Errorf("Expected prod's client kept, got context %q", k.GetSelectedContext())
+	}
+	if len(k.GetContexts()) != 3 {
+		t.Errorf("Expected the context list refreshed, got %v", k.GetContexts())
+	}
+}
@@ -0,0 +1,316 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"kubeoptic/internal/messages"
+	"kubeoptic/internal/services"
+)
+
+// loadTimeout bounds every request a loader makes to the cluster. Log streams
+// only use it while connecting, since a followed stream never completes.
+const loadTimeout = 30 * time.Second
+
+// operation tracks an in-flight cluster request so it can be cancelled and
+// so results that arrive after being superseded can be recognised
+type operation struct {
+	id     int
+	cancel context.CancelFunc
+}
+
+// begin registers a new operation, cancelling any previous one with the same name
+func (k *Kubeoptic) begin(name string) (context.Context, int) {
+	k.CancelLoad(name)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	k.requestSeq++
+	k.operations[name] = &operation{id: k.requestSeq, cancel: cancel}
+	return ctx, k.requestSeq
+}
+
+// finish claims the current operation for a result, returning nil when the
+// result belongs to a superseded or cancelled operation
+func (k *Kubeoptic) finish(name string, id int) *operation {
+	op, ok := k.operations[name]
+	if !ok || op.id != id {
+		return nil
+	}
+	delete(k.operations, name)
+	return op
+}
+
+// CancelLoad aborts the named in-flight operation, if any. Cancelling logs
+// also closes an established log stream.
+func (k *Kubeoptic) CancelLoad(name string) {
+	if op, ok := k.operations[name]; ok {
+		op.cancel()
+		delete(k.operations, name)
+	}
+	if name == messages.LoadingLogs && k.logStream != nil {
+		if k.logCancel != nil {
+			k.logCancel()
+		}
+		k.logStream.Close()
+		k.logStream = nil
+		k.logCancel = nil
+		k.isFollowing = false
+	}
+}
+
+// StopLogStream cancels the log stream and leaves the log view
+func (k *Kubeoptic) StopLogStream() {
+	k.CancelLoad(messages.LoadingLogs)
+	if k.focusedView == LogView {
+		k.focusedView = PodView
+	}
+}
+
+// IsLoading reports whether the named operation is still in flight
+func (k *Kubeoptic) IsLoading(name string) bool {
+	_, ok := k.operations[name]
+	return ok
+}
+
+// SelectContextCmd switches to a context and loads its namespaces in the background
+func (k *Kubeoptic) SelectContextCmd(contextName string) tea.Cmd {
+	if !k.hasContext(contextName) {
+		return errorCmd(fmt.Errorf("context %s not found", contextName), "selecting context")
+	}
+
+	if err := k.switchContext(contextName); err != nil {
+		return errorCmd(err, "selecting context")
+	}
+	return k.LoadNamespacesCmd()
+}
+
+// SelectNamespaceCmd switches to a namespace and loads its pods in the background
+func (k *Kubeoptic) SelectNamespaceCmd(namespace string) tea.Cmd {
+	k.CancelLoad(messages.LoadingLogs)
+	k.resetSearch()
+	if namespace != k.selectedNamespace {
+		k.workloads = nil
+		k.workloadList = nil
+	}
+	k.drillDown = nil
+	k.selectedNamespace = namespace
+	k.focusedView = PodView
+	return k.LoadPodsCmd()
+}
+
+// SelectPodCmd selects a pod and starts streaming its logs in the background
+func (k *Kubeoptic) SelectPodCmd(pod services.Pod) tea.Cmd {
+	k.selectedPod = &pod
+	k.logPods = nil
+	k.focusedView = LogView
+	return k.StartLogStreamCmd()
+}
+
+// SearchPodsCmd filters the pods of the selected namespace in the background
+// with a query in the syntax of services.ParsePodQuery. An empty query lists
+// every pod again.
+func (k *Kubeoptic) SearchPodsCmd(query string) tea.Cmd {
+	podQuery, err := services.ParsePodQuery(query)
+	if err != nil {
+		return errorCmd(err, "searching pods")
+	}
+	k.podSearchQuery = query
+	k.podQuery = podQuery
+	return k.LoadPodsCmd()
+}
+
+// LoadNamespacesCmd fetches the namespaces of the selected context
+func (k *Kubeoptic) LoadNamespacesCmd() tea.Cmd {
+	if k.namespaceSvc == nil {
+		return errorCmd(fmt.Errorf("no cluster connection"), "loading namespaces")
+	}
+
+	ctx, id := k.begin(messages.LoadingNamespaces)
+	svc := k.namespaceSvc
+
+	fetch := func() tea.Msg {
+		ctx, cancel := context.WithTimeout(ctx, loadTimeout)
+		defer cancel()
+
+		namespaces, err := svc.ListNamespacesDetailed(ctx)
+		if err != nil {
+			return messages.NamespacesLoadedMsg{Error: loadError("namespaces", err), RequestID: id}
+		}
+
+		names := make([]string, len(namespaces))
+		for i, ns := range namespaces {
+			names[i] = ns.Name
+		}
+		return messages.NamespacesLoadedMsg{Namespaces: names, Detailed: namespaces, RequestID: id}
+	}
+
+	return withLoading(messages.LoadingNamespaces, fetch)
+}
+
+// LoadPodsCmd fetches the pods of the selected namespace that match the current search
+func (k *Kubeoptic) LoadPodsCmd() tea.Cmd {
+	if k.podSvc == nil {
+		return errorCmd(fmt.Errorf("no cluster connection"), "loading pods")
+	}
+	return withLoading(messages.LoadingPods, k.FetchPodsCmd())
+}
+
+// FetchPodsCmd is LoadPodsCmd without the loading indicator around it: it
+// fetches the pods of the selected namespace matching the search query
+func (k *Kubeoptic) FetchPodsCmd() tea.Cmd {
+	if k.podSvc == nil {
+		return errorCmd(fmt.Errorf("no cluster connection"), "loading pods")
+	}
+
+	ctx, id := k.begin(messages.LoadingPods)
+	svc := k.podSvc
+	namespace := k.selectedNamespace
+	query := k.podSearchQuery
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(ctx, loadTimeout)
+		defer cancel()
+
+		var pods []services.Pod
+		var err error
+		if query == "" {
+			pods, err = svc.ListPods(ctx, namespace)
+		} else {
+			pods, err = svc.SearchPods(ctx, namespace, query)
+		}
+		return messages.PodsLoadedMsg{Pods: pods, Namespace: namespace, Query: query, Error: loadError("pods", err), RequestID: id}
+	}
+}
+
+// StartLogStreamCmd opens a follow stream for the selected pod's logs, or
+// for the logs of every pod of the selected workload
+func (k *Kubeoptic) StartLogStreamCmd() tea.Cmd {
+	if k.selectedPod == nil {
+		return errorCmd(fmt.Errorf("no pod selected"), "log streaming")
+	}
+	if k.podSvc == nil {
+		return errorCmd(fmt.Errorf("no cluster connection"), "log streaming")
+	}
+
+	ctx, id := k.begin(messages.LoadingLogs)
+	svc := k.podSvc
+	pod := *k.selectedPod
+	targets := k.logPods
+
+	connect := func() tea.Msg {
+		// The stream lives as long as ctx, so only bound the time to connect
+		ctx, cancel := context.WithCancel(ctx)
+		timer := time.AfterFunc(loadTimeout, cancel)
+
+		var stream io.ReadCloser
+		var err error
+		if targets == nil {
+			stream, err = svc.GetPodLogs(ctx, pod.Name, pod.Namespace)
+		} else {
+			stream, err = services.OpenMergedLogs(ctx, svc, targets, services.LogOptions{})
+		}
+		if !timer.Stop() || err != nil {
+			cancel()
+			if stream != nil {
+				stream.Close()
+			}
+			if err == nil {
+				err = context.DeadlineExceeded
+			}
+			return messages.LogStreamStartedMsg{Pod: &pod, Error: loadError("logs", err), RequestID: id}
+		}
+
+		return messages.LogStreamStartedMsg{Pod: &pod, Stream: stream, RequestID: id}
+	}
+
+	return withLoading(messages.LoadingLogs, connect)
+}
+
+// ApplyNamespaces stores loaded namespaces. It returns false for results of
+// superseded or cancelled loads, which callers should discard.
+func (k *Kubeoptic) ApplyNamespaces(msg messages.NamespacesLoadedMsg) bool {
+	op := k.finish(messages.LoadingNamespaces, msg.RequestID)
+	if op == nil {
+		return false
+	}
+	op.cancel()
+	if msg.Error == nil {
+		k.namespaces = msg.Detailed
+		k.watchNamespaces()
+		// A rebuilt client needs a new watch on the pods being shown
+		if k.pods != nil {
+			k.watchPods()
+		}
+	}
+	return true
+}
+
+// ApplyPods stores loaded pods. It returns false for results of superseded
+// or cancelled loads, which callers should discard.
+func (k *Kubeoptic) ApplyPods(msg messages.PodsLoadedMsg) bool {
+	op := k.finish(messages.LoadingPods, msg.RequestID)
+	if op == nil {
+		return false
+	}
+	op.cancel()
+	if msg.Error == nil {
+		// Search results narrow the list; the full list is kept for counts
+		if msg.Query == "" {
+			k.pods = msg.Pods
+		}
+		k.filteredPods = msg.Pods
+		k.updatePodCount()
+		k.watchPods()
+	}
+	return true
+}
+
+// ApplyLogStream takes ownership of an opened log stream. It returns false,
+// closing the stream, when the stream was superseded or cancelled.
+func (k *Kubeoptic) ApplyLogStream(msg messages.LogStreamStartedMsg) bool {
+	op := k.finish(messages.LoadingLogs, msg.RequestID)
+	if op == nil {
+		if msg.Stream != nil {
+			msg.Stream.Close()
+		}
+		return false
+	}
+	if msg.Error != nil {
+		op.cancel()
+		return true
+	}
+
+	// The stream's context stays alive until the stream is cancelled
+	k.logStream = msg.Stream
+	k.logCancel = op.cancel
+	k.isFollowing = true
+	return true
+}
+
+// withLoading wraps a loader with loading started/completed notifications
+func withLoading(component string, load tea.Cmd) tea.Cmd {
+	return tea.Sequence(
+		func() tea.Msg { return messages.LoadingStartedMsg{Component: component} },
+		load,
+		func() tea.Msg { return messages.LoadingCompletedMsg{Component: component} },
+	)
+}
+
+func errorCmd(err error, context string) tea.Cmd {
+	return func() tea.Msg {
+		return messages.ErrorMsg{Error: err, Context: context}
+	}
+}
+
+// loadError turns timeouts into a readable error, passing others through
+func loadError(what string, err error) error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("loading %s timed out after %s", what, loadTimeout)
+	}
+	return err
+}
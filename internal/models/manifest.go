@@ -0,0 +1,56 @@
+package models
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"kubeoptic/internal/messages"
+)
+
+// ShowManifestCmd switches to the YAML view of a pod or workload and fetches
+// its manifest
+func (k *Kubeoptic) ShowManifestCmd(kind, namespace, name string) tea.Cmd {
+	if k.manifestSvc == nil {
+		return errorCmd(fmt.Errorf("no cluster connection"), "loading manifest")
+	}
+
+	// Reloading the shown manifest keeps the way back
+	if k.focusedView != ManifestView {
+		k.manifestReturn = k.focusedView
+	}
+	k.focusedView = ManifestView
+
+	ctx, id := k.begin(messages.LoadingManifest)
+	svc := k.manifestSvc
+
+	fetch := func() tea.Msg {
+		ctx, cancel := context.WithTimeout(ctx, loadTimeout)
+		defer cancel()
+
+		manifest, err := svc.GetManifest(ctx, kind, namespace, name)
+		return messages.ManifestLoadedMsg{Manifest: manifest, Error: loadError("manifest", err), RequestID: id}
+	}
+
+	return withLoading(messages.LoadingManifest, fetch)
+}
+
+// ApplyManifest accepts a fetched manifest. It returns false for results of
+// superseded or cancelled loads, which callers should discard.
+func (k *Kubeoptic) ApplyManifest(msg messages.ManifestLoadedMsg) bool {
+	op := k.finish(messages.LoadingManifest, msg.RequestID)
+	if op == nil {
+		return false
+	}
+	op.cancel()
+	return true
+}
+
+// HideManifest leaves the YAML view for the view it was opened from,
+// abandoning a pending fetch, and returns that view
+func (k *Kubeoptic) HideManifest() ViewType {
+	k.CancelLoad(messages.LoadingManifest)
+	k.focusedView = k.manifestReturn
+	return k.focusedView
+}
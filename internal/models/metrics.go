@@ -0,0 +1,82 @@
+package models
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"kubeoptic/internal/messages"
+	"kubeoptic/internal/services"
+)
+
+// LoadMetricsCmd fetches what the pods of every namespace currently use.
+// Without permission to read metrics cluster-wide it falls back to the
+// selected namespace. It runs in the background without loading messages,
+// since usage is refreshed periodically.
+func (k *Kubeoptic) LoadMetricsCmd() tea.Cmd {
+	// Usage is optional, so without a cluster connection it is just not shown
+	if k.metricsSvc == nil {
+		return nil
+	}
+
+	ctx, id := k.begin(messages.LoadingMetrics)
+	svc := k.metricsSvc
+	namespace := k.selectedNamespace
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(ctx, loadTimeout)
+		defer cancel()
+
+		metrics, err := svc.ListPodMetrics(ctx, metav1.NamespaceAll)
+		if apierrors.IsForbidden(err) && namespace != "" {
+			metrics, err = svc.ListPodMetrics(ctx, namespace)
+		}
+		return messages.MetricsLoadedMsg{Metrics: metrics, Error: loadError("metrics", err), RequestID: id}
+	}
+}
+
+// ApplyMetrics stores loaded pod usage, or forgets it when metrics can't be
+// loaded so that usage is hidden rather than shown stale. It returns false
+// for results of superseded or cancelled loads, which callers should discard.
+func (k *Kubeoptic) ApplyMetrics(msg messages.MetricsLoadedMsg) bool {
+	op := k.finish(messages.LoadingMetrics, msg.RequestID)
+	if op == nil {
+		return false
+	}
+	op.cancel()
+	if msg.Error != nil {
+		k.podMetrics = nil
+	} else {
+		k.podMetrics = msg.Metrics
+		if k.podMetrics == nil {
+			k.podMetrics = []services.PodMetrics{}
+		}
+	}
+	return true
+}
+
+// MetricsAvailable reports whether pod resource usage is known
+func (k *Kubeoptic) MetricsAvailable() bool {
+	return k.podMetrics != nil
+}
+
+// GetPodMetrics returns what pods currently use, or nil when metrics are
+// unavailable
+func (k *Kubeoptic) GetPodMetrics() []services.PodMetrics {
+	return k.podMetrics
+}
+
+// GetNamespaceUsage totals pod resource usage by namespace. It is nil when
+// metrics are unavailable.
+func (k *Kubeoptic) GetNamespaceUsage() map[string]services.ResourceAmounts {
+	if k.podMetrics == nil {
+		return nil
+	}
+	usage := make(map[string]services.ResourceAmounts)
+	for _, m := range k.podMetrics {
+		usage[m.Namespace] = usage[m.Namespace].Add(m.Usage)
+	}
+	return usage
+}
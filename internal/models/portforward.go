@@ -0,0 +1,105 @@
+package models
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"kubeoptic/internal/messages"
+	"kubeoptic/internal/services"
+)
+
+// StartPortForwardCmd forwards a local port to a pod, or to a ready pod
+// backing a Service. Forwards keep running while other views are shown.
+func (k *Kubeoptic) StartPortForwardCmd(req messages.PortForwardRequestedMsg) tea.Cmd {
+	config, err := k.restConfig()
+	if err != nil {
+		return errorCmd(err, "port-forwarding")
+	}
+	svc := services.NewPortForwardService(k.client, config)
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), loadTimeout)
+		defer cancel()
+
+		forward := services.PortForwardRequest{
+			Namespace:  req.Namespace,
+			Pod:        req.Pod,
+			LocalPort:  req.LocalPort,
+			RemotePort: req.RemotePort,
+		}
+		if req.Service != "" {
+			pod, port, err := svc.ResolveService(ctx, req.Namespace, req.Service, req.RemotePort)
+			if err != nil {
+				return messages.PortForwardStartedMsg{Error: loadError("service", err)}
+			}
+			forward.Pod, forward.RemotePort = pod, port
+			if forward.LocalPort == 0 {
+				forward.LocalPort = req.RemotePort
+			}
+		}
+
+		started, err := svc.Forward(ctx, forward)
+		return messages.PortForwardStartedMsg{Forward: started, Error: loadError("port-forward", err)}
+	}
+}
+
+// ApplyPortForward keeps a started forward, returning a command that reports
+// when it ends by itself
+func (k *Kubeoptic) ApplyPortForward(msg messages.PortForwardStartedMsg) tea.Cmd {
+	if msg.Error != nil || msg.Forward == nil {
+		return nil
+	}
+	k.portForwards = append(k.portForwards, msg.Forward)
+
+	forward := msg.Forward
+	return func() tea.Msg {
+		<-forward.Done()
+		return messages.PortForwardEndedMsg{Forward: forward}
+	}
+}
+
+// StopPortForward ends a forward and drops it from the list
+func (k *Kubeoptic) StopPortForward(forward *services.PortForward) {
+	forward.Stop()
+	for i, f := range k.portForwards {
+		if f == forward {
+			k.portForwards = append(k.portForwards[:i:i], k.portForwards[i+1:]...)
+			return
+		}
+	}
+}
+
+// StopAllPortForwards ends every forward, as when quitting
+func (k *Kubeoptic) StopAllPortForwards() {
+	for _, forward := range k.portForwards {
+		forward.Stop()
+	}
+	k.portForwards = nil
+}
+
+// GetPortForwards returns the forwards started, including those that ended
+// by themselves and haven't been dropped yet
+func (k *Kubeoptic) GetPortForwards() []*services.PortForward {
+	return k.portForwards
+}
+
+// ShowPortForwards switches to the port-forward panel
+func (k *Kubeoptic) ShowPortForwards() {
+	switch k.focusedView {
+	case PortForwardView:
+	case ContextView, NamespaceView, PodView, WorkloadView, EventView:
+		k.portForwardReturn = k.focusedView
+	default:
+		// Full-screen views are left for the pods
+		k.portForwardReturn = PodView
+	}
+	k.focusedView = PortForwardView
+}
+
+// HidePortForwards leaves the port-forward panel for the view it was opened
+// from, and returns that view
+func (k *Kubeoptic) HidePortForwards() ViewType {
+	k.focusedView = k.portForwardReturn
+	return k.focusedView
+}
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"kubeoptic/internal/messages"
+	"kubeoptic/internal/services"
+)
+
+// ShowRolloutHistoryCmd switches to the revision history of a Deployment,
+// StatefulSet or DaemonSet and fetches it
+func (k *Kubeoptic) ShowRolloutHistoryCmd(workload services.Workload) tea.Cmd {
+	if k.rolloutSvc == nil {
+		return errorCmd(fmt.Errorf("no cluster connection"), "loading revision history")
+	}
+
+	// Reloading the shown history keeps the way back
+	if k.focusedView != RolloutView {
+		k.rolloutReturn = k.focusedView
+	}
+	k.focusedView = RolloutView
+
+	ctx, id := k.begin(messages.LoadingRollout)
+	svc := k.rolloutSvc
+
+	fetch := func() tea.Msg {
+		ctx, cancel := context.WithTimeout(ctx, loadTimeout)
+		defer cancel()
+
+		revisions, err := svc.History(ctx, workload.Namespace, services.OwnerRef{Kind: workload.Kind, Name: workload.Name})
+		return messages.RolloutHistoryLoadedMsg{Revisions: revisions, Error: loadError("revision history", err), RequestID: id}
+	}
+
+	return withLoading(messages.LoadingRollout, fetch)
+}
+
+// ApplyRolloutHistory accepts fetched revisions. It returns false for
+// results of superseded or cancelled loads, which callers should discard.
+func (k *Kubeoptic) ApplyRolloutHistory(msg messages.RolloutHistoryLoadedMsg) bool {
+	op := k.finish(messages.LoadingRollout, msg.RequestID)
+	if op == nil {
+		return false
+	}
+	op.cancel()
+	return true
+}
+
+// HideRolloutHistory leaves the revision history for the view it was opened
+// from, abandoning a pending fetch, and returns that view
+func (k *Kubeoptic) HideRolloutHistory() ViewType {
+	k.CancelLoad(messages.LoadingRollout)
+	k.focusedView = k.rolloutReturn
+	return k.focusedView
+}
+
+// RolloutStatusCmd checks how far the rollout of a workload has got
+func (k *Kubeoptic) RolloutStatusCmd(namespace, kind, name string) tea.Cmd {
+	if k.rolloutSvc == nil {
+		return nil
+	}
+	svc := k.rolloutSvc
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), loadTimeout)
+		defer cancel()
+
+		status, err := svc.Status(ctx, namespace, services.OwnerRef{Kind: kind, Name: name})
+		return messages.RolloutStatusMsg{
+			Namespace: namespace,
+			Kind:      kind,
+			Name:      name,
+			Status:    status,
+			Error:     loadError("rollout status", err),
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package models
+
+import (
+	"reflect"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"kubeoptic/internal/messages"
+	"kubeoptic/internal/services"
+)
+
+// resourceSettleInterval batches bursts of cluster changes, such as a
+// deployment rolling out, into a single refresh of the lists
+const resourceSettleInterval = 250 * time.Millisecond
+
+// WatchResourcesCmd waits for the next change to watched namespaces or pods.
+// Callers should issue it again after each ResourcesChangedMsg.
+func (k *Kubeoptic) WatchResourcesCmd() tea.Cmd {
+	changes := k.resourceChanges
+	return func() tea.Msg {
+		<-changes
+		time.Sleep(resourceSettleInterval)
+		select {
+		case <-changes:
+		default:
+		}
+		return messages.ResourcesChangedMsg{}
+	}
+}
+
+// SyncWatchedResources copies the live namespace and pod caches into the
+// model, reporting which of them changed. Lists with a load in flight are
+// left alone so the load's result is not overwritten.
+func (k *Kubeoptic) SyncWatchedResources() (namespacesChanged, podsChanged bool) {
+	if k.watcher == nil {
+		return false, false
+	}
+
+	if !k.IsLoading(messages.LoadingNamespaces) {
+		if namespaces, ok := k.watcher.Namespaces(); ok && !reflect.DeepEqual(namespaces, k.namespaces) {
+			k.namespaces = namespaces
+			namespacesChanged = true
+		}
+	}
+
+	if !k.IsLoading(messages.LoadingPods) {
+		pods, ok := k.watcher.Pods(k.selectedNamespace, services.PodQuery{})
+		matched, _ := k.watcher.Pods(k.selectedNamespace, k.podQuery)
+		if ok && (!reflect.DeepEqual(pods, k.pods) || !reflect.DeepEqual(matched, k.filteredPods)) {
+			k.pods = pods
+			k.filteredPods = matched
+			k.updatePodCount()
+			podsChanged = true
+		}
+	}
+
+	return namespacesChanged, podsChanged
+}
+
+// SyncWatchedEvents copies the live event cache into the model once events
+// have been loaded for the selected namespace, reporting whether they changed
+func (k *Kubeoptic) SyncWatchedEvents() bool {
+	if k.watcher == nil || k.eventsNamespace != k.selectedNamespace || k.IsLoading(messages.LoadingEvents) {
+		return false
+	}
+
+	events, ok := k.watcher.Events(k.selectedNamespace)
+	if !ok || reflect.DeepEqual(events, k.events) {
+		return false
+	}
+	k.events = events
+	return true
+}
+
+// notifyResourceChange records that a watched resource changed. It is called
+// from informer goroutines and never blocks; pending changes are coalesced.
+func (k *Kubeoptic) notifyResourceChange() {
+	select {
+	case k.resourceChanges <- struct{}{}:
+	default:
+	}
+}
+
+// watchNamespaces keeps the namespace list live for the current client
+func (k *Kubeoptic) watchNamespaces() {
+	if k.watcher != nil {
+		k.watcher.WatchNamespaces()
+	}
+}
+
+// watchPods keeps the pod list of the selected namespace live
+func (k *Kubeoptic) watchPods() {
+	if k.watcher != nil {
+		k.watcher.WatchPods(k.selectedNamespace)
+	}
+}
+
+// watchEvents keeps the events of the selected namespace live
+func (k *Kubeoptic) watchEvents() {
+	if k.watcher != nil {
+		k.watcher.WatchEvents(k.selectedNamespace)
+	}
+}
+
+// setWatcher replaces the resource watcher, stopping the previous one
+func (k *Kubeoptic) setWatcher(watcher *services.ResourceWatcher) {
+	if k.watcher != nil {
+		k.watcher.Stop()
+	}
+	k.watcher = watcher
+}
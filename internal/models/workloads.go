@@ -0,0 +1,178 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"kubeoptic/internal/messages"
+	"kubeoptic/internal/services"
+)
+
+// LoadWorkloadsCmd fetches the workloads owning the selected namespace's pods
+func (k *Kubeoptic) LoadWorkloadsCmd() tea.Cmd {
+	if k.workloadSvc == nil {
+		return errorCmd(fmt.Errorf("no cluster connection"), "loading workloads")
+	}
+
+	ctx, id := k.begin(messages.LoadingWorkloads)
+	svc := k.workloadSvc
+	namespace := k.selectedNamespace
+
+	fetch := func() tea.Msg {
+		ctx, cancel := context.WithTimeout(ctx, loadTimeout)
+		defer cancel()
+
+		workloads, err := svc.ListWorkloads(ctx, namespace)
+		return messages.WorkloadsLoadedMsg{Workloads: workloads, Namespace: namespace, Error: loadError("workloads", err), RequestID: id}
+	}
+
+	return withLoading(messages.LoadingWorkloads, fetch)
+}
+
+// ApplyWorkloads stores loaded workloads. It returns false for results of
+// superseded or cancelled loads, which callers should discard.
+func (k *Kubeoptic) ApplyWorkloads(msg messages.WorkloadsLoadedMsg) bool {
+	op := k.finish(messages.LoadingWorkloads, msg.RequestID)
+	if op == nil {
+		return false
+	}
+	op.cancel()
+	if msg.Error == nil && msg.Namespace == k.selectedNamespace {
+		k.workloads = msg.Workloads
+	}
+	return true
+}
+
+// WorkloadsOutdated reports whether a pod has an owner that the loaded
+// workloads don't account for, as happens when a rollout creates a ReplicaSet
+func (k *Kubeoptic) WorkloadsOutdated() bool {
+	known := make(map[services.OwnerRef]bool)
+	for _, workload := range k.workloads {
+		for _, controller := range workload.Controllers {
+			known[controller] = true
+		}
+	}
+	for _, pod := range k.pods {
+		if !pod.Owner.IsZero() && !known[pod.Owner] {
+			return true
+		}
+	}
+	return false
+}
+
+// TailWorkloadCmd streams the logs of all of a workload's pods together
+func (k *Kubeoptic) TailWorkloadCmd(workload services.Workload, pods []services.Pod) tea.Cmd {
+	if len(pods) == 0 {
+		return errorCmd(fmt.Errorf("%s %s has no pods", workload.Kind, workload.Name), "log streaming")
+	}
+
+	// The selected pod stands in for the workload, named the way kubectl would
+	k.selectedPod = &services.Pod{
+		Name:      strings.ToLower(workload.Kind) + "/" + workload.Name,
+		Namespace: workload.Namespace,
+	}
+	k.logPods = pods
+	k.focusedView = LogView
+	return k.StartLogStreamCmd()
+}
+
+// GetWorkloads returns the workloads of the selected namespace
+func (k *Kubeoptic) GetWorkloads() []services.Workload {
+	return k.workloads
+}
+
+// ShowWorkloadsCmd switches to the workload view of the selected namespace
+// and loads its workloads of a kind
+func (k *Kubeoptic) ShowWorkloadsCmd(kind string) tea.Cmd {
+	if kind != k.workloadKind {
+		k.workloadList = nil
+	}
+	k.workloadKind = kind
+	k.focusedView = WorkloadView
+	return k.LoadWorkloadListCmd()
+}
+
+// LoadWorkloadListCmd fetches the workloads shown in the workload view
+func (k *Kubeoptic) LoadWorkloadListCmd() tea.Cmd {
+	if k.workloadSvc == nil {
+		return errorCmd(fmt.Errorf("no cluster connection"), "loading workloads")
+	}
+
+	ctx, id := k.begin(messages.LoadingWorkloadList)
+	svc := k.workloadSvc
+	namespace := k.selectedNamespace
+	kind := k.workloadKind
+
+	fetch := func() tea.Msg {
+		ctx, cancel := context.WithTimeout(ctx, loadTimeout)
+		defer cancel()
+
+		workloads, err := svc.ListWorkloadsOfKind(ctx, namespace, kind)
+		return messages.WorkloadListLoadedMsg{
+			Kind:      kind,
+			Namespace: namespace,
+			Workloads: workloads,
+			Error:     loadError(strings.ToLower(kind)+"s", err),
+			RequestID: id,
+		}
+	}
+
+	return withLoading(messages.LoadingWorkloadList, fetch)
+}
+
+// ApplyWorkloadList stores the workloads of the workload view. It returns
+// false for results of superseded or cancelled loads, which callers should discard.
+func (k *Kubeoptic) ApplyWorkloadList(msg messages.WorkloadListLoadedMsg) bool {
+	op := k.finish(messages.LoadingWorkloadList, msg.RequestID)
+	if op == nil {
+		return false
+	}
+	op.cancel()
+	if msg.Error == nil {
+		k.workloadList = msg.Workloads
+	}
+	return true
+}
+
+// DrillDownCmd shows the pods of a workload by searching for its selector
+func (k *Kubeoptic) DrillDownCmd(workload services.Workload) tea.Cmd {
+	if workload.Selector == "" {
+		return errorCmd(fmt.Errorf("%s %s has no pods to show", workload.Kind, workload.Name), "showing workload pods")
+	}
+	k.drillDown = &workload
+	k.focusedView = PodView
+	return k.SearchPodsCmd(workload.Selector)
+}
+
+// BackToWorkloads leaves a workload's pods for the workload view, reporting
+// false when the pods weren't reached from a workload
+func (k *Kubeoptic) BackToWorkloads() bool {
+	if k.drillDown == nil {
+		return false
+	}
+	k.drillDown = nil
+	k.CancelLoad(messages.LoadingPods)
+	k.ClearSearch()
+	k.focusedView = WorkloadView
+	return true
+}
+
+// HideWorkloads leaves the workload view for the pods of the namespace,
+// abandoning a pending workload load
+func (k *Kubeoptic) HideWorkloads() {
+	k.CancelLoad(messages.LoadingWorkloadList)
+	k.focusedView = PodView
+}
+
+// GetWorkloadKind returns the kind shown in the workload view
+func (k *Kubeoptic) GetWorkloadKind() string {
+	return k.workloadKind
+}
+
+// GetWorkloadList returns the workloads shown in the workload view
+func (k *Kubeoptic) GetWorkloadList() []services.Workload {
+	return k.workloadList
+}
@@ -0,0 +1,189 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+// restartedAtAnnotation is the pod template annotation kubectl rollout
+// restart sets; changing it rolls out new pods
+const restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+type ActionServiceImpl struct {
+	client kubernetes.Interface
+}
+
+func NewActionService(client kubernetes.Interface) ActionService {
+	return &ActionServiceImpl{
+		client: client,
+	}
+}
+
+// DeletePod deletes a pod, gracefully unless forced
+func (a *ActionServiceImpl) DeletePod(ctx context.Context, namespace, name string, force bool) error {
+	opts := metav1.DeleteOptions{}
+	if force {
+		gracePeriod := int64(0)
+		opts.GracePeriodSeconds = &gracePeriod
+	}
+	if err := a.client.CoreV1().Pods(namespace).Delete(ctx, name, opts); err != nil {
+		return fmt.Errorf("failed to delete pod %s/%s: %w", namespace, name, err)
+	}
+	return nil
+}
+
+// WorkloadOf finds the workload behind a pod's controller. Only workloads
+// that can be restarted are returned.
+func (a *ActionServiceImpl) WorkloadOf(ctx context.Context, namespace string, owner OwnerRef) (OwnerRef, error) {
+	switch owner.Kind {
+	case KindDeployment, KindStatefulSet, KindDaemonSet:
+		return owner, nil
+	case KindReplicaSet:
+		rs, err := a.client.AppsV1().ReplicaSets(namespace).Get(ctx, owner.Name, metav1.GetOptions{})
+		if err != nil {
+			return OwnerRef{}, fmt.Errorf("failed to get replicaset %s/%s: %w", namespace, owner.Name, err)
+		}
+		if parent := controllerOf(&rs.ObjectMeta); parent.Kind == KindDeployment {
+			return parent, nil
+		}
+		return OwnerRef{}, fmt.Errorf("replicaset %s/%s is not managed by a deployment, so can't be restarted", namespace, owner.Name)
+	case "":
+		return OwnerRef{}, fmt.Errorf("the pod has no workload to restart")
+	default:
+		return OwnerRef{}, fmt.Errorf("a %s can't be restarted", strings.ToLower(owner.Kind))
+	}
+}
+
+// RestartWorkload stamps the workload's pod template with the time, which
+// replaces its pods the way its update strategy says
+func (a *ActionServiceImpl) RestartWorkload(ctx context.Context, namespace string, workload OwnerRef) error {
+	patch := []byte(fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{%q:%q}}}}}`,
+		restartedAtAnnotation, time.Now().Format(time.RFC3339)))
+
+	var err error
+	switch workload.Kind {
+	case KindDeployment:
+		_, err = a.client.AppsV1().Deployments(namespace).Patch(ctx, workload.Name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	case KindStatefulSet:
+		_, err = a.client.AppsV1().StatefulSets(namespace).Patch(ctx, workload.Name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	case KindDaemonSet:
+		_, err = a.client.AppsV1().DaemonSets(namespace).Patch(ctx, workload.Name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	default:
+		return fmt.Errorf("a %s can't be restarted", strings.ToLower(workload.Kind))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to restart %s %s/%s: %w", strings.ToLower(workload.Kind), namespace, workload.Name, err)
+	}
+	return nil
+}
+
+// ScaleWorkload sets the replicas of a Deployment or StatefulSet
+func (a *ActionServiceImpl) ScaleWorkload(ctx context.Context, namespace string, workload OwnerRef, replicas int32) error {
+	if replicas < 0 {
+		return fmt.Errorf("replicas can't be negative")
+	}
+	patch := []byte(fmt.Sprintf(`{"spec":{"replicas":%d}}`, replicas))
+
+	var err error
+	switch workload.Kind {
+	case KindDeployment:
+		_, err = a.client.AppsV1().Deployments(namespace).Patch(ctx, workload.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	case KindStatefulSet:
+		_, err = a.client.AppsV1().StatefulSets(namespace).Patch(ctx, workload.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	default:
+		return fmt.Errorf("a %s can't be scaled", strings.ToLower(workload.Kind))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to scale %s %s/%s: %w", strings.ToLower(workload.Kind), namespace, workload.Name, err)
+	}
+	return nil
+}
+
+// RollbackWorkload puts back the pod template of a revision. A Deployment
+// gets the template of the revision's ReplicaSet; a StatefulSet or
+// DaemonSet has its ControllerRevision's patch applied.
+func (a *ActionServiceImpl) RollbackWorkload(ctx context.Context, namespace string, workload OwnerRef, revision int64) error {
+	var err error
+	switch workload.Kind {
+	case KindDeployment:
+		err = a.rollbackDeployment(ctx, namespace, workload.Name, revision)
+	case KindStatefulSet, KindDaemonSet:
+		err = a.rollbackControllerRevision(ctx, namespace, workload, revision)
+	default:
+		return fmt.Errorf("a %s can't be rolled back", strings.ToLower(workload.Kind))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to roll back %s %s/%s: %w", strings.ToLower(workload.Kind), namespace, workload.Name, err)
+	}
+	return nil
+}
+
+func (a *ActionServiceImpl) rollbackDeployment(ctx context.Context, namespace, name string, revision int64) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		d, err := a.client.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if d.Spec.Paused {
+			return fmt.Errorf("the deployment is paused; resume it first")
+		}
+
+		replicaSets, err := deploymentReplicaSets(ctx, a.client, d)
+		if err != nil {
+			return err
+		}
+		var target *appsv1.ReplicaSet
+		for i := range replicaSets {
+			if replicaSetRevision(&replicaSets[i]) == revision {
+				target = &replicaSets[i]
+			}
+		}
+		if target == nil {
+			return fmt.Errorf("revision %d not found", revision)
+		}
+
+		// The template hash label is the ReplicaSet's own, not the template's
+		template := target.Spec.Template.DeepCopy()
+		delete(template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+		d.Spec.Template = *template
+		if cause, ok := target.Annotations[changeCauseAnnotation]; ok {
+			if d.Annotations == nil {
+				d.Annotations = map[string]string{}
+			}
+			d.Annotations[changeCauseAnnotation] = cause
+		} else {
+			delete(d.Annotations, changeCauseAnnotation)
+		}
+
+		_, err = a.client.AppsV1().Deployments(namespace).Update(ctx, d, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+func (a *ActionServiceImpl) rollbackControllerRevision(ctx context.Context, namespace string, workload OwnerRef, revision int64) error {
+	history, _, err := controllerRevisions(ctx, a.client, namespace, workload)
+	if err != nil {
+		return err
+	}
+	for _, rev := range history {
+		if rev.Revision != revision {
+			continue
+		}
+		switch workload.Kind {
+		case KindStatefulSet:
+			_, err = a.client.AppsV1().StatefulSets(namespace).Patch(ctx, workload.Name, types.StrategicMergePatchType, rev.Data.Raw, metav1.PatchOptions{})
+		case KindDaemonSet:
+			_, err = a.client.AppsV1().DaemonSets(namespace).Patch(ctx, workload.Name, types.StrategicMergePatchType, rev.Data.Raw, metav1.PatchOptions{})
+		}
+		return err
+	}
+	return fmt.Errorf("revision %d not found", revision)
+}
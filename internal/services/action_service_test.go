@@ -0,0 +1,196 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// podTemplate returns a pod template running one container of an image
+func podTemplate(labels map[string]string, container, image string) corev1.PodTemplateSpec {
+	return corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{Labels: labels},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: container, Image: image}}},
+	}
+}
+
+// revisionReplicaSet returns a ReplicaSet a Deployment created for a revision
+func revisionReplicaSet(revision, hash, image, changeCause string) *appsv1.ReplicaSet {
+	rs := &appsv1.ReplicaSet{
+		ObjectMeta: ownedBy("api-"+hash, KindDeployment, "api"),
+		Spec: appsv1.ReplicaSetSpec{
+			Template: podTemplate(map[string]string{"app": "api", appsv1.DefaultDeploymentUniqueLabelKey: hash}, "api", image),
+		},
+	}
+	rs.Labels = rs.Spec.Template.Labels
+	rs.Annotations = map[string]string{revisionAnnotation: revision}
+	if changeCause != "" {
+		rs.Annotations[changeCauseAnnotation] = changeCause
+	}
+	return rs
+}
+
+func TestRollbackDeployment(t *testing.T) {
+	deployment := func(paused bool) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "api",
+				Namespace: "default",
+				Annotations: map[string]string{
+					revisionAnnotation:    "3",
+					changeCauseAnnotation: "kubectl set image deployment/api api=api:v3",
+				},
+			},
+			Spec: appsv1.DeploymentSpec{
+				Paused:   paused,
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "api"}},
+				Template: podTemplate(map[string]string{"app": "api"}, "api", "api:v3"),
+			},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		paused          bool
+		revision        int64
+		wantImage       string
+		wantChangeCause string
+		wantErr         string
+	}{
+		{name: "change cause carried over", revision: 1, wantImage: "api:v1", wantChangeCause: "initial release"},
+		{name: "change cause dropped when the revision has none", revision: 2, wantImage: "api:v2"},
+		{name: "missing revision", revision: 9, wantErr: "revision 9 not found"},
+		{name: "paused", paused: true, revision: 1, wantErr: "paused"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewClientset(
+				deployment(tt.paused),
+				revisionReplicaSet("1", "6b7c8", "api:v1", "initial release"),
+				revisionReplicaSet("2", "5f9d4", "api:v2", ""),
+				revisionReplicaSet("3", "7d4b9", "api:v3", "kubectl set image deployment/api api=api:v3"),
+			)
+			svc := NewActionService(client)
+
+			err := svc.RollbackWorkload(context.Background(), "default", OwnerRef{Kind: KindDeployment, Name: "api"}, tt.revision)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("RollbackWorkload() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RollbackWorkload() error = %v", err)
+			}
+
+			d, err := client.AppsV1().Deployments("default").Get(context.Background(), "api", metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := d.Spec.Template.Spec.Containers[0].Image; got != tt.wantImage {
+				t.Errorf("image = %q, want %q", got, tt.wantImage)
+			}
+			if _, ok := d.Spec.Template.Labels[appsv1.DefaultDeploymentUniqueLabelKey]; ok {
+				t.Errorf("template labels = %v, want the pod-template-hash stripped", d.Spec.Template.Labels)
+			}
+			if d.Spec.Template.Labels["app"] != "api" {
+				t.Errorf("template labels = %v, want app=api kept", d.Spec.Template.Labels)
+			}
+			if got := d.Annotations[changeCauseAnnotation]; got != tt.wantChangeCause {
+				t.Errorf("change cause = %q, want %q", got, tt.wantChangeCause)
+			}
+		})
+	}
+}
+
+func TestRollbackControllerRevision(t *testing.T) {
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}}
+	revision := func(name, kind, owner string, number int64, image string) *appsv1.ControllerRevision {
+		rev := &appsv1.ControllerRevision{
+			ObjectMeta: ownedBy(name, kind, owner),
+			Revision:   number,
+			Data: runtime.RawExtension{Raw: []byte(`{"spec":{"template":{"$patch":"replace","metadata":{"labels":{"app":"db"}},` +
+				`"spec":{"containers":[{"name":"db","image":"` + image + `"}]}}}}`)},
+		}
+		rev.Labels = map[string]string{"app": "db"}
+		return rev
+	}
+
+	tests := []struct {
+		kind     string
+		revision int64
+		want     string
+		wantErr  bool
+	}{
+		{kind: KindStatefulSet, revision: 1, want: "db:v1"},
+		{kind: KindStatefulSet, revision: 7, wantErr: true},
+		{kind: KindDaemonSet, revision: 1, want: "db:v1"},
+		{kind: KindDaemonSet, revision: 7, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s revision %d", tt.kind, tt.revision), func(t *testing.T) {
+			client := fake.NewClientset(
+				&appsv1.StatefulSet{
+					ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+					Spec:       appsv1.StatefulSetSpec{Selector: selector, Template: podTemplate(selector.MatchLabels, "db", "db:v2")},
+				},
+				&appsv1.DaemonSet{
+					ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+					Spec:       appsv1.DaemonSetSpec{Selector: selector, Template: podTemplate(selector.MatchLabels, "db", "db:v2")},
+				},
+				revision("db-1", tt.kind, "db", 1, "db:v1"),
+				revision("db-2", tt.kind, "db", 2, "db:v2"),
+				// The same revision number of another workload is left alone
+				revision("other-7", tt.kind, "other", 7, "other:v7"),
+			)
+			svc := NewActionService(client)
+
+			err := svc.RollbackWorkload(context.Background(), "default", OwnerRef{Kind: tt.kind, Name: "db"}, tt.revision)
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "not found") {
+					t.Fatalf("RollbackWorkload() error = %v, want revision not found", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RollbackWorkload() error = %v", err)
+			}
+
+			var template corev1.PodTemplateSpec
+			switch tt.kind {
+			case KindStatefulSet:
+				sts, err := client.AppsV1().StatefulSets("default").Get(context.Background(), "db", metav1.GetOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				template = sts.Spec.Template
+			case KindDaemonSet:
+				ds, err := client.AppsV1().DaemonSets("default").Get(context.Background(), "db", metav1.GetOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				template = ds.Spec.Template
+			}
+			if got := template.Spec.Containers[0].Image; got != tt.want {
+				t.Errorf("image = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRollbackUnsupportedKind(t *testing.T) {
+	svc := NewActionService(fake.NewClientset())
+	err := svc.RollbackWorkload(context.Background(), "default", OwnerRef{Kind: KindReplicaSet, Name: "api-7d4b9"}, 1)
+	if err == nil || !strings.Contains(err.Error(), "can't be rolled back") {
+		t.Errorf("RollbackWorkload() error = %v, want a replicaset refused", err)
+	}
+}
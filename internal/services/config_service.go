@@ -1,26 +1,69 @@
 package services
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/util/homedir"
 )
 
-type ConfigServiceImpl struct{}
+// ConnectionOptions mirrors kubectl's connection flags. Set fields override
+// whatever the kubeconfig specifies for the context being connected to.
+type ConnectionOptions struct {
+	Impersonate           string
+	ImpersonateGroups     []string
+	RequestTimeout        string
+	InsecureSkipTLSVerify bool
+	CertificateAuthority  string
+	Server                string
+	Token                 string
+	ProxyURL              string
+}
+
+type ConfigServiceImpl struct {
+	options        ConnectionOptions
+	requestTimeout time.Duration
+}
 
 func NewConfigService() ConfigService {
 	return &ConfigServiceImpl{}
 }
 
+// NewConfigServiceWithOptions creates a config service that applies the given
+// connection overrides to every client it builds. Groups can only be
+// impersonated along with a user, as kubectl requires.
+func NewConfigServiceWithOptions(options ConnectionOptions) (ConfigService, error) {
+	if len(options.ImpersonateGroups) > 0 && options.Impersonate == "" {
+		return nil, fmt.Errorf("impersonating groups requires a user to impersonate: give --as with --as-group")
+	}
+	requestTimeout, err := parseRequestTimeout(options.RequestTimeout)
+	if err != nil {
+		return nil, err
+	}
+	return &ConfigServiceImpl{options: options, requestTimeout: requestTimeout}, nil
+}
+
 func (c *ConfigServiceImpl) DiscoverConfig() (string, error) {
-	// Check KUBECONFIG environment variable
+	// Check KUBECONFIG environment variable, which may list several files
 	if kubeconfig := os.Getenv("KUBECONFIG"); kubeconfig != "" {
-		if _, err := os.Stat(kubeconfig); err == nil {
-			return kubeconfig, nil
+		var existing []string
+		for _, path := range filepath.SplitList(kubeconfig) {
+			if _, err := os.Stat(path); err == nil {
+				existing = append(existing, path)
+			}
+		}
+		if len(existing) > 0 {
+			return JoinConfigPaths(existing), nil
 		}
 	}
 
@@ -37,38 +80,164 @@ func (c *ConfigServiceImpl) DiscoverConfig() (string, error) {
 }
 
 func (c *ConfigServiceImpl) LoadContexts(configPath string) ([]Context, string, *kubernetes.Clientset, error) {
-	// Read config file
-	configData, err := os.ReadFile(configPath)
-	if err != nil {
-		return nil, "", nil, fmt.Errorf("failed to read kubeconfig: %w", err)
-	}
-
-	// Parse kubeconfig
-	kubeconfig, err := clientcmd.LoadFromFile(configPath)
+	// Read and merge the config file(s)
+	kubeconfig, err := loadingRules(configPath).Load()
 	if err != nil {
 		return nil, "", nil, fmt.Errorf("failed to parse kubeconfig: %w", err)
 	}
 
-	// Extract contexts
-	contexts := make([]Context, 0, len(kubeconfig.Contexts))
+	// Extract contexts in a stable order so reloads keep list positions
+	names := make([]string, 0, len(kubeconfig.Contexts))
 	for name := range kubeconfig.Contexts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	contexts := make([]Context, 0, len(names))
+	for _, name := range names {
 		contexts = append(contexts, Context{Name: name})
 	}
 
 	// Get current context
 	currentContext := kubeconfig.CurrentContext
 
-	// Build REST config
-	restConfig, err := clientcmd.RESTConfigFromKubeConfig(configData)
+	// Create Kubernetes client for the current context
+	clientset, err := c.ClientForContext(configPath, currentContext)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	return contexts, currentContext, clientset, nil
+}
+
+func (c *ConfigServiceImpl) ClientForContext(configPath, contextName string) (*kubernetes.Clientset, error) {
+	restConfig, err := c.restConfig(configPath, contextName)
 	if err != nil {
-		return nil, "", nil, fmt.Errorf("failed to build REST config: %w", err)
+		return nil, err
 	}
 
-	// Create Kubernetes client
 	clientset, err := kubernetes.NewForConfig(restConfig)
 	if err != nil {
-		return nil, "", nil, fmt.Errorf("failed to create Kubernetes client: %w", err)
+		return nil, fmt.Errorf("failed to create Kubernetes client: %w", err)
 	}
 
-	return contexts, currentContext, clientset, nil
-}
\ No newline at end of file
+	return clientset, nil
+}
+
+func (c *ConfigServiceImpl) CredentialFingerprint(configPath, contextName string) (string, error) {
+	restConfig, err := c.restConfig(configPath, contextName)
+	if err != nil {
+		return "", err
+	}
+
+	// Hash everything that determines where and as whom requests are made
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s|%s|%v|%s|%s|%s|%s|%s|%s|%s|",
+		restConfig.Host,
+		restConfig.Impersonate.UserName,
+		restConfig.Impersonate.Groups,
+		restConfig.BearerToken,
+		restConfig.BearerTokenFile,
+		restConfig.Username,
+		restConfig.Password,
+		restConfig.CertFile,
+		restConfig.KeyFile,
+		restConfig.CAFile,
+	)
+	hash.Write(restConfig.CertData)
+	hash.Write(restConfig.KeyData)
+	hash.Write(restConfig.CAData)
+	if restConfig.ExecProvider != nil {
+		fmt.Fprintf(hash, "|exec:%s %v %v", restConfig.ExecProvider.Command, restConfig.ExecProvider.Args, restConfig.ExecProvider.Env)
+	}
+	if restConfig.AuthProvider != nil {
+		fmt.Fprintf(hash, "|auth:%s %v", restConfig.AuthProvider.Name, restConfig.AuthProvider.Config)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// RESTConfigForContext returns the REST config of a context, for connections
+// a clientset doesn't make, such as exec sessions
+func (c *ConfigServiceImpl) RESTConfigForContext(configPath, contextName string) (*rest.Config, error) {
+	return c.restConfig(configPath, contextName)
+}
+
+// NamespaceForContext returns the namespace a context selects, or default
+// when it selects none
+func (c *ConfigServiceImpl) NamespaceForContext(configPath, contextName string) (string, error) {
+	overrides := c.overrides()
+	overrides.CurrentContext = contextName
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules(configPath), overrides)
+
+	namespace, _, err := clientConfig.Namespace()
+	if err != nil {
+		return "", fmt.Errorf("failed to read the namespace of context %q: %w", contextName, err)
+	}
+	return namespace, nil
+}
+
+// restConfig builds the REST config for a context of the given kubeconfig
+func (c *ConfigServiceImpl) restConfig(configPath, contextName string) (*rest.Config, error) {
+	overrides := c.overrides()
+	overrides.CurrentContext = contextName
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules(configPath), overrides)
+
+	restConfig, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build REST config for context %q: %w", contextName, err)
+	}
+
+	// Unlike kubectl, which sets the HTTP client's timeout, bound requests
+	// one at a time so log streams, exec sessions and watches stay open
+	if c.requestTimeout > 0 {
+		restConfig.Wrap(func(next http.RoundTripper) http.RoundTripper {
+			return &requestTimeoutRoundTripper{next: next, timeout: c.requestTimeout}
+		})
+	}
+
+	return restConfig, nil
+}
+
+// overrides translates the connection options into kubeconfig overrides,
+// which clientcmd merges the same way kubectl does
+func (c *ConfigServiceImpl) overrides() *clientcmd.ConfigOverrides {
+	overrides := &clientcmd.ConfigOverrides{}
+
+	overrides.AuthInfo.Impersonate = c.options.Impersonate
+	overrides.AuthInfo.ImpersonateGroups = c.options.ImpersonateGroups
+	overrides.AuthInfo.Token = c.options.Token
+
+	overrides.ClusterInfo.Server = c.options.Server
+	overrides.ClusterInfo.InsecureSkipTLSVerify = c.options.InsecureSkipTLSVerify
+	overrides.ClusterInfo.CertificateAuthority = c.options.CertificateAuthority
+	overrides.ClusterInfo.ProxyURL = c.options.ProxyURL
+
+	return overrides
+}
+
+// loadingRules merges every file of a (possibly colon-separated) kubeconfig path
+func loadingRules(configPath string) *clientcmd.ClientConfigLoadingRules {
+	paths := SplitConfigPaths(configPath)
+	if len(paths) == 1 {
+		// An explicit path must exist, a merged list may have gaps
+		return &clientcmd.ClientConfigLoadingRules{ExplicitPath: paths[0]}
+	}
+	return &clientcmd.ClientConfigLoadingRules{Precedence: paths}
+}
+
+// SplitConfigPaths splits a KUBECONFIG-style path list into its files
+func SplitConfigPaths(configPath string) []string {
+	var paths []string
+	for _, path := range filepath.SplitList(configPath) {
+		if path != "" {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// JoinConfigPaths joins kubeconfig files into a KUBECONFIG-style path list
+func JoinConfigPaths(paths []string) string {
+	return strings.Join(paths, string(filepath.ListSeparator))
+}
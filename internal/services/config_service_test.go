@@ -0,0 +1,145 @@
+package services
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/rest"
+)
+
+const testKubeconfig = `apiVersion: v1
+kind: Config
+current-context: dev
+clusters:
+- name: dev
+  cluster:
+    server: https://dev.example:6443
+users:
+- name: dev
+  user:
+    token: dev-token
+contexts:
+- name: dev
+  context:
+    cluster: dev
+    user: dev
+`
+
+func TestConnectionOptionsOverrideRESTConfig(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config")
+	if err := os.WriteFile(configPath, []byte(testKubeconfig), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	caPath := filepath.Join(dir, "ca.crt")
+	if err := os.WriteFile(caPath, []byte("ca"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		options ConnectionOptions
+		check   func(config *rest.Config) (got, want any)
+	}{
+		{
+			name:    "no options",
+			options: ConnectionOptions{},
+			check: func(config *rest.Config) (any, any) {
+				return []any{config.Host, config.BearerToken, config.Timeout}, []any{"https://dev.example:6443", "dev-token", time.Duration(0)}
+			},
+		},
+		{
+			name:    "impersonated user",
+			options: ConnectionOptions{Impersonate: "jane"},
+			check: func(config *rest.Config) (any, any) {
+				return config.Impersonate.UserName, "jane"
+			},
+		},
+		{
+			name:    "impersonated groups",
+			options: ConnectionOptions{Impersonate: "jane", ImpersonateGroups: []string{"admins", "devs"}},
+			check: func(config *rest.Config) (any, any) {
+				return config.Impersonate.Groups, []string{"admins", "devs"}
+			},
+		},
+		{
+			name:    "request timeout",
+			options: ConnectionOptions{RequestTimeout: "5s"},
+			check: func(config *rest.Config) (any, any) {
+				// Bounded per request, leaving streams open
+				return []any{config.Timeout, config.WrapTransport != nil}, []any{time.Duration(0), true}
+			},
+		},
+		{
+			name:    "insecure",
+			options: ConnectionOptions{InsecureSkipTLSVerify: true},
+			check: func(config *rest.Config) (any, any) {
+				return config.TLSClientConfig.Insecure, true
+			},
+		},
+		{
+			name:    "certificate authority",
+			options: ConnectionOptions{CertificateAuthority: caPath},
+			check: func(config *rest.Config) (any, any) {
+				return config.TLSClientConfig.CAFile, caPath
+			},
+		},
+		{
+			name:    "server",
+			options: ConnectionOptions{Server: "https://other.example:6443"},
+			check: func(config *rest.Config) (any, any) {
+				return config.Host, "https://other.example:6443"
+			},
+		},
+		{
+			name:    "token",
+			options: ConnectionOptions{Token: "override-token"},
+			check: func(config *rest.Config) (any, any) {
+				return config.BearerToken, "override-token"
+			},
+		},
+		{
+			name:    "proxy",
+			options: ConnectionOptions{ProxyURL: "http://proxy.example:3128"},
+			check: func(config *rest.Config) (any, any) {
+				if config.Proxy == nil {
+					return nil, "http://proxy.example:3128"
+				}
+				proxy, err := config.Proxy(&http.Request{})
+				if err != nil || proxy == nil {
+					return err, "http://proxy.example:3128"
+				}
+				return proxy.String(), "http://proxy.example:3128"
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configSvc, err := NewConfigServiceWithOptions(tt.options)
+			if err != nil {
+				t.Fatalf("NewConfigServiceWithOptions() error = %v", err)
+			}
+			config, err := configSvc.RESTConfigForContext(configPath, "dev")
+			if err != nil {
+				t.Fatalf("RESTConfigForContext() error = %v", err)
+			}
+			if got, want := tt.check(config); !reflect.DeepEqual(got, want) {
+				t.Errorf("got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestNewConfigServiceWithOptionsRequiresUserForGroups(t *testing.T) {
+	if _, err := NewConfigServiceWithOptions(ConnectionOptions{ImpersonateGroups: []string{"admins"}}); err == nil {
+		t.Error("NewConfigServiceWithOptions() with groups but no user: want an error")
+	}
+	if _, err := NewConfigServiceWithOptions(ConnectionOptions{Impersonate: "jane", ImpersonateGroups: []string{"admins"}}); err != nil {
+		t.Errorf("NewConfigServiceWithOptions() error = %v", err)
+	}
+}
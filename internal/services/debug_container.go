@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+)
+
+// ephemeralContainerPollInterval is how often a starting ephemeral container
+// is checked
+const ephemeralContainerPollInterval = time.Second
+
+// containerStartFailures are the reasons a waiting container gives when it
+// won't start without something being changed
+var containerStartFailures = map[string]bool{
+	"ErrImagePull":               true,
+	"ImagePullBackOff":           true,
+	"InvalidImageName":           true,
+	"ErrImageNeverPull":          true,
+	"CreateContainerError":       true,
+	"CreateContainerConfigError": true,
+	"RunContainerError":          true,
+}
+
+// AddDebugContainer adds an interactive ephemeral container to a pod through
+// its ephemeralcontainers subresource, named like kubectl debug names them
+func (a *ActionServiceImpl) AddDebugContainer(ctx context.Context, req DebugRequest) (string, error) {
+	pods := a.client.CoreV1().Pods(req.Namespace)
+
+	var name string
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		pod, err := pods.Get(ctx, req.Pod, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			return fmt.Errorf("the pod has %s", pod.Status.Phase)
+		}
+
+		name = debugContainerName(pod)
+		pod.Spec.EphemeralContainers = append(pod.Spec.EphemeralContainers, corev1.EphemeralContainer{
+			EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+				Name:                     name,
+				Image:                    req.Image,
+				ImagePullPolicy:          corev1.PullIfNotPresent,
+				Stdin:                    true,
+				TTY:                      true,
+				TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+			},
+			TargetContainerName: req.Target,
+		})
+
+		_, err = pods.UpdateEphemeralContainers(ctx, req.Pod, pod, metav1.UpdateOptions{})
+		// The pod was just read, so a missing subresource is what is missing
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf("the cluster doesn't support ephemeral containers")
+		}
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to add a debug container to pod %s/%s: %w", req.Namespace, req.Pod, err)
+	}
+	return name, nil
+}
+
+// debugContainerName picks a name for a debug container no container of the
+// pod has
+func debugContainerName(pod *corev1.Pod) string {
+	taken := make(map[string]bool)
+	for _, c := range pod.Spec.Containers {
+		taken[c.Name] = true
+	}
+	for _, c := range pod.Spec.InitContainers {
+		taken[c.Name] = true
+	}
+	for _, c := range pod.Spec.EphemeralContainers {
+		taken[c.Name] = true
+	}
+	for {
+		name := "debugger-" + utilrand.String(5)
+		if !taken[name] {
+			return name
+		}
+	}
+}
+
+// WaitForEphemeralContainer polls the pod until the ephemeral container
+// runs, or until it exits or can't be created
+func (a *ActionServiceImpl) WaitForEphemeralContainer(ctx context.Context, namespace, pod, container string) error {
+	err := wait.PollUntilContextCancel(ctx, ephemeralContainerPollInterval, true, func(ctx context.Context) (bool, error) {
+		current, err := a.client.CoreV1().Pods(namespace).Get(ctx, pod, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		for _, status := range current.Status.EphemeralContainerStatuses {
+			if status.Name != container {
+				continue
+			}
+			switch state := status.State; {
+			case state.Running != nil:
+				return true, nil
+			case state.Terminated != nil:
+				return false, fmt.Errorf("it exited with code %d: %s", state.Terminated.ExitCode, state.Terminated.Reason)
+			case state.Waiting != nil && containerStartFailures[state.Waiting.Reason]:
+				return false, fmt.Errorf("%s: %s", state.Waiting.Reason, state.Waiting.Message)
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		return fmt.Errorf("container %s of pod %s/%s didn't start: %w", container, namespace, pod, err)
+	}
+	return nil
+}
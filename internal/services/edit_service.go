@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/yaml"
+)
+
+type EditServiceImpl struct {
+	client kubernetes.Interface
+}
+
+func NewEditService(client kubernetes.Interface) EditService {
+	return &EditServiceImpl{
+		client: client,
+	}
+}
+
+// DryRun replaces the resource with dryRun=All, so the server validates the
+// change and runs admission on it without saving anything
+func (e *EditServiceImpl) DryRun(ctx context.Context, kind, namespace, name, edited string) (*Manifest, error) {
+	raw, err := e.replace(ctx, kind, namespace, name, edited, true)
+	if err != nil {
+		return nil, err
+	}
+
+	object, gvk, err := scheme.Codecs.UniversalDeserializer().Decode(raw, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the dry run of %s %s/%s: %w", strings.ToLower(kind), namespace, name, err)
+	}
+	object.GetObjectKind().SetGroupVersionKind(*gvk)
+	return newManifest(object, kind, namespace, name)
+}
+
+// Apply replaces the resource. The edited YAML keeps the resourceVersion it
+// was fetched with, so a resource changed since is a conflict rather than
+// overwritten.
+func (e *EditServiceImpl) Apply(ctx context.Context, kind, namespace, name, edited string) error {
+	_, err := e.replace(ctx, kind, namespace, name, edited, false)
+	return err
+}
+
+// replace sends the edited YAML as is, so fields the typed API doesn't know
+// reach the server's strict validation instead of being dropped
+func (e *EditServiceImpl) replace(ctx context.Context, kind, namespace, name, edited string, dryRun bool) ([]byte, error) {
+	body, err := yaml.YAMLToJSON([]byte(edited))
+	if err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+	client, resource, err := e.resourceOf(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	request := client.Put().
+		Namespace(namespace).
+		Resource(resource).
+		Name(name).
+		Param("fieldValidation", "Strict").
+		SetHeader("Content-Type", "application/json").
+		Body(body)
+	if dryRun {
+		request = request.Param("dryRun", "All")
+	}
+
+	// Error reads the server's Status, which says what was rejected and why
+	result := request.Do(ctx)
+	if err := result.Error(); err != nil {
+		return nil, err
+	}
+	return result.Raw()
+}
+
+// resourceOf returns the REST client of the API group serving a kind, and
+// the kind's resource
+func (e *EditServiceImpl) resourceOf(kind string) (rest.Interface, string, error) {
+	switch kind {
+	case KindPod:
+		return e.client.CoreV1().RESTClient(), "pods", nil
+	case KindDeployment:
+		return e.client.AppsV1().RESTClient(), "deployments", nil
+	case KindStatefulSet:
+		return e.client.AppsV1().RESTClient(), "statefulsets", nil
+	case KindDaemonSet:
+		return e.client.AppsV1().RESTClient(), "daemonsets", nil
+	case KindReplicaSet:
+		return e.client.AppsV1().RESTClient(), "replicasets", nil
+	case KindJob:
+		return e.client.BatchV1().RESTClient(), "jobs", nil
+	case KindCronJob:
+		return e.client.BatchV1().RESTClient(), "cronjobs", nil
+	}
+	return nil, "", fmt.Errorf("editing a %s is not supported", kind)
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type EventServiceImpl struct {
+	client kubernetes.Interface
+}
+
+func NewEventService(client kubernetes.Interface) EventService {
+	return &EventServiceImpl{
+		client: client,
+	}
+}
+
+// ListEvents lists the events of a namespace, oldest first
+func (e *EventServiceImpl) ListEvents(ctx context.Context, namespace string) ([]Event, error) {
+	eventList, err := e.client.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events in namespace %s: %w", namespace, err)
+	}
+
+	events := make([]Event, 0, len(eventList.Items))
+	for i := range eventList.Items {
+		events = append(events, newEvent(&eventList.Items[i]))
+	}
+	sortEvents(events)
+	return events, nil
+}
+
+// newEvent converts a Kubernetes event. Events from the newer events API
+// record when they happened in EventTime and repeats in Series rather than
+// in the timestamps and count of core events.
+func newEvent(ev *corev1.Event) Event {
+	event := Event{
+		UID:       string(ev.UID),
+		Namespace: ev.Namespace,
+		Type:      ev.Type,
+		Reason:    ev.Reason,
+		Message:   ev.Message,
+		Source:    ev.Source.Component,
+		Object:    OwnerRef{Kind: ev.InvolvedObject.Kind, Name: ev.InvolvedObject.Name},
+		Count:     ev.Count,
+		FirstSeen: ev.FirstTimestamp.Time,
+		LastSeen:  ev.LastTimestamp.Time,
+	}
+	if event.Source == "" {
+		event.Source = ev.ReportingController
+	}
+
+	if event.FirstSeen.IsZero() {
+		event.FirstSeen = firstNonZero(ev.EventTime.Time, ev.CreationTimestamp.Time)
+	}
+	if ev.Series != nil {
+		event.Count = ev.Series.Count
+		if event.LastSeen.IsZero() {
+			event.LastSeen = ev.Series.LastObservedTime.Time
+		}
+	}
+	if event.LastSeen.IsZero() {
+		event.LastSeen = event.FirstSeen
+	}
+	if event.Count == 0 {
+		event.Count = 1
+	}
+	return event
+}
+
+// sortEvents orders events by when they were last seen, oldest first, like
+// a timeline
+func sortEvents(events []Event) {
+	sort.SliceStable(events, func(i, j int) bool {
+		if !events[i].LastSeen.Equal(events[j].LastSeen) {
+			return events[i].LastSeen.Before(events[j].LastSeen)
+		}
+		return events[i].UID < events[j].UID
+	})
+}
+
+func firstNonZero(times ...time.Time) time.Time {
+	for _, t := range times {
+		if !t.IsZero() {
+			return t
+		}
+	}
+	return time.Time{}
+}
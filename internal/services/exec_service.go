@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+type ExecServiceImpl struct {
+	client kubernetes.Interface
+	config *rest.Config
+}
+
+// NewExecService creates an exec service. Besides the client it needs the
+// REST config the client was built from, to open streaming connections.
+func NewExecService(client kubernetes.Interface, config *rest.Config) ExecService {
+	return &ExecServiceImpl{
+		client: client,
+		config: config,
+	}
+}
+
+// Exec runs a command in a container until it exits, connecting it to the
+// request's streams
+func (e *ExecServiceImpl) Exec(ctx context.Context, req ExecRequest) error {
+	request := e.client.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(req.Namespace).
+		Name(req.Pod).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: req.Container,
+			Command:   req.Command,
+			Stdin:     req.Stdin != nil,
+			Stdout:    req.Stdout != nil,
+			Stderr:    req.Stderr != nil && !req.TTY,
+			TTY:       req.TTY,
+		}, scheme.ParameterCodec)
+
+	if err := e.stream(ctx, request, req); err != nil {
+		return fmt.Errorf("exec in %s/%s (container %s) failed: %w", req.Namespace, req.Pod, req.Container, err)
+	}
+	return nil
+}
+
+// Attach connects the request's streams to the running process of a
+// container until it exits or the streams close
+func (e *ExecServiceImpl) Attach(ctx context.Context, req ExecRequest) error {
+	request := e.client.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(req.Namespace).
+		Name(req.Pod).
+		SubResource("attach").
+		VersionedParams(&corev1.PodAttachOptions{
+			Container: req.Container,
+			Stdin:     req.Stdin != nil,
+			Stdout:    req.Stdout != nil,
+			Stderr:    req.Stderr != nil && !req.TTY,
+			TTY:       req.TTY,
+		}, scheme.ParameterCodec)
+
+	if err := e.stream(ctx, request, req); err != nil {
+		return fmt.Errorf("attach to %s/%s (container %s) failed: %w", req.Namespace, req.Pod, req.Container, err)
+	}
+	return nil
+}
+
+// stream connects the request's streams to an exec or attach request. Like
+// kubectl it talks WebSocket to the API server, falling back to SPDY for
+// servers that don't support it.
+func (e *ExecServiceImpl) stream(ctx context.Context, request *rest.Request, req ExecRequest) error {
+	spdy, err := remotecommand.NewSPDYExecutor(e.config, "POST", request.URL())
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s/%s: %w", req.Namespace, req.Pod, err)
+	}
+	websocket, err := remotecommand.NewWebSocketExecutor(e.config, "GET", request.URL().String())
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s/%s: %w", req.Namespace, req.Pod, err)
+	}
+	executor, err := remotecommand.NewFallbackExecutor(websocket, spdy, func(err error) bool {
+		return httpstream.IsUpgradeFailure(err) || httpstream.IsHTTPSProxyError(err)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s/%s: %w", req.Namespace, req.Pod, err)
+	}
+
+	options := remotecommand.StreamOptions{
+		Stdin:  req.Stdin,
+		Stdout: req.Stdout,
+		Tty:    req.TTY,
+	}
+	if !req.TTY {
+		options.Stderr = req.Stderr
+	}
+	if req.Resize != nil {
+		options.TerminalSizeQueue = terminalSizeQueue(req.Resize)
+	}
+	return executor.StreamWithContext(ctx, options)
+}
+
+// terminalSizeQueue hands the sizes of a channel to the executor
+type terminalSizeQueue <-chan TerminalSize
+
+// Next blocks until the terminal is resized, returning nil once the channel
+// is closed
+func (q terminalSizeQueue) Next() *remotecommand.TerminalSize {
+	size, ok := <-q
+	if !ok {
+		return nil
+	}
+	return &remotecommand.TerminalSize{Width: size.Width, Height: size.Height}
+}
+
+// ShellCommand returns the command that starts a shell in a container.
+// ShellAuto prefers bash and falls back to sh, which every image with a
+// shell has.
+func ShellCommand(shell string) []string {
+	switch shell {
+	case ShellBash, ShellSh:
+		return []string{shell}
+	default:
+		return []string{"/bin/sh", "-c", "command -v bash >/dev/null 2>&1 && exec bash || exec sh"}
+	}
+}
@@ -0,0 +1,282 @@
+package services
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// lsLine matches an entry of ls -ln: mode, links, owner, group, size (or a
+// device's numbers), the three fields of the time, and the name
+var lsLine = regexp.MustCompile(`^(\S+)\s+\d+\s+\S+\s+\S+\s+(\d+|\d+,\s*\d+)\s+(\S+\s+\S+\s+\S+)\s(.*)$`)
+
+type FileServiceImpl struct {
+	exec ExecService
+}
+
+// NewFileService creates a file service. Besides the client it needs the
+// REST config the client was built from, to exec in containers.
+func NewFileService(client kubernetes.Interface, config *rest.Config) FileService {
+	return &FileServiceImpl{
+		exec: NewExecService(client, config),
+	}
+}
+
+// Download is a copy of a file or directory out of a container. It runs
+// until done, failed or cancelled.
+type Download struct {
+	DownloadRequest
+	StartedAt time.Time
+
+	copied atomic.Int64
+	files  atomic.Int64
+
+	cancel   context.CancelFunc
+	canceled atomic.Bool
+	done     chan struct{}
+	err      error
+}
+
+// BytesCopied returns how many bytes of file content were written locally
+func (d *Download) BytesCopied() int64 {
+	return d.copied.Load()
+}
+
+// FilesCopied returns how many files were written locally
+func (d *Download) FilesCopied() int64 {
+	return d.files.Load()
+}
+
+// Cancel stops the download, leaving what was copied so far
+func (d *Download) Cancel() {
+	d.canceled.Store(true)
+	d.cancel()
+}
+
+// Done is closed once the download has ended
+func (d *Download) Done() <-chan struct{} {
+	return d.done
+}
+
+// Err returns why the download failed, context.Canceled when cancelled, and
+// nil while it runs or once it succeeded
+func (d *Download) Err() error {
+	select {
+	case <-d.done:
+		return d.err
+	default:
+		return nil
+	}
+}
+
+// List runs ls in the container, listing a directory with the files hidden
+// by a leading dot. A symbolic link to a directory is listed as the
+// directory it points to.
+func (f *FileServiceImpl) List(ctx context.Context, namespace, pod, container, dir string) ([]RemoteFile, error) {
+	var stdout, stderr bytes.Buffer
+	err := f.exec.Exec(ctx, ExecRequest{
+		Namespace: namespace,
+		Pod:       pod,
+		Container: container,
+		Command:   []string{"ls", "-lAn", "--", directoryPath(dir)},
+		Stdout:    &stdout,
+		Stderr:    &stderr,
+	})
+	// ls fails for an unreadable entry but still lists the others
+	if err != nil && stdout.Len() == 0 {
+		return nil, execError(fmt.Sprintf("failed to list %s", dir), err, &stderr)
+	}
+	return parseListing(stdout.String()), nil
+}
+
+// directoryPath makes ls list what a directory, or a link to one, holds
+func directoryPath(dir string) string {
+	dir = path.Clean("/" + dir)
+	if dir == "/" {
+		return dir
+	}
+	return dir + "/"
+}
+
+// parseListing reads the entries of ls -ln, skipping its total
+func parseListing(output string) []RemoteFile {
+	var files []RemoteFile
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		match := lsLine.FindStringSubmatch(scanner.Text())
+		if match == nil {
+			continue
+		}
+		file := RemoteFile{
+			Name:    match[4],
+			Mode:    match[1],
+			ModTime: strings.Join(strings.Fields(match[3]), " "),
+			IsDir:   strings.HasPrefix(match[1], "d"),
+		}
+		file.Size, _ = strconv.ParseInt(match[2], 10, 64)
+		if strings.HasPrefix(file.Mode, "l") {
+			file.Name, file.Link, _ = strings.Cut(file.Name, " -> ")
+		}
+		if file.Name == "." || file.Name == ".." {
+			continue
+		}
+		files = append(files, file)
+	}
+	return files
+}
+
+// Download streams the file or directory out of the container as a tar
+// archive, writing it to the local path. Symbolic links and special files
+// are skipped, as kubectl cp does.
+func (f *FileServiceImpl) Download(req DownloadRequest) *Download {
+	ctx, cancel := context.WithCancel(context.Background())
+	download := &Download{
+		DownloadRequest: req,
+		StartedAt:       time.Now(),
+		cancel:          cancel,
+		done:            make(chan struct{}),
+	}
+
+	remote := path.Clean("/" + req.Path)
+	parent, base := path.Split(remote)
+	if base == "" {
+		parent, base = "/", "."
+	}
+
+	go func() {
+		defer close(download.done)
+		defer cancel()
+
+		reader, writer := io.Pipe()
+		var stderr bytes.Buffer
+		execDone := make(chan error, 1)
+		go func() {
+			err := f.exec.Exec(ctx, ExecRequest{
+				Namespace: req.Namespace,
+				Pod:       req.Pod,
+				Container: req.Container,
+				Command:   []string{"tar", "cf", "-", "-C", parent, base},
+				Stdout:    writer,
+				Stderr:    &stderr,
+			})
+			writer.CloseWithError(err)
+			execDone <- err
+		}()
+
+		err := untar(reader, base, req.Local, download)
+		if err != nil {
+			// Stop the copy in the container as well
+			cancel()
+			reader.CloseWithError(err)
+		} else {
+			// The padding after the archive's last entry is still read
+			io.Copy(io.Discard, reader)
+		}
+		execErr := <-execDone
+
+		switch {
+		case download.canceled.Load():
+			download.err = context.Canceled
+		case err != nil && (execErr == nil || !errors.Is(err, execErr)):
+			download.err = fmt.Errorf("failed to copy %s: %w", remote, err)
+		case execErr != nil:
+			download.err = execError(fmt.Sprintf("failed to copy %s", remote), execErr, &stderr)
+		}
+	}()
+	return download
+}
+
+// untar writes the entries of a tar archive under base to the local path,
+// refusing any that would land outside it
+func untar(r io.Reader, base, local string, download *Download) error {
+	archive := tar.NewReader(r)
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		name := path.Clean(header.Name)
+		var rel string
+		switch {
+		case name == base:
+		case base == ".":
+			rel = name
+		case strings.HasPrefix(name, base+"/"):
+			rel = strings.TrimPrefix(name, base+"/")
+		default:
+			return fmt.Errorf("unexpected entry %q in the archive", header.Name)
+		}
+		if rel == ".." || strings.HasPrefix(rel, "../") || path.IsAbs(rel) {
+			return fmt.Errorf("entry %q would be written outside %s", header.Name, local)
+		}
+		target := filepath.Join(local, filepath.FromSlash(rel))
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := writeFile(target, archive, header.FileInfo().Mode().Perm(), download); err != nil {
+				return err
+			}
+			download.files.Add(1)
+		}
+	}
+}
+
+// writeFile copies a file of the archive to disk, counting its bytes
+func writeFile(target string, r io.Reader, perm os.FileMode, download *Download) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm|0o200)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(file, &countingReader{reader: r, count: &download.copied})
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// countingReader counts the bytes read through it
+type countingReader struct {
+	reader io.Reader
+	count  *atomic.Int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.reader.Read(p)
+	c.count.Add(int64(n))
+	return n, err
+}
+
+// execError explains a failed command with what it printed to stderr, such
+// as a missing file or a container without ls or tar
+func execError(what string, err error, stderr *bytes.Buffer) error {
+	if message := strings.TrimSpace(stderr.String()); message != "" {
+		return fmt.Errorf("%s: %s", what, message)
+	}
+	return fmt.Errorf("%s: %w", what, err)
+}
@@ -0,0 +1,193 @@
+package services
+
+import (
+	"archive/tar"
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// tarEntry is an entry of a crafted archive
+type tarEntry struct {
+	name     string
+	typeflag byte
+	body     string
+	linkname string
+}
+
+func tarball(t *testing.T, entries []tarEntry) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	archive := tar.NewWriter(&buf)
+	for _, entry := range entries {
+		header := &tar.Header{Name: entry.name, Typeflag: entry.typeflag, Linkname: entry.linkname, Mode: 0o644}
+		if entry.typeflag == tar.TypeReg {
+			header.Size = int64(len(entry.body))
+		}
+		if err := archive.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := archive.Write([]byte(entry.body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return &buf
+}
+
+func TestUntar(t *testing.T) {
+	file := func(name, body string) tarEntry { return tarEntry{name: name, typeflag: tar.TypeReg, body: body} }
+	dir := func(name string) tarEntry { return tarEntry{name: name, typeflag: tar.TypeDir} }
+
+	tests := []struct {
+		name    string
+		base    string
+		entries []tarEntry
+		want    map[string]string
+		wantErr string
+	}{
+		{
+			name:    "directory",
+			base:    "logs",
+			entries: []tarEntry{dir("logs/"), file("logs/app.log", "started"), dir("logs/old/"), file("logs/old/app.log.1", "stopped")},
+			want:    map[string]string{"app.log": "started", "old/app.log.1": "stopped"},
+		},
+		{
+			name:    "single file",
+			base:    "app.log",
+			entries: []tarEntry{file("app.log", "started")},
+			want:    map[string]string{"": "started"},
+		},
+		{
+			name:    "root",
+			base:    ".",
+			entries: []tarEntry{dir("./"), file("etc/hostname", "api-7d9f")},
+			want:    map[string]string{"etc/hostname": "api-7d9f"},
+		},
+		{
+			name:    "parent entry",
+			base:    ".",
+			entries: []tarEntry{file("../escape", "owned")},
+			wantErr: "outside",
+		},
+		{
+			name:    "parent entry inside the base",
+			base:    "logs",
+			entries: []tarEntry{file("logs/../../escape", "owned")},
+			wantErr: "unexpected entry",
+		},
+		{
+			name:    "absolute name",
+			base:    ".",
+			entries: []tarEntry{file("/escape", "owned")},
+			wantErr: "outside",
+		},
+		{
+			name:    "absolute name with a base",
+			base:    "logs",
+			entries: []tarEntry{file("/logs/app.log", "owned")},
+			wantErr: "unexpected entry",
+		},
+		{
+			name:    "outside the base",
+			base:    "logs",
+			entries: []tarEntry{file("logs/app.log", "started"), file("secrets/token", "owned")},
+			wantErr: "unexpected entry",
+		},
+		{
+			name:    "sharing the base's prefix",
+			base:    "logs",
+			entries: []tarEntry{file("logs-old/app.log", "owned")},
+			wantErr: "unexpected entry",
+		},
+		{
+			// Links are skipped, so a file under one stays in the destination
+			name: "links skipped",
+			base: "logs",
+			entries: []tarEntry{
+				dir("logs/"),
+				{name: "logs/escape", typeflag: tar.TypeSymlink, linkname: "../.."},
+				{name: "logs/passwd", typeflag: tar.TypeLink, linkname: "/etc/passwd"},
+				file("logs/escape/owned", "contained"),
+				file("logs/app.log", "started"),
+			},
+			want: map[string]string{"escape/owned": "contained", "app.log": "started"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			local := filepath.Join(root, "dest")
+
+			err := untar(tarball(t, tt.entries), tt.base, local, &Download{})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("untar() error = %v, want %q", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("untar() error = %v", err)
+			}
+
+			// Nothing is written outside the destination, nor any link
+			got := map[string]string{}
+			err = filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+				if err != nil {
+					return err
+				}
+				if info.Mode()&os.ModeSymlink != 0 {
+					t.Errorf("link %s was created", path)
+				}
+				if info.IsDir() {
+					return nil
+				}
+				rel, err := filepath.Rel(local, path)
+				if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+					t.Errorf("%s was written outside %s", path, local)
+					return nil
+				}
+				if rel == "." {
+					rel = ""
+				}
+				body, err := os.ReadFile(path)
+				if err != nil {
+					return err
+				}
+				got[filepath.ToSlash(rel)] = string(body)
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.wantErr == "" && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("files = %v, want %v", got, tt.want)
+			}
+			// Nor are refused entries written inside it
+			for name, body := range got {
+				if body == "owned" {
+					t.Errorf("refused entry written to %s", name)
+				}
+			}
+		})
+	}
+}
+
+func TestUntarCountsFiles(t *testing.T) {
+	download := &Download{}
+	entries := []tarEntry{
+		{name: "logs/", typeflag: tar.TypeDir},
+		{name: "logs/a.log", typeflag: tar.TypeReg, body: "abc"},
+		{name: "logs/b.log", typeflag: tar.TypeReg, body: "defg"},
+	}
+	if err := untar(tarball(t, entries), "logs", t.TempDir(), download); err != nil {
+		t.Fatalf("untar() error = %v", err)
+	}
+	if download.FilesCopied() != 2 || download.BytesCopied() != 7 {
+		t.Errorf("copied %d files and %d bytes, want 2 and 7", download.FilesCopied(), download.BytesCopied())
+	}
+}
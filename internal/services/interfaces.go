@@ -2,9 +2,12 @@ package services
 
 import (
 	"context"
+	"errors"
 	"io"
+	"time"
 
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 )
 
 type Pod struct {
@@ -12,6 +15,51 @@ type Pod struct {
 	Namespace string
 	Status    PodStatus
 	Labels    map[string]string
+
+	// DisplayStatus is the status kubectl shows, such as CrashLoopBackOff,
+	// Init:0/1, Completed or Terminating
+	DisplayStatus   string
+	ReadyContainers int
+	TotalContainers int
+	Restarts        int
+	CreatedAt       time.Time
+	NodeName        string
+	PodIP           string
+	QOSClass        string
+
+	// Containers names the pod's containers, in the order of its spec
+	Containers []string
+
+	// Ports are the ports its containers declare
+	Ports []ContainerPort
+
+	// Requests and Limits total the resources of the pod's containers. A
+	// limit is zero when any container runs without one.
+	Requests ResourceAmounts
+	Limits   ResourceAmounts
+
+	// Owner is the controller that created the pod, such as a ReplicaSet or
+	// Job; it is empty for pods created directly
+	Owner OwnerRef
+}
+
+// ContainerPort is a port a container declares
+type ContainerPort struct {
+	Container string
+	Name      string
+	Port      int32
+	Protocol  string
+}
+
+// OwnerRef identifies the controller of a resource
+type OwnerRef struct {
+	Kind string
+	Name string
+}
+
+// IsZero reports whether the reference is empty
+func (o OwnerRef) IsZero() bool {
+	return o.Kind == "" && o.Name == ""
 }
 
 type PodStatus string
@@ -31,12 +79,395 @@ type Context struct {
 type ConfigService interface {
 	DiscoverConfig() (string, error)
 	LoadContexts(configPath string) ([]Context, string, *kubernetes.Clientset, error)
+	ClientForContext(configPath, contextName string) (*kubernetes.Clientset, error)
+	CredentialFingerprint(configPath, contextName string) (string, error)
+
+	// RESTConfigForContext gives the REST config of a context, which
+	// streaming connections such as exec need
+	RESTConfigForContext(configPath, contextName string) (*rest.Config, error)
+
+	// NamespaceForContext gives the namespace a context selects, which
+	// commands default to as kubectl does
+	NamespaceForContext(configPath, contextName string) (string, error)
 }
 
 type PodService interface {
 	ListPods(ctx context.Context, namespace string) ([]Pod, error)
 	SearchPods(ctx context.Context, namespace, query string) ([]Pod, error)
 	GetPodLogs(ctx context.Context, podName, namespace string) (io.ReadCloser, error)
+
+	// StreamPodLogs follows logs with options, such as those of one
+	// container of a pod with sidecars
+	StreamPodLogs(ctx context.Context, podName, namespace string, options LogOptions) (io.ReadCloser, error)
+}
+
+// LogOptions narrow the logs a stream follows. The zero value follows the
+// pod's only container from its start, as GetPodLogs does.
+type LogOptions struct {
+	// Container names the container of a pod with several
+	Container string
+
+	// SinceTime leaves out lines logged before it, when set
+	SinceTime time.Time
+}
+
+type DescribeService interface {
+	DescribePod(ctx context.Context, namespace, name string) (*PodDescription, error)
+}
+
+// KindPod is the kind of pods, for services that handle pods and workloads
+// alike
+const KindPod = "Pod"
+
+// Manifest is a resource rendered as YAML
+type Manifest struct {
+	Kind      string
+	Namespace string
+	Name      string
+
+	// YAML leaves out metadata.managedFields, which FullYAML keeps
+	YAML     string
+	FullYAML string
+}
+
+type ManifestService interface {
+	GetManifest(ctx context.Context, kind, namespace, name string) (*Manifest, error)
+}
+
+// ManifestEdit is a change made to the YAML of a resource in an editor.
+// Result is the YAML a server-side dry run of the change produced, and Diff
+// compares it with Original line by line.
+type ManifestEdit struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Original  string
+	Edited    string
+	Result    string
+	Diff      []DiffLine
+}
+
+// Changed reports whether the dry run left the resource any different
+func (e *ManifestEdit) Changed() bool {
+	for _, line := range e.Diff {
+		if line.Op != DiffEqual {
+			return true
+		}
+	}
+	return false
+}
+
+// DiffOp is what a line of a diff does to the text before it
+type DiffOp int
+
+const (
+	DiffEqual DiffOp = iota
+	DiffInsert
+	DiffDelete
+)
+
+// DiffLine is a line of a diff, kept, added or removed
+type DiffLine struct {
+	Op   DiffOp
+	Text string
+}
+
+// EditService checks and saves changes to the YAML of pods and workloads.
+// The server validates the YAML strictly, so misspelt fields are errors.
+type EditService interface {
+	// DryRun sends the edited YAML without saving it, returning the
+	// resource as the change would leave it. Validation and admission
+	// errors are returned as the server words them.
+	DryRun(ctx context.Context, kind, namespace, name, edited string) (*Manifest, error)
+
+	// Apply replaces the resource with the edited YAML
+	Apply(ctx context.Context, kind, namespace, name, edited string) error
+}
+
+// ResourceAmounts are amounts of CPU, in millicores, and memory, in bytes
+type ResourceAmounts struct {
+	CPU    int64
+	Memory int64
+}
+
+// Add returns the sum of two amounts
+func (r ResourceAmounts) Add(other ResourceAmounts) ResourceAmounts {
+	return ResourceAmounts{CPU: r.CPU + other.CPU, Memory: r.Memory + other.Memory}
+}
+
+// PodMetrics is what a pod currently uses, as reported by metrics-server
+type PodMetrics struct {
+	Namespace string
+	Name      string
+	Usage     ResourceAmounts
+}
+
+// ErrMetricsUnavailable is returned when the cluster doesn't serve the
+// metrics.k8s.io API, as when metrics-server isn't installed
+var ErrMetricsUnavailable = errors.New("resource metrics are not available")
+
+type MetricsService interface {
+	ListPodMetrics(ctx context.Context, namespace string) ([]PodMetrics, error)
+}
+
+// Shells that can be asked for in a container; ShellAuto starts bash where
+// the container has it and sh otherwise
+const (
+	ShellAuto = "auto"
+	ShellBash = "bash"
+	ShellSh   = "sh"
+)
+
+// TerminalSize is the size of a terminal in cells
+type TerminalSize struct {
+	Width  uint16
+	Height uint16
+}
+
+// ExecRequest describes a command to run in a container. With TTY set the
+// command gets a terminal, and stderr arrives on Stdout.
+type ExecRequest struct {
+	Namespace string
+	Pod       string
+	Container string
+	Command   []string
+
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+	TTY    bool
+
+	// Resize delivers the terminal's size whenever it changes, until closed
+	Resize <-chan TerminalSize
+}
+
+type ExecService interface {
+	Exec(ctx context.Context, req ExecRequest) error
+
+	// Attach connects the request's streams to the main process of a
+	// running container, such as an ephemeral debug container; the
+	// request's command is ignored
+	Attach(ctx context.Context, req ExecRequest) error
+}
+
+// PortForwardRequest asks for a local port to be forwarded to a port of a
+// pod. A zero LocalPort picks any free port.
+type PortForwardRequest struct {
+	Namespace  string
+	Pod        string
+	LocalPort  int
+	RemotePort int
+}
+
+type PortForwardService interface {
+	// Forward starts forwarding, returning once the local port listens
+	Forward(ctx context.Context, req PortForwardRequest) (*PortForward, error)
+
+	// ResolveService finds a ready pod backing a Service and the pod's port
+	// behind a port of the Service, or behind its first port when zero
+	ResolveService(ctx context.Context, namespace, name string, port int) (pod string, podPort int, err error)
+}
+
+// RemoteFile is an entry of a directory in a container, as ls lists it
+type RemoteFile struct {
+	Name string
+
+	// Mode is the file's type and permissions, e.g. drwxr-xr-x
+	Mode string
+	Size int64
+
+	// ModTime is when the file last changed, as ls shows it
+	ModTime string
+	IsDir   bool
+
+	// Link is where a symbolic link points
+	Link string
+}
+
+// DownloadRequest asks for a file or directory of a container to be copied
+// to a local path. Size is the size of a file, zero when unknown.
+type DownloadRequest struct {
+	Namespace string
+	Pod       string
+	Container string
+	Path      string
+	Local     string
+	Size      int64
+}
+
+// FileService browses the filesystems of containers and copies files out
+// of them, running ls and tar in the container like kubectl cp does
+type FileService interface {
+	// List lists a directory of a container
+	List(ctx context.Context, namespace, pod, container, dir string) ([]RemoteFile, error)
+
+	// Download starts copying, which goes on in the background until done
+	// or cancelled
+	Download(req DownloadRequest) *Download
+}
+
+// Workload kinds
+const (
+	KindDeployment  = "Deployment"
+	KindStatefulSet = "StatefulSet"
+	KindDaemonSet   = "DaemonSet"
+	KindReplicaSet  = "ReplicaSet"
+	KindJob         = "Job"
+	KindCronJob     = "CronJob"
+)
+
+// Workload is a controller of pods: a Deployment, StatefulSet, DaemonSet,
+// ReplicaSet, Job or CronJob
+type Workload struct {
+	Kind      string
+	Name      string
+	Namespace string
+	CreatedAt time.Time
+
+	// Ready and Desired count pods; Jobs count completions and CronJobs
+	// count active jobs with no desired count
+	Ready     int
+	Desired   int
+	UpToDate  int
+	Available int
+
+	// Status summarises the rollout or run, e.g. Progressing, Complete,
+	// Failed, Paused or Suspended
+	Status string
+
+	// Selector is a label selector matching the workload's pods
+	Selector string
+
+	// CronJob schedule and when it last created a job
+	Schedule     string
+	LastSchedule time.Time
+
+	// Controllers are the owners through which the workload's pods belong
+	// to it: its ReplicaSets or Jobs, or the workload itself
+	Controllers []OwnerRef
+}
+
+type WorkloadService interface {
+	ListWorkloads(ctx context.Context, namespace string) ([]Workload, error)
+	ListWorkloadsOfKind(ctx context.Context, namespace, kind string) ([]Workload, error)
+}
+
+// ActionService makes the changes to the cluster offered from the TUI
+type ActionService interface {
+	// DeletePod deletes a pod; force skips the grace period, as for a pod
+	// stuck Terminating on a node that is gone
+	DeletePod(ctx context.Context, namespace, name string, force bool) error
+
+	// WorkloadOf finds the workload behind a pod's controller, resolving a
+	// ReplicaSet to the Deployment that owns it
+	WorkloadOf(ctx context.Context, namespace string, owner OwnerRef) (OwnerRef, error)
+
+	// RestartWorkload restarts the pods of a Deployment, StatefulSet or
+	// DaemonSet with a rolling update, as kubectl rollout restart does
+	RestartWorkload(ctx context.Context, namespace string, workload OwnerRef) error
+
+	// ScaleWorkload sets the replicas of a Deployment or StatefulSet
+	ScaleWorkload(ctx context.Context, namespace string, workload OwnerRef, replicas int32) error
+
+	// RollbackWorkload rolls a workload back to the pod template of a
+	// revision in its history, as kubectl rollout undo does
+	RollbackWorkload(ctx context.Context, namespace string, workload OwnerRef, revision int64) error
+
+	// AddDebugContainer adds an ephemeral container running an image to a
+	// pod, sharing the process namespace of the target container when one
+	// is named, and returns the new container's name
+	AddDebugContainer(ctx context.Context, req DebugRequest) (string, error)
+
+	// WaitForEphemeralContainer waits until an ephemeral container of a pod
+	// runs, failing when it can't start, as when its image can't be pulled
+	WaitForEphemeralContainer(ctx context.Context, namespace, pod, container string) error
+}
+
+// DebugRequest describes an ephemeral container to add to a pod for
+// debugging. Target is the container whose processes it sees; empty shares
+// only the pod's network.
+type DebugRequest struct {
+	Namespace string
+	Pod       string
+	Target    string
+	Image     string
+}
+
+// Revision is a revision of a workload's pod template, kept by a Deployment
+// in a ReplicaSet and by a StatefulSet or DaemonSet in a ControllerRevision
+type Revision struct {
+	Number      int64
+	Name        string
+	CreatedAt   time.Time
+	ChangeCause string
+	Images      []ContainerImage
+
+	// Current marks the revision the workload runs, or is rolling out
+	Current bool
+}
+
+// ContainerImage is the image a container of a pod template runs
+type ContainerImage struct {
+	Container string
+	Image     string
+}
+
+// RolloutStatus is how far a workload's rollout has got
+type RolloutStatus struct {
+	// Message says what the rollout waits for, or how it ended, as
+	// kubectl rollout status does
+	Message string
+	Done    bool
+
+	// Failed is set when a Deployment exceeded its progress deadline
+	Failed bool
+}
+
+type RolloutService interface {
+	// History lists the revisions of a Deployment, StatefulSet or
+	// DaemonSet, oldest first
+	History(ctx context.Context, namespace string, workload OwnerRef) ([]Revision, error)
+
+	// Status reports the progress of a workload's rollout
+	Status(ctx context.Context, namespace string, workload OwnerRef) (RolloutStatus, error)
+}
+
+// Event types
+const (
+	EventNormal  = "Normal"
+	EventWarning = "Warning"
+)
+
+// Event is a Kubernetes event about an object in a namespace
+type Event struct {
+	UID       string
+	Namespace string
+	Type      string
+	Reason    string
+	Message   string
+	Source    string
+
+	// Object is the object the event is about, such as a Pod or Deployment
+	Object OwnerRef
+
+	// Count is how many times the event happened between FirstSeen and LastSeen
+	Count     int32
+	FirstSeen time.Time
+	LastSeen  time.Time
+}
+
+// IsWarning reports whether the event is a Warning rather than Normal
+func (e Event) IsWarning() bool {
+	return e.Type == EventWarning
+}
+
+// IsAbout reports whether the event concerns the given object
+func (e Event) IsAbout(kind, name string) bool {
+	return e.Object.Kind == kind && e.Object.Name == name
+}
+
+type EventService interface {
+	ListEvents(ctx context.Context, namespace string) ([]Event, error)
 }
 
 type Namespace struct {
@@ -0,0 +1,56 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+)
+
+// KubeconfigWatcher detects changes to the kubeconfig file(s) by comparing
+// content hashes between polls. Polling survives the write-to-temp-and-rename
+// pattern used by `aws eks update-kubeconfig` and most token refresh scripts.
+type KubeconfigWatcher struct {
+	paths  []string
+	hashes map[string]string
+}
+
+// NewKubeconfigWatcher creates a watcher for a (possibly colon-separated) kubeconfig path
+func NewKubeconfigWatcher(configPath string) *KubeconfigWatcher {
+	w := &KubeconfigWatcher{
+		paths:  SplitConfigPaths(configPath),
+		hashes: make(map[string]string),
+	}
+	for _, path := range w.paths {
+		w.hashes[path] = hashFile(path)
+	}
+	return w
+}
+
+// Paths returns the files being watched
+func (w *KubeconfigWatcher) Paths() []string {
+	return w.paths
+}
+
+// Changed reports whether any watched file changed since the last call.
+// A file that is temporarily missing is not reported until it reappears.
+func (w *KubeconfigWatcher) Changed() bool {
+	changed := false
+	for _, path := range w.paths {
+		hash := hashFile(path)
+		if hash == "" || hash == w.hashes[path] {
+			continue
+		}
+		w.hashes[path] = hash
+		changed = true
+	}
+	return changed
+}
+
+func hashFile(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
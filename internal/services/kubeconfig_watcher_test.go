@@ -0,0 +1,62 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestKubeconfigWatcherChanged(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, "first")
+	second := filepath.Join(dir, "second")
+	write := func(path, content string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(first, "first")
+	write(second, "second")
+
+	w := NewKubeconfigWatcher(first + string(filepath.ListSeparator) + second)
+	if got := w.Paths(); len(got) != 2 || got[0] != first || got[1] != second {
+		t.Fatalf("Paths() = %v, want [%s %s]", got, first, second)
+	}
+
+	steps := []struct {
+		name   string
+		change func()
+		want   bool
+	}{
+		{"nothing written", func() {}, false},
+		{"same content written", func() { write(first, "first") }, false},
+		{"second file changed", func() { write(second, "second changed") }, true},
+		{"change already reported", func() {}, false},
+		{"first file changed", func() { write(first, "first changed") }, true},
+		{"file removed", func() { os.Remove(first) }, false},
+		{"file back as it was", func() { write(first, "first changed") }, false},
+		{"file back changed", func() { os.Remove(first); write(first, "first again") }, true},
+	}
+	for _, step := range steps {
+		step.change()
+		if got := w.Changed(); got != step.want {
+			t.Errorf("%s: Changed() = %v, want %v", step.name, got, step.want)
+		}
+	}
+}
+
+func TestKubeconfigWatcherMissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	w := NewKubeconfigWatcher(path)
+	if w.Changed() {
+		t.Error("Changed() = true for a file that doesn't exist")
+	}
+
+	if err := os.WriteFile(path, []byte("created"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if !w.Changed() {
+		t.Error("Changed() = false once the file is created")
+	}
+}
@@ -0,0 +1,57 @@
+package services
+
+import "strings"
+
+// Log levels, as guessed from the words of a log line
+const (
+	LogLevelError = "error"
+	LogLevelWarn  = "warn"
+	LogLevelInfo  = "info"
+	LogLevelDebug = "debug"
+)
+
+// LogLine is a line read from a stream of MergeLogStreams: the pod that
+// logged it, the text as logged and the level guessed from it, if any
+type LogLine struct {
+	Pod   string
+	Text  string
+	Level string
+}
+
+// ParseMergedLogLine splits a line of a merged stream into the pod its
+// prefix names and the text that follows
+func ParseMergedLogLine(line string) LogLine {
+	parsed := LogLine{Text: line}
+	if rest, ok := strings.CutPrefix(line, "["); ok {
+		if pod, text, ok := strings.Cut(rest, "] "); ok {
+			parsed.Pod = pod
+			parsed.Text = text
+		}
+	}
+	parsed.Level = LogLevelOf(parsed.Text)
+	return parsed
+}
+
+// LogLevelOf guesses the level of a log line from the words error, warn,
+// info and debug, ignoring case; a line with several takes the most severe.
+// It is empty for a line with none of them.
+func LogLevelOf(line string) string {
+	lower := strings.ToLower(line)
+	for _, level := range []string{LogLevelError, LogLevelWarn, LogLevelInfo, LogLevelDebug} {
+		if strings.Contains(lower, level) {
+			return level
+		}
+	}
+	return ""
+}
+
+// LogFilter selects the log lines containing a query, ignoring case, as the
+// log view's search does. Without a query it selects every line.
+type LogFilter struct {
+	Query string
+}
+
+// Matches reports whether the filter selects a line
+func (f LogFilter) Matches(line string) bool {
+	return f.Query == "" || strings.Contains(strings.ToLower(line), strings.ToLower(f.Query))
+}
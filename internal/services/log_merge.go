@@ -0,0 +1,117 @@
+package services
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"sort"
+	"sync"
+)
+
+// mergedLogs interleaves several log streams line by line
+type mergedLogs struct {
+	reader  *io.PipeReader
+	streams []io.ReadCloser
+	once    sync.Once
+}
+
+// MergeLogStreams interleaves the lines of several log streams as they
+// arrive, prefixing each line with the name of its stream in brackets. The
+// merged stream ends when every stream has ended; closing it closes them all.
+func MergeLogStreams(streams map[string]io.ReadCloser) io.ReadCloser {
+	reader, writer := io.Pipe()
+	merged := &mergedLogs{reader: reader}
+
+	names := make([]string, 0, len(streams))
+	for name := range streams {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, name := range names {
+		stream := streams[name]
+		merged.streams = append(merged.streams, stream)
+		prefix := "[" + name + "] "
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			lines := bufio.NewReader(stream)
+			for {
+				line, err := lines.ReadString('\n')
+				if line != "" {
+					if line[len(line)-1] != '\n' {
+						line += "\n"
+					}
+					mu.Lock()
+					_, werr := io.WriteString(writer, prefix+line)
+					mu.Unlock()
+					if werr != nil {
+						return
+					}
+				}
+				if err != nil {
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		writer.Close()
+	}()
+
+	return merged
+}
+
+func (m *mergedLogs) Read(p []byte) (int, error) {
+	return m.reader.Read(p)
+}
+
+// Close stops reading from every stream and closes them
+func (m *mergedLogs) Close() error {
+	m.once.Do(func() {
+		m.reader.Close()
+		for _, stream := range m.streams {
+			stream.Close()
+		}
+	})
+	return nil
+}
+
+// OpenMergedLogs follows the logs of several pods as one stream, as
+// MergeLogStreams merges them. Pods whose logs can't be opened yet, such as
+// pods still starting, are left out; it fails only when none can be opened.
+func OpenMergedLogs(ctx context.Context, svc PodService, pods []Pod, options LogOptions) (io.ReadCloser, error) {
+	streams := make(map[string]io.ReadCloser)
+	var firstErr error
+	for _, pod := range pods {
+		stream, err := OpenPodLogs(ctx, svc, pod, options)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		streams[pod.Name] = stream
+	}
+	if len(streams) == 0 {
+		if firstErr == nil {
+			firstErr = errors.New("no pods to follow")
+		}
+		return nil, firstErr
+	}
+	return MergeLogStreams(streams), nil
+}
+
+// OpenPodLogs follows the logs of a pod, with options when any are set
+func OpenPodLogs(ctx context.Context, svc PodService, pod Pod, options LogOptions) (io.ReadCloser, error) {
+	if options == (LogOptions{}) {
+		return svc.GetPodLogs(ctx, pod.Name, pod.Namespace)
+	}
+	return svc.StreamPodLogs(ctx, pod.Name, pod.Namespace, options)
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+)
+
+type ManifestServiceImpl struct {
+	client kubernetes.Interface
+}
+
+func NewManifestService(client kubernetes.Interface) ManifestService {
+	return &ManifestServiceImpl{
+		client: client,
+	}
+}
+
+// GetManifest fetches a pod or workload and renders it as YAML, the way
+// kubectl get -o yaml does
+func (m *ManifestServiceImpl) GetManifest(ctx context.Context, kind, namespace, name string) (*Manifest, error) {
+	opts := metav1.GetOptions{}
+	var object runtime.Object
+	var groupVersion schema.GroupVersion
+	var err error
+
+	switch kind {
+	case KindPod:
+		object, err = m.client.CoreV1().Pods(namespace).Get(ctx, name, opts)
+		groupVersion = corev1.SchemeGroupVersion
+	case KindDeployment:
+		object, err = m.client.AppsV1().Deployments(namespace).Get(ctx, name, opts)
+		groupVersion = appsv1.SchemeGroupVersion
+	case KindStatefulSet:
+		object, err = m.client.AppsV1().StatefulSets(namespace).Get(ctx, name, opts)
+		groupVersion = appsv1.SchemeGroupVersion
+	case KindDaemonSet:
+		object, err = m.client.AppsV1().DaemonSets(namespace).Get(ctx, name, opts)
+		groupVersion = appsv1.SchemeGroupVersion
+	case KindReplicaSet:
+		object, err = m.client.AppsV1().ReplicaSets(namespace).Get(ctx, name, opts)
+		groupVersion = appsv1.SchemeGroupVersion
+	case KindJob:
+		object, err = m.client.BatchV1().Jobs(namespace).Get(ctx, name, opts)
+		groupVersion = batchv1.SchemeGroupVersion
+	case KindCronJob:
+		object, err = m.client.BatchV1().CronJobs(namespace).Get(ctx, name, opts)
+		groupVersion = batchv1.SchemeGroupVersion
+	default:
+		return nil, fmt.Errorf("showing the manifest of a %s is not supported", kind)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s %s/%s: %w", strings.ToLower(kind), namespace, name, err)
+	}
+
+	// Typed clients leave out the kind and apiVersion the manifest starts with
+	object.GetObjectKind().SetGroupVersionKind(groupVersion.WithKind(kind))
+	return newManifest(object, kind, namespace, name)
+}
+
+// newManifest renders a resource as YAML, with and without its managed
+// fields
+func newManifest(object runtime.Object, kind, namespace, name string) (*Manifest, error) {
+	var err error
+	manifest := &Manifest{Kind: kind, Namespace: namespace, Name: name}
+	if manifest.FullYAML, err = marshalManifest(object); err != nil {
+		return nil, err
+	}
+
+	accessor, err := meta.Accessor(object)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read metadata of %s %s/%s: %w", strings.ToLower(kind), namespace, name, err)
+	}
+	accessor.SetManagedFields(nil)
+	if manifest.YAML, err = marshalManifest(object); err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+func marshalManifest(object runtime.Object) (string, error) {
+	data, err := yaml.Marshal(object)
+	if err != nil {
+		return "", fmt.Errorf("failed to render manifest: %w", err)
+	}
+	return string(data), nil
+}
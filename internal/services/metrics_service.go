@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// metricsAPIPath is where metrics-server serves the metrics.k8s.io API
+const metricsAPIPath = "/apis/metrics.k8s.io/v1beta1"
+
+type MetricsServiceImpl struct {
+	client kubernetes.Interface
+}
+
+func NewMetricsService(client kubernetes.Interface) MetricsService {
+	return &MetricsServiceImpl{
+		client: client,
+	}
+}
+
+// podMetricsList is the part of a metrics.k8s.io PodMetricsList that is
+// shown. The API is read as plain JSON, so no metrics client is needed.
+type podMetricsList struct {
+	Items []struct {
+		Metadata struct {
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+		} `json:"metadata"`
+		Containers []struct {
+			Usage corev1.ResourceList `json:"usage"`
+		} `json:"containers"`
+	} `json:"items"`
+}
+
+// ListPodMetrics returns what the pods of a namespace, or of every namespace
+// when it is empty, currently use. It returns ErrMetricsUnavailable when the
+// cluster doesn't serve metrics.
+func (m *MetricsServiceImpl) ListPodMetrics(ctx context.Context, namespace string) ([]PodMetrics, error) {
+	restClient := m.client.Discovery().RESTClient()
+	if restClient == nil {
+		return nil, ErrMetricsUnavailable
+	}
+
+	path := metricsAPIPath + "/pods"
+	if namespace != metav1.NamespaceAll {
+		path = metricsAPIPath + "/namespaces/" + namespace + "/pods"
+	}
+	data, err := restClient.Get().AbsPath(path).DoRaw(ctx)
+	if err != nil {
+		if apierrors.IsNotFound(err) || apierrors.IsServiceUnavailable(err) {
+			return nil, ErrMetricsUnavailable
+		}
+		return nil, fmt.Errorf("failed to get pod metrics: %w", err)
+	}
+	return parsePodMetrics(data)
+}
+
+// parsePodMetrics totals the usage of each pod's containers
+func parsePodMetrics(data []byte) ([]PodMetrics, error) {
+	var list podMetricsList
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("failed to read pod metrics: %w", err)
+	}
+
+	metrics := make([]PodMetrics, 0, len(list.Items))
+	for _, item := range list.Items {
+		pod := PodMetrics{Namespace: item.Metadata.Namespace, Name: item.Metadata.Name}
+		for _, c := range item.Containers {
+			pod.Usage = pod.Usage.Add(resourceAmounts(c.Usage))
+		}
+		metrics = append(metrics, pod)
+	}
+	return metrics, nil
+}
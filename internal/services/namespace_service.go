@@ -40,18 +40,22 @@ func (n *NamespaceServiceImpl) ListNamespacesDetailed(ctx context.Context) ([]Na
 	}
 
 	namespaces := make([]Namespace, 0, len(namespaceList.Items))
-	for _, ns := range namespaceList.Items {
-		status := convertNamespaceStatus(ns.Status.Phase)
-		namespaces = append(namespaces, Namespace{
-			Name:   ns.Name,
-			Status: status,
-			Labels: ns.Labels,
-		})
+	for i := range namespaceList.Items {
+		namespaces = append(namespaces, newNamespace(&namespaceList.Items[i]))
 	}
 
 	return namespaces, nil
 }
 
+// newNamespace converts a Kubernetes namespace to the service representation
+func newNamespace(ns *corev1.Namespace) Namespace {
+	return Namespace{
+		Name:   ns.Name,
+		Status: convertNamespaceStatus(ns.Status.Phase),
+		Labels: ns.Labels,
+	}
+}
+
 func convertNamespaceStatus(phase corev1.NamespacePhase) NamespaceStatus {
 	switch phase {
 	case corev1.NamespaceActive:
@@ -0,0 +1,324 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+)
+
+type DescribeServiceImpl struct {
+	client kubernetes.Interface
+}
+
+func NewDescribeService(client kubernetes.Interface) DescribeService {
+	return &DescribeServiceImpl{
+		client: client,
+	}
+}
+
+// PodDescription is what kubectl describe pod shows about a pod
+type PodDescription struct {
+	Pod            Pod
+	Annotations    map[string]string
+	ServiceAccount string
+	HostIP         string
+	StartTime      time.Time
+	PriorityClass  string
+	NodeSelector   map[string]string
+
+	InitContainers []ContainerDescription
+	Containers     []ContainerDescription
+	Conditions     []PodCondition
+
+	// EphemeralContainers are the containers added to debug the pod
+	EphemeralContainers []ContainerDescription
+
+	// Tolerations are formatted like kubectl, e.g.
+	// node.kubernetes.io/not-ready:NoExecute op=Exists for 300s
+	Tolerations []string
+
+	// Events are the pod's events, oldest first
+	Events []Event
+}
+
+// ContainerDescription describes one container of a pod
+type ContainerDescription struct {
+	Name         string
+	Image        string
+	Ready        bool
+	RestartCount int32
+	State        ContainerState
+	LastState    ContainerState
+
+	// Ports are formatted as port/protocol with the port name, if any
+	Ports []string
+
+	// Requests and Limits map resource names to quantities
+	Requests map[string]string
+	Limits   map[string]string
+
+	// Env lists the container's environment variables with their values or,
+	// for values taken from elsewhere, what they refer to
+	Env []EnvVar
+
+	// EnvFrom lists the ConfigMaps and Secrets all of whose keys are imported
+	EnvFrom []string
+
+	// Mounts are formatted as path from volume (ro|rw)
+	Mounts []string
+
+	// Target is the container whose processes an ephemeral container sees
+	Target string
+}
+
+// EnvVar is an environment variable with either a value or a reference
+type EnvVar struct {
+	Name  string
+	Value string
+	Ref   string
+}
+
+// ContainerState is the state of a container: Running, Waiting or
+// Terminated, or empty when the container has no such state
+type ContainerState struct {
+	State      string
+	Reason     string
+	Message    string
+	ExitCode   int32
+	StartedAt  time.Time
+	FinishedAt time.Time
+}
+
+// PodCondition is one of a pod's conditions, such as Ready
+type PodCondition struct {
+	Type    string
+	Status  string
+	Reason  string
+	Message string
+
+	// LastTransition is when the condition last changed status
+	LastTransition time.Time
+}
+
+// DescribePod gathers the details of a pod, with its events. Events the user
+// may not list are left out rather than failing the description.
+func (d *DescribeServiceImpl) DescribePod(ctx context.Context, namespace, name string) (*PodDescription, error) {
+	pod, err := d.client.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pod %s/%s: %w", namespace, name, err)
+	}
+
+	description := newPodDescription(pod)
+
+	selector := fields.Set{
+		"involvedObject.kind": "Pod",
+		"involvedObject.name": name,
+	}.AsSelector().String()
+	eventList, err := d.client.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{FieldSelector: selector})
+	if err != nil && !apierrors.IsForbidden(err) {
+		return nil, fmt.Errorf("failed to list events of pod %s/%s: %w", namespace, name, err)
+	}
+	if eventList != nil {
+		for i := range eventList.Items {
+			event := newEvent(&eventList.Items[i])
+			if event.IsAbout("Pod", name) {
+				description.Events = append(description.Events, event)
+			}
+		}
+		sortEvents(description.Events)
+	}
+
+	return description, nil
+}
+
+func newPodDescription(pod *corev1.Pod) *PodDescription {
+	description := &PodDescription{
+		Pod:            newPod(pod),
+		Annotations:    pod.Annotations,
+		ServiceAccount: pod.Spec.ServiceAccountName,
+		HostIP:         pod.Status.HostIP,
+		PriorityClass:  pod.Spec.PriorityClassName,
+		NodeSelector:   pod.Spec.NodeSelector,
+	}
+	if pod.Status.StartTime != nil {
+		description.StartTime = pod.Status.StartTime.Time
+	}
+
+	description.InitContainers = describeContainers(pod.Spec.InitContainers, pod.Status.InitContainerStatuses)
+	description.Containers = describeContainers(pod.Spec.Containers, pod.Status.ContainerStatuses)
+	description.EphemeralContainers = describeEphemeralContainers(pod.Spec.EphemeralContainers, pod.Status.EphemeralContainerStatuses)
+
+	for _, condition := range pod.Status.Conditions {
+		description.Conditions = append(description.Conditions, PodCondition{
+			Type:           string(condition.Type),
+			Status:         string(condition.Status),
+			Reason:         condition.Reason,
+			Message:        condition.Message,
+			LastTransition: condition.LastTransitionTime.Time,
+		})
+	}
+	for _, toleration := range pod.Spec.Tolerations {
+		description.Tolerations = append(description.Tolerations, formatToleration(toleration))
+	}
+
+	return description
+}
+
+func describeContainers(containers []corev1.Container, statuses []corev1.ContainerStatus) []ContainerDescription {
+	byName := make(map[string]corev1.ContainerStatus, len(statuses))
+	for _, status := range statuses {
+		byName[status.Name] = status
+	}
+
+	descriptions := make([]ContainerDescription, 0, len(containers))
+	for _, container := range containers {
+		description := ContainerDescription{
+			Name:     container.Name,
+			Image:    container.Image,
+			Requests: formatResources(container.Resources.Requests),
+			Limits:   formatResources(container.Resources.Limits),
+		}
+		if status, ok := byName[container.Name]; ok {
+			description.Ready = status.Ready
+			description.RestartCount = status.RestartCount
+			description.State = newContainerState(status.State)
+			description.LastState = newContainerState(status.LastTerminationState)
+		}
+
+		for _, port := range container.Ports {
+			formatted := fmt.Sprintf("%d/%s", port.ContainerPort, port.Protocol)
+			if port.Name != "" {
+				formatted += " (" + port.Name + ")"
+			}
+			description.Ports = append(description.Ports, formatted)
+		}
+		for _, env := range container.Env {
+			description.Env = append(description.Env, EnvVar{Name: env.Name, Value: env.Value, Ref: envRef(env.ValueFrom)})
+		}
+		for _, source := range container.EnvFrom {
+			description.EnvFrom = append(description.EnvFrom, envFromRef(source))
+		}
+		for _, mount := range container.VolumeMounts {
+			mode := "rw"
+			if mount.ReadOnly {
+				mode = "ro"
+			}
+			path := mount.MountPath
+			if mount.SubPath != "" {
+				path += " (path " + mount.SubPath + ")"
+			}
+			description.Mounts = append(description.Mounts, fmt.Sprintf("%s from %s (%s)", path, mount.Name, mode))
+		}
+
+		descriptions = append(descriptions, description)
+	}
+	return descriptions
+}
+
+// describeEphemeralContainers describes debug containers like the others,
+// with the container each one targets
+func describeEphemeralContainers(containers []corev1.EphemeralContainer, statuses []corev1.ContainerStatus) []ContainerDescription {
+	common := make([]corev1.Container, len(containers))
+	for i, container := range containers {
+		common[i] = corev1.Container(container.EphemeralContainerCommon)
+	}
+	descriptions := describeContainers(common, statuses)
+	for i := range descriptions {
+		descriptions[i].Target = containers[i].TargetContainerName
+	}
+	return descriptions
+}
+
+func newContainerState(state corev1.ContainerState) ContainerState {
+	switch {
+	case state.Running != nil:
+		return ContainerState{State: "Running", StartedAt: state.Running.StartedAt.Time}
+	case state.Waiting != nil:
+		return ContainerState{State: "Waiting", Reason: state.Waiting.Reason, Message: state.Waiting.Message}
+	case state.Terminated != nil:
+		return ContainerState{
+			State:      "Terminated",
+			Reason:     state.Terminated.Reason,
+			Message:    state.Terminated.Message,
+			ExitCode:   state.Terminated.ExitCode,
+			StartedAt:  state.Terminated.StartedAt.Time,
+			FinishedAt: state.Terminated.FinishedAt.Time,
+		}
+	}
+	return ContainerState{}
+}
+
+// envRef describes where an environment variable's value comes from, the
+// way kubectl describe does
+func envRef(source *corev1.EnvVarSource) string {
+	switch {
+	case source == nil:
+		return ""
+	case source.SecretKeyRef != nil:
+		return fmt.Sprintf("key '%s' in secret '%s'", source.SecretKeyRef.Key, source.SecretKeyRef.Name)
+	case source.ConfigMapKeyRef != nil:
+		return fmt.Sprintf("key '%s' of config map '%s'", source.ConfigMapKeyRef.Key, source.ConfigMapKeyRef.Name)
+	case source.FieldRef != nil:
+		return fmt.Sprintf("field %s", source.FieldRef.FieldPath)
+	case source.ResourceFieldRef != nil:
+		ref := "resource " + source.ResourceFieldRef.Resource
+		if source.ResourceFieldRef.ContainerName != "" {
+			ref += " of container " + source.ResourceFieldRef.ContainerName
+		}
+		return ref
+	}
+	return "unknown source"
+}
+
+func envFromRef(source corev1.EnvFromSource) string {
+	var ref string
+	switch {
+	case source.ConfigMapRef != nil:
+		ref = source.ConfigMapRef.Name + " ConfigMap"
+	case source.SecretRef != nil:
+		ref = source.SecretRef.Name + " Secret"
+	}
+	if source.Prefix != "" {
+		ref += " with prefix '" + source.Prefix + "'"
+	}
+	return ref
+}
+
+func formatResources(resources corev1.ResourceList) map[string]string {
+	if len(resources) == 0 {
+		return nil
+	}
+	formatted := make(map[string]string, len(resources))
+	for name, quantity := range resources {
+		formatted[string(name)] = quantity.String()
+	}
+	return formatted
+}
+
+func formatToleration(toleration corev1.Toleration) string {
+	var b strings.Builder
+	b.WriteString(toleration.Key)
+	if toleration.Value != "" {
+		b.WriteString("=" + toleration.Value)
+	}
+	if toleration.Effect != "" {
+		b.WriteString(":" + string(toleration.Effect))
+	}
+	if toleration.Operator == corev1.TolerationOpExists && toleration.Value == "" {
+		if toleration.Key != "" || toleration.Effect != "" {
+			b.WriteString(" ")
+		}
+		b.WriteString("op=Exists")
+	}
+	if toleration.TolerationSeconds != nil {
+		fmt.Fprintf(&b, " for %ds", *toleration.TolerationSeconds)
+	}
+	return b.String()
+}
@@ -0,0 +1,211 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// PodQuery is a parsed pod search. Selector terms are sent to the API server;
+// free text is matched against pod names afterwards.
+type PodQuery struct {
+	LabelSelector string
+	FieldSelector string
+	Text          string
+
+	labels labels.Selector
+	fields fields.Selector
+}
+
+// ParsePodQuery parses a pod search such as
+//
+//	app=api,tier!=cache status.phase=Failed web
+//
+// Terms with an operator are selectors: those on a pod field the API server
+// can select on (status.phase, spec.nodeName, ...) are field selectors and
+// the rest label selectors, including set-based ones like `env in (a,b)` and
+// `!canary`. Remaining words are free text matched against pod names.
+// Quotes group words as a shell would, so `'env in (a, b)'` and `app="api"`
+// work as typed for kubectl.
+func ParsePodQuery(query string) (PodQuery, error) {
+	var labelTerms, fieldTerms, text []string
+
+	for _, token := range tokenizeQuery(query) {
+		if !isSelectorTerm(token) {
+			text = append(text, token)
+			continue
+		}
+		for _, term := range splitTopLevel(token, ',') {
+			if term == "" {
+				continue
+			}
+			if isFieldTerm(term) {
+				fieldTerms = append(fieldTerms, term)
+			} else {
+				labelTerms = append(labelTerms, term)
+			}
+		}
+	}
+
+	q := PodQuery{
+		LabelSelector: strings.Join(labelTerms, ","),
+		FieldSelector: strings.Join(fieldTerms, ","),
+		Text:          strings.Join(text, " "),
+	}
+
+	var err error
+	if q.labels, err = labels.Parse(q.LabelSelector); err != nil {
+		return PodQuery{}, fmt.Errorf("invalid label selector: %w", err)
+	}
+	if q.fields, err = fields.ParseSelector(q.FieldSelector); err != nil {
+		return PodQuery{}, fmt.Errorf("invalid field selector: %w", err)
+	}
+	return q, nil
+}
+
+// IsEmpty reports whether the query matches every pod
+func (q PodQuery) IsEmpty() bool {
+	return q.LabelSelector == "" && q.FieldSelector == "" && q.Text == ""
+}
+
+// ListOptions returns the list options that push the selectors to the API server
+func (q PodQuery) ListOptions() metav1.ListOptions {
+	return metav1.ListOptions{
+		LabelSelector: q.LabelSelector,
+		FieldSelector: q.FieldSelector,
+	}
+}
+
+// Matches evaluates the whole query against a pod, for pods already cached
+func (q PodQuery) Matches(pod *corev1.Pod) bool {
+	if q.labels != nil && !q.labels.Matches(labels.Set(pod.Labels)) {
+		return false
+	}
+	if q.fields != nil && !q.fields.Matches(podFields(pod)) {
+		return false
+	}
+	return q.MatchesText(pod.Name)
+}
+
+// MatchesText reports whether a pod name contains every free text word
+func (q PodQuery) MatchesText(name string) bool {
+	name = strings.ToLower(name)
+	for _, word := range strings.Fields(strings.ToLower(q.Text)) {
+		if !strings.Contains(name, word) {
+			return false
+		}
+	}
+	return true
+}
+
+// podFields returns the fields the API server lets pods be selected on
+func podFields(pod *corev1.Pod) fields.Set {
+	return fields.Set{
+		"metadata.name":            pod.Name,
+		"metadata.namespace":       pod.Namespace,
+		"spec.nodeName":            pod.Spec.NodeName,
+		"spec.restartPolicy":       string(pod.Spec.RestartPolicy),
+		"spec.schedulerName":       pod.Spec.SchedulerName,
+		"spec.serviceAccountName":  pod.Spec.ServiceAccountName,
+		"spec.hostNetwork":         strconv.FormatBool(pod.Spec.HostNetwork),
+		"status.phase":             string(pod.Status.Phase),
+		"status.podIP":             pod.Status.PodIP,
+		"status.nominatedNodeName": pod.Status.NominatedNodeName,
+	}
+}
+
+// isFieldTerm reports whether a selector term is on a selectable pod field
+func isFieldTerm(term string) bool {
+	key := strings.TrimLeft(term, "!")
+	if i := strings.IndexAny(key, "!="); i >= 0 {
+		key = key[:i]
+	}
+	_, ok := podFields(&corev1.Pod{})[strings.TrimSpace(key)]
+	return ok
+}
+
+// isSelectorTerm reports whether a token is a selector rather than free text
+func isSelectorTerm(token string) bool {
+	return strings.ContainsAny(token, "=!") ||
+		strings.Contains(token, " in (") ||
+		strings.Contains(token, " notin (")
+}
+
+// tokenizeQuery splits a query on whitespace, keeping set-based terms such
+// as `env in (a, b)` and quoted text together as a single token. Quotes are
+// dropped.
+func tokenizeQuery(query string) []string {
+	var words []string
+	var current strings.Builder
+	depth := 0
+	var quote rune
+	for _, r := range query {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+				continue
+			}
+		case r == '"' || r == '\'':
+			quote = r
+			continue
+		case r == '(':
+			depth++
+		case r == ')' && depth > 0:
+			depth--
+		case unicode.IsSpace(r) && depth == 0:
+			if current.Len() > 0 {
+				words = append(words, current.String())
+				current.Reset()
+			}
+			continue
+		}
+		current.WriteRune(r)
+	}
+	if current.Len() > 0 {
+		words = append(words, current.String())
+	}
+
+	// Join "key in (values)" back together, along with any terms attached to it
+	var tokens []string
+	for i := 0; i < len(words); i++ {
+		word := words[i]
+		if i+2 < len(words) && (words[i+1] == "in" || words[i+1] == "notin") && strings.HasPrefix(words[i+2], "(") {
+			word = words[i] + " " + words[i+1] + " " + words[i+2]
+			i += 2
+		}
+		if len(tokens) > 0 && (strings.HasSuffix(tokens[len(tokens)-1], ",") || strings.HasPrefix(word, ",")) {
+			tokens[len(tokens)-1] += word
+			continue
+		}
+		tokens = append(tokens, word)
+	}
+	return tokens
+}
+
+// splitTopLevel splits s on sep outside of parentheses
+func splitTopLevel(s string, sep rune) []string {
+	var parts []string
+	var current strings.Builder
+	depth := 0
+	for _, r := range s {
+		switch {
+		case r == '(':
+			depth++
+		case r == ')' && depth > 0:
+			depth--
+		case r == sep && depth == 0:
+			parts = append(parts, strings.TrimSpace(current.String()))
+			current.Reset()
+			continue
+		}
+		current.WriteRune(r)
+	}
+	return append(parts, strings.TrimSpace(current.String()))
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParsePodQuery(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		wantLabel string
+		wantField string
+		wantText  string
+		wantErr   bool
+	}{
+		{name: "empty", query: ""},
+		{name: "equality", query: "app=api", wantLabel: "app=api"},
+		{name: "double equals", query: "app==api", wantLabel: "app==api"},
+		{name: "inequality", query: "app!=api", wantLabel: "app!=api"},
+		{name: "several terms", query: "app=api,tier!=cache", wantLabel: "app=api,tier!=cache"},
+		{name: "terms apart", query: "app=api tier!=cache", wantLabel: "app=api,tier!=cache"},
+		{name: "empty term", query: "app=api,,tier=web", wantLabel: "app=api,tier=web"},
+		{name: "in", query: "env in (dev, staging)", wantLabel: "env in (dev, staging)"},
+		{name: "notin", query: "env notin (prod)", wantLabel: "env notin (prod)"},
+		{name: "set-based with more terms", query: "env in (dev,staging),app=api", wantLabel: "env in (dev,staging),app=api"},
+		{name: "bare key is free text", query: "canary", wantText: "canary"},
+		{name: "does not exist", query: "!canary", wantLabel: "!canary"},
+		{name: "field", query: "status.phase=Running", wantField: "status.phase=Running"},
+		{name: "field inequality", query: "spec.nodeName!=node-1", wantField: "spec.nodeName!=node-1"},
+		{name: "label and field in one term", query: "app=api,status.phase=Failed", wantLabel: "app=api", wantField: "status.phase=Failed"},
+		{name: "unknown field is a label", query: "metadata.uid=abc", wantLabel: "metadata.uid=abc"},
+		{name: "free text", query: "web worker", wantText: "web worker"},
+		{name: "free text around selectors", query: "web app=api worker status.phase=Running", wantLabel: "app=api", wantField: "status.phase=Running", wantText: "web worker"},
+		{name: "quoted value", query: `app="api"`, wantLabel: "app=api"},
+		{name: "quoted set-based term", query: "'env in (dev, staging)' web", wantLabel: "env in (dev, staging)", wantText: "web"},
+		{name: "quoted free text", query: `"web" 'worker'`, wantText: "web worker"},
+		{name: "unclosed set", query: "env in (dev", wantErr: true},
+		{name: "invalid label value", query: "app=a b=(c", wantErr: true},
+		{name: "invalid label key", query: "-app=api", wantErr: true},
+		{name: "invalid field", query: "status.phase!", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := ParsePodQuery(tt.query)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParsePodQuery(%q) = %+v, want an error", tt.query, q)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParsePodQuery(%q) error = %v", tt.query, err)
+			}
+			if q.LabelSelector != tt.wantLabel {
+				t.Errorf("LabelSelector = %q, want %q", q.LabelSelector, tt.wantLabel)
+			}
+			if q.FieldSelector != tt.wantField {
+				t.Errorf("FieldSelector = %q, want %q", q.FieldSelector, tt.wantField)
+			}
+			if q.Text != tt.wantText {
+				t.Errorf("Text = %q, want %q", q.Text, tt.wantText)
+			}
+		})
+	}
+}
+
+func TestTokenizeQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"", nil},
+		{"  web   worker ", []string{"web", "worker"}},
+		{"app=api tier=web", []string{"app=api", "tier=web"}},
+		{"env in (a, b) web", []string{"env in (a, b)", "web"}},
+		{"env notin (a) web", []string{"env notin (a)", "web"}},
+		{"app=api, tier=web", []string{"app=api,tier=web"}},
+		{"app=api ,tier=web", []string{"app=api,tier=web"}},
+		{"env in (a),app=api web", []string{"env in (a),app=api", "web"}},
+		{"in (a)", []string{"in", "(a)"}},
+		{`app="api" web`, []string{"app=api", "web"}},
+		{`"env in (a, b)"`, []string{"env in (a, b)"}},
+		{`'a "b" c'`, []string{`a "b" c`}},
+		{`"unclosed quote`, []string{"unclosed quote"}},
+		{`""`, nil},
+	}
+
+	for _, tt := range tests {
+		if got := tokenizeQuery(tt.query); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("tokenizeQuery(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
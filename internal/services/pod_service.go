@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -22,58 +21,127 @@ func NewPodService(client kubernetes.Interface) PodService {
 }
 
 func (p *PodServiceImpl) ListPods(ctx context.Context, namespace string) ([]Pod, error) {
-	podList, err := p.client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	return p.listPods(ctx, namespace, PodQuery{})
+}
+
+// SearchPods lists the pods matching a query in the syntax of ParsePodQuery.
+// Label and field selectors are evaluated by the API server.
+func (p *PodServiceImpl) SearchPods(ctx context.Context, namespace, query string) ([]Pod, error) {
+	podQuery, err := ParsePodQuery(query)
 	if err != nil {
+		return nil, err
+	}
+	return p.listPods(ctx, namespace, podQuery)
+}
+
+func (p *PodServiceImpl) listPods(ctx context.Context, namespace string, query PodQuery) ([]Pod, error) {
+	podList, err := p.client.CoreV1().Pods(namespace).List(ctx, query.ListOptions())
+	if err != nil {
+		if namespace == metav1.NamespaceAll {
+			return nil, fmt.Errorf("failed to list pods in all namespaces: %w", err)
+		}
 		return nil, fmt.Errorf("failed to list pods in namespace %s: %w", namespace, err)
 	}
 
 	pods := make([]Pod, 0, len(podList.Items))
-	for _, k8sPod := range podList.Items {
-		pod := Pod{
-			Name:      k8sPod.Name,
-			Namespace: k8sPod.Namespace,
-			Status:    convertPodStatus(k8sPod.Status.Phase),
-			Labels:    k8sPod.Labels,
+	for i := range podList.Items {
+		if query.MatchesText(podList.Items[i].Name) {
+			pods = append(pods, newPod(&podList.Items[i]))
 		}
-		pods = append(pods, pod)
 	}
 
 	return pods, nil
 }
 
-func (p *PodServiceImpl) SearchPods(ctx context.Context, namespace, query string) ([]Pod, error) {
-	allPods, err := p.ListPods(ctx, namespace)
-	if err != nil {
-		return nil, err
+func (p *PodServiceImpl) GetPodLogs(ctx context.Context, podName, namespace string) (io.ReadCloser, error) {
+	return p.StreamPodLogs(ctx, podName, namespace, LogOptions{})
+}
+
+// StreamPodLogs follows the logs of a pod with options
+func (p *PodServiceImpl) StreamPodLogs(ctx context.Context, podName, namespace string, options LogOptions) (io.ReadCloser, error) {
+	logOptions := &corev1.PodLogOptions{
+		Container: options.Container,
+		Follow:    true,
 	}
+	if !options.SinceTime.IsZero() {
+		logOptions.SinceTime = &metav1.Time{Time: options.SinceTime}
+	}
+	req := p.client.CoreV1().Pods(namespace).GetLogs(podName, logOptions)
 
-	if query == "" {
-		return allPods, nil
+	stream, err := req.Stream(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stream logs for pod %s/%s: %w", namespace, podName, err)
 	}
 
-	var filteredPods []Pod
-	query = strings.ToLower(query)
+	return stream, nil
+}
 
-	for _, pod := range allPods {
-		if strings.Contains(strings.ToLower(pod.Name), query) {
-			filteredPods = append(filteredPods, pod)
+// newPod converts a Kubernetes pod to the service representation
+func newPod(k8sPod *corev1.Pod) Pod {
+	summary := summarizePod(k8sPod)
+	requests, limits := podResources(&k8sPod.Spec)
+	containers := make([]string, len(k8sPod.Spec.Containers))
+	var ports []ContainerPort
+	for i, c := range k8sPod.Spec.Containers {
+		containers[i] = c.Name
+		for _, port := range c.Ports {
+			ports = append(ports, ContainerPort{Container: c.Name, Name: port.Name, Port: port.ContainerPort, Protocol: string(port.Protocol)})
 		}
 	}
-
-	return filteredPods, nil
+	return Pod{
+		Name:            k8sPod.Name,
+		Namespace:       k8sPod.Namespace,
+		Status:          convertPodStatus(k8sPod.Status.Phase),
+		Labels:          k8sPod.Labels,
+		DisplayStatus:   summary.status,
+		ReadyContainers: summary.ready,
+		TotalContainers: summary.total,
+		Restarts:        summary.restarts,
+		CreatedAt:       k8sPod.CreationTimestamp.Time,
+		NodeName:        k8sPod.Spec.NodeName,
+		PodIP:           k8sPod.Status.PodIP,
+		QOSClass:        string(k8sPod.Status.QOSClass),
+		Containers:      containers,
+		Ports:           ports,
+		Requests:        requests,
+		Limits:          limits,
+		Owner:           controllerOf(&k8sPod.ObjectMeta),
+	}
 }
 
-func (p *PodServiceImpl) GetPodLogs(ctx context.Context, podName, namespace string) (io.ReadCloser, error) {
-	req := p.client.CoreV1().Pods(namespace).GetLogs(podName, &corev1.PodLogOptions{
-		Follow: true,
-	})
+// podResources totals the requests and limits of a pod's containers the way
+// the scheduler does: init containers run one at a time before the others,
+// so the largest of them counts when it needs more than the rest together
+func podResources(spec *corev1.PodSpec) (requests, limits ResourceAmounts) {
+	unlimitedCPU, unlimitedMemory := false, false
+	for _, c := range spec.Containers {
+		requests = requests.Add(resourceAmounts(c.Resources.Requests))
+		limit := resourceAmounts(c.Resources.Limits)
+		unlimitedCPU = unlimitedCPU || limit.CPU == 0
+		unlimitedMemory = unlimitedMemory || limit.Memory == 0
+		limits = limits.Add(limit)
+	}
+	for _, c := range spec.InitContainers {
+		request, limit := resourceAmounts(c.Resources.Requests), resourceAmounts(c.Resources.Limits)
+		requests = ResourceAmounts{CPU: max(requests.CPU, request.CPU), Memory: max(requests.Memory, request.Memory)}
+		limits = ResourceAmounts{CPU: max(limits.CPU, limit.CPU), Memory: max(limits.Memory, limit.Memory)}
+	}
 
-	stream, err := req.Stream(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to stream logs for pod %s/%s: %w", namespace, podName, err)
+	if unlimitedCPU {
+		limits.CPU = 0
+	}
+	if unlimitedMemory {
+		limits.Memory = 0
 	}
+	return requests, limits
+}
 
-	return stream, nil
+// resourceAmounts reads the CPU and memory of a resource list
+func resourceAmounts(resources corev1.ResourceList) ResourceAmounts {
+	return ResourceAmounts{
+		CPU:    resources.Cpu().MilliValue(),
+		Memory: resources.Memory().Value(),
+	}
 }
 
 func convertPodStatus(phase corev1.PodPhase) PodStatus {
@@ -89,4 +157,4 @@ func convertPodStatus(phase corev1.PodPhase) PodStatus {
 	default:
 		return PodUnknown
 	}
-}
\ No newline at end of file
+}
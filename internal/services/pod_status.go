@@ -0,0 +1,128 @@
+package services
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// nodeUnreachableReason is set by the node controller on pods of unreachable nodes
+const nodeUnreachableReason = "NodeLost"
+
+// podSummary holds the container counts and status kubectl prints for a pod
+type podSummary struct {
+	status   string
+	ready    int
+	total    int
+	restarts int
+}
+
+// summarizePod computes the READY, STATUS and RESTARTS columns the same way
+// `kubectl get pods` does, so the values match what users already know
+func summarizePod(pod *corev1.Pod) podSummary {
+	summary := podSummary{
+		status: string(pod.Status.Phase),
+		total:  len(pod.Spec.Containers),
+	}
+	if pod.Status.Reason != "" {
+		summary.status = pod.Status.Reason
+	}
+
+	// Sidecars are init containers that keep running and count as containers
+	sidecars := make(map[string]bool)
+	for _, container := range pod.Spec.InitContainers {
+		if container.RestartPolicy != nil && *container.RestartPolicy == corev1.ContainerRestartPolicyAlways {
+			sidecars[container.Name] = true
+			summary.total++
+		}
+	}
+
+	initializing := false
+	sidecarRestarts := 0
+	for i, container := range pod.Status.InitContainerStatuses {
+		summary.restarts += int(container.RestartCount)
+		if sidecars[container.Name] {
+			sidecarRestarts += int(container.RestartCount)
+		}
+		if sidecars[container.Name] && container.Started != nil && *container.Started {
+			if container.Ready {
+				summary.ready++
+			}
+			continue
+		}
+
+		switch {
+		case container.State.Terminated != nil && container.State.Terminated.ExitCode == 0:
+			continue
+		case container.State.Terminated != nil:
+			summary.status = "Init:" + terminatedReason(container.State.Terminated)
+		case container.State.Waiting != nil && container.State.Waiting.Reason != "" && container.State.Waiting.Reason != "PodInitializing":
+			summary.status = "Init:" + container.State.Waiting.Reason
+		default:
+			summary.status = fmt.Sprintf("Init:%d/%d", i, len(pod.Spec.InitContainers))
+		}
+		initializing = true
+		break
+	}
+
+	if !initializing || hasCondition(pod, corev1.PodInitialized) {
+		// Once initialized, only restarts of long-running containers count
+		summary.restarts = sidecarRestarts
+		hasRunning := false
+		for i := len(pod.Status.ContainerStatuses) - 1; i >= 0; i-- {
+			container := pod.Status.ContainerStatuses[i]
+			summary.restarts += int(container.RestartCount)
+
+			switch {
+			case container.State.Waiting != nil && container.State.Waiting.Reason != "":
+				summary.status = container.State.Waiting.Reason
+			case container.State.Terminated != nil:
+				summary.status = terminatedReason(container.State.Terminated)
+			case container.Ready && container.State.Running != nil:
+				hasRunning = true
+				summary.ready++
+			}
+		}
+
+		// A completed container next to running ones doesn't complete the pod
+		if summary.status == "Completed" && hasRunning {
+			if hasCondition(pod, corev1.PodReady) {
+				summary.status = "Running"
+			} else {
+				summary.status = "NotReady"
+			}
+		}
+	}
+
+	if pod.DeletionTimestamp != nil {
+		if pod.Status.Reason == nodeUnreachableReason {
+			summary.status = "Unknown"
+		} else {
+			summary.status = "Terminating"
+		}
+	}
+
+	return summary
+}
+
+// terminatedReason describes why a container terminated
+func terminatedReason(state *corev1.ContainerStateTerminated) string {
+	switch {
+	case state.Reason != "":
+		return state.Reason
+	case state.Signal != 0:
+		return fmt.Sprintf("Signal:%d", state.Signal)
+	default:
+		return fmt.Sprintf("ExitCode:%d", state.ExitCode)
+	}
+}
+
+// hasCondition reports whether a pod condition is true
+func hasCondition(pod *corev1.Pod, conditionType corev1.PodConditionType) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == conditionType {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
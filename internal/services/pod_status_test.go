@@ -0,0 +1,263 @@
+package services
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func running(name string, ready bool, restarts int32) corev1.ContainerStatus {
+	return corev1.ContainerStatus{
+		Name:         name,
+		Ready:        ready,
+		RestartCount: restarts,
+		State:        corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+	}
+}
+
+func waiting(name, reason string, restarts int32) corev1.ContainerStatus {
+	return corev1.ContainerStatus{
+		Name:         name,
+		RestartCount: restarts,
+		State:        corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: reason}},
+	}
+}
+
+func terminated(name string, state corev1.ContainerStateTerminated) corev1.ContainerStatus {
+	return corev1.ContainerStatus{
+		Name:  name,
+		State: corev1.ContainerState{Terminated: &state},
+	}
+}
+
+func started(status corev1.ContainerStatus) corev1.ContainerStatus {
+	yes := true
+	status.Started = &yes
+	return status
+}
+
+func containers(names ...string) []corev1.Container {
+	var list []corev1.Container
+	for _, name := range names {
+		list = append(list, corev1.Container{Name: name})
+	}
+	return list
+}
+
+func sidecar(name string) corev1.Container {
+	always := corev1.ContainerRestartPolicyAlways
+	return corev1.Container{Name: name, RestartPolicy: &always}
+}
+
+func conditions(types ...corev1.PodConditionType) []corev1.PodCondition {
+	var list []corev1.PodCondition
+	for _, conditionType := range types {
+		list = append(list, corev1.PodCondition{Type: conditionType, Status: corev1.ConditionTrue})
+	}
+	return list
+}
+
+func TestSummarizePod(t *testing.T) {
+	deleted := metav1.Now()
+
+	tests := []struct {
+		name string
+		pod  corev1.Pod
+		want podSummary
+	}{
+		{
+			name: "running",
+			pod: corev1.Pod{
+				Spec: corev1.PodSpec{Containers: containers("api", "proxy")},
+				Status: corev1.PodStatus{
+					Phase:             corev1.PodRunning,
+					Conditions:        conditions(corev1.PodInitialized, corev1.PodReady),
+					ContainerStatuses: []corev1.ContainerStatus{running("api", true, 2), running("proxy", true, 1)},
+				},
+			},
+			want: podSummary{status: "Running", ready: 2, total: 2, restarts: 3},
+		},
+		{
+			name: "crash looping",
+			pod: corev1.Pod{
+				Spec: corev1.PodSpec{Containers: containers("api")},
+				Status: corev1.PodStatus{
+					Phase:             corev1.PodRunning,
+					ContainerStatuses: []corev1.ContainerStatus{waiting("api", "CrashLoopBackOff", 5)},
+				},
+			},
+			want: podSummary{status: "CrashLoopBackOff", total: 1, restarts: 5},
+		},
+		{
+			name: "pending",
+			pod: corev1.Pod{
+				Spec:   corev1.PodSpec{Containers: containers("api")},
+				Status: corev1.PodStatus{Phase: corev1.PodPending},
+			},
+			want: podSummary{status: "Pending", total: 1},
+		},
+		{
+			name: "evicted",
+			pod: corev1.Pod{
+				Spec:   corev1.PodSpec{Containers: containers("api")},
+				Status: corev1.PodStatus{Phase: corev1.PodFailed, Reason: "Evicted"},
+			},
+			want: podSummary{status: "Evicted", total: 1},
+		},
+		{
+			name: "killed by a signal",
+			pod: corev1.Pod{
+				Spec: corev1.PodSpec{Containers: containers("api")},
+				Status: corev1.PodStatus{
+					Phase:             corev1.PodFailed,
+					ContainerStatuses: []corev1.ContainerStatus{terminated("api", corev1.ContainerStateTerminated{ExitCode: 137, Signal: 9})},
+				},
+			},
+			want: podSummary{status: "Signal:9", total: 1},
+		},
+		{
+			name: "exit code without reason",
+			pod: corev1.Pod{
+				Spec: corev1.PodSpec{Containers: containers("api")},
+				Status: corev1.PodStatus{
+					Phase:             corev1.PodFailed,
+					ContainerStatuses: []corev1.ContainerStatus{terminated("api", corev1.ContainerStateTerminated{ExitCode: 2})},
+				},
+			},
+			want: podSummary{status: "ExitCode:2", total: 1},
+		},
+		{
+			name: "init containers in progress",
+			pod: corev1.Pod{
+				Spec: corev1.PodSpec{InitContainers: containers("fetch", "migrate", "warm"), Containers: containers("api")},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodPending,
+					InitContainerStatuses: []corev1.ContainerStatus{
+						terminated("fetch", corev1.ContainerStateTerminated{Reason: "Completed"}),
+						running("migrate", false, 1),
+						waiting("warm", "PodInitializing", 0),
+					},
+					ContainerStatuses: []corev1.ContainerStatus{waiting("api", "PodInitializing", 0)},
+				},
+			},
+			want: podSummary{status: "Init:1/3", total: 1, restarts: 1},
+		},
+		{
+			name: "init container crash looping",
+			pod: corev1.Pod{
+				Spec: corev1.PodSpec{InitContainers: containers("migrate"), Containers: containers("api")},
+				Status: corev1.PodStatus{
+					Phase:                 corev1.PodPending,
+					InitContainerStatuses: []corev1.ContainerStatus{waiting("migrate", "CrashLoopBackOff", 4)},
+				},
+			},
+			want: podSummary{status: "Init:CrashLoopBackOff", total: 1, restarts: 4},
+		},
+		{
+			name: "init container failed",
+			pod: corev1.Pod{
+				Spec: corev1.PodSpec{InitContainers: containers("migrate"), Containers: containers("api")},
+				Status: corev1.PodStatus{
+					Phase:                 corev1.PodPending,
+					InitContainerStatuses: []corev1.ContainerStatus{terminated("migrate", corev1.ContainerStateTerminated{Reason: "Error", ExitCode: 1})},
+				},
+			},
+			want: podSummary{status: "Init:Error", total: 1},
+		},
+		{
+			name: "sidecar counts as a container",
+			pod: corev1.Pod{
+				Spec: corev1.PodSpec{InitContainers: []corev1.Container{sidecar("mesh")}, Containers: containers("api")},
+				Status: corev1.PodStatus{
+					Phase:                 corev1.PodRunning,
+					Conditions:            conditions(corev1.PodInitialized, corev1.PodReady),
+					InitContainerStatuses: []corev1.ContainerStatus{started(running("mesh", true, 2))},
+					ContainerStatuses:     []corev1.ContainerStatus{running("api", true, 1)},
+				},
+			},
+			want: podSummary{status: "Running", ready: 2, total: 2, restarts: 3},
+		},
+		{
+			name: "sidecar started before a regular init container",
+			pod: corev1.Pod{
+				Spec: corev1.PodSpec{InitContainers: []corev1.Container{sidecar("mesh"), {Name: "migrate"}}, Containers: containers("api")},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodPending,
+					InitContainerStatuses: []corev1.ContainerStatus{
+						started(running("mesh", true, 0)),
+						running("migrate", false, 0),
+					},
+				},
+			},
+			want: podSummary{status: "Init:1/2", ready: 1, total: 2},
+		},
+		{
+			name: "completed container next to a ready one",
+			pod: corev1.Pod{
+				Spec: corev1.PodSpec{Containers: containers("setup", "api")},
+				Status: corev1.PodStatus{
+					Phase:      corev1.PodRunning,
+					Conditions: conditions(corev1.PodInitialized, corev1.PodReady),
+					ContainerStatuses: []corev1.ContainerStatus{
+						terminated("setup", corev1.ContainerStateTerminated{Reason: "Completed"}),
+						running("api", true, 0),
+					},
+				},
+			},
+			want: podSummary{status: "Running", ready: 1, total: 2},
+		},
+		{
+			name: "completed container next to running ones in a pod not ready",
+			pod: corev1.Pod{
+				Spec: corev1.PodSpec{Containers: containers("setup", "api")},
+				Status: corev1.PodStatus{
+					Phase:      corev1.PodRunning,
+					Conditions: conditions(corev1.PodInitialized),
+					ContainerStatuses: []corev1.ContainerStatus{
+						terminated("setup", corev1.ContainerStateTerminated{Reason: "Completed"}),
+						running("api", true, 0),
+					},
+				},
+			},
+			want: podSummary{status: "NotReady", ready: 1, total: 2},
+		},
+		{
+			name: "terminating",
+			pod: corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{DeletionTimestamp: &deleted},
+				Spec:       corev1.PodSpec{Containers: containers("api")},
+				Status: corev1.PodStatus{
+					Phase:             corev1.PodRunning,
+					ContainerStatuses: []corev1.ContainerStatus{running("api", true, 0)},
+				},
+			},
+			want: podSummary{status: "Terminating", ready: 1, total: 1},
+		},
+		{
+			name: "node lost",
+			pod: corev1.Pod{
+				Spec:   corev1.PodSpec{Containers: containers("api")},
+				Status: corev1.PodStatus{Phase: corev1.PodRunning, Reason: nodeUnreachableReason},
+			},
+			want: podSummary{status: "NodeLost", total: 1},
+		},
+		{
+			name: "deleted on a lost node",
+			pod: corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{DeletionTimestamp: &deleted},
+				Spec:       corev1.PodSpec{Containers: containers("api")},
+				Status:     corev1.PodStatus{Phase: corev1.PodRunning, Reason: nodeUnreachableReason},
+			},
+			want: podSummary{status: "Unknown", total: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := summarizePod(&tt.pod); got != tt.want {
+				t.Errorf("summarizePod() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
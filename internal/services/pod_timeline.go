@@ -0,0 +1,127 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TimelineEntry is one thing that happened to a pod: a condition changing,
+// a container starting, stopping or waiting, or an event
+type TimelineEntry struct {
+	Time time.Time
+
+	// Subject is what changed: "pod", "condition", "event" or a container
+	// such as "container api"
+	Subject string
+
+	// What says what happened, e.g. "Terminated: Error (exit code 1)"
+	What string
+
+	// Detail is a longer message, such as why a container stopped
+	Detail string
+
+	Warning bool
+}
+
+// Timeline lists what is known to have happened to the pod, oldest first.
+// Kubernetes keeps only the latest transition of each condition and the
+// last two states of each container; events fill in the rest for as long
+// as the cluster keeps them.
+func (d *PodDescription) Timeline() []TimelineEntry {
+	var entries []TimelineEntry
+	add := func(entry TimelineEntry) {
+		if !entry.Time.IsZero() {
+			entries = append(entries, entry)
+		}
+	}
+
+	add(TimelineEntry{Time: d.Pod.CreatedAt, Subject: "pod", What: "Created"})
+	node := ""
+	if d.Pod.NodeName != "" {
+		node = " on " + d.Pod.NodeName
+	}
+	add(TimelineEntry{Time: d.StartTime, Subject: "pod", What: "Started" + node})
+
+	for _, condition := range d.Conditions {
+		what := condition.Type + "=" + condition.Status
+		if condition.Reason != "" {
+			what += " (" + condition.Reason + ")"
+		}
+		add(TimelineEntry{
+			Time:    condition.LastTransition,
+			Subject: "condition",
+			What:    what,
+			Detail:  condition.Message,
+			Warning: condition.Status != "True",
+		})
+	}
+
+	for _, c := range d.InitContainers {
+		entries = append(entries, containerTimeline("init container "+c.Name, c, d.StartTime)...)
+	}
+	for _, c := range d.Containers {
+		entries = append(entries, containerTimeline("container "+c.Name, c, d.StartTime)...)
+	}
+
+	for _, event := range d.Events {
+		what := event.Reason
+		if event.Count > 1 {
+			what += fmt.Sprintf(" (x%d since %s)", event.Count, event.FirstSeen.Format(time.TimeOnly))
+		}
+		add(TimelineEntry{
+			Time:    event.LastSeen,
+			Subject: "event",
+			What:    what,
+			Detail:  strings.ReplaceAll(event.Message, "\n", " "),
+			Warning: event.IsWarning(),
+		})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Time.Before(entries[j].Time)
+	})
+	return entries
+}
+
+// containerTimeline lists the known state transitions of a container: the
+// run before its last restart, if any, and what it is doing now
+func containerTimeline(subject string, c ContainerDescription, podStarted time.Time) []TimelineEntry {
+	var entries []TimelineEntry
+	add := func(t time.Time, what, detail string, warning bool) {
+		if !t.IsZero() {
+			entries = append(entries, TimelineEntry{Time: t, Subject: subject, What: what, Detail: detail, Warning: warning})
+		}
+	}
+
+	if c.LastState.State == "Terminated" {
+		add(c.LastState.StartedAt, "Running", "", false)
+		add(c.LastState.FinishedAt, terminatedText(c.LastState), c.LastState.Message, c.LastState.ExitCode != 0)
+	}
+
+	restarts := ""
+	if c.RestartCount > 0 {
+		restarts = fmt.Sprintf(" (restart %d)", c.RestartCount)
+	}
+	switch c.State.State {
+	case "Running":
+		add(c.State.StartedAt, "Running"+restarts, "", false)
+	case "Terminated":
+		add(c.State.StartedAt, "Running"+restarts, "", false)
+		add(c.State.FinishedAt, terminatedText(c.State), c.State.Message, c.State.ExitCode != 0)
+	case "Waiting":
+		// Waiting has no timestamp; it began when the last run ended, or
+		// when the pod started for a container that has not run yet
+		since := c.LastState.FinishedAt
+		if since.IsZero() {
+			since = podStarted
+		}
+		add(since, "Waiting: "+c.State.Reason+restarts, c.State.Message, c.State.Reason != "ContainerCreating" && c.State.Reason != "PodInitializing")
+	}
+	return entries
+}
+
+func terminatedText(state ContainerState) string {
+	return fmt.Sprintf("Terminated: %s (exit code %d)", state.Reason, state.ExitCode)
+}
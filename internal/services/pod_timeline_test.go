@@ -0,0 +1,161 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTimeline(t *testing.T) {
+	created := time.Date(2026, 3, 14, 9, 0, 0, 0, time.UTC)
+	at := func(offset time.Duration) time.Time { return created.Add(offset) }
+	pod := Pod{Name: "api-7d9f", Namespace: "payments", NodeName: "node-a", CreatedAt: created}
+
+	tests := []struct {
+		name        string
+		description PodDescription
+		want        []TimelineEntry
+	}{
+		{
+			name:        "not yet started",
+			description: PodDescription{Pod: Pod{Name: "api-7d9f", CreatedAt: created}},
+			want: []TimelineEntry{
+				{Time: at(0), Subject: "pod", What: "Created"},
+			},
+		},
+		{
+			name: "running",
+			description: PodDescription{
+				Pod:       pod,
+				StartTime: at(2 * time.Second),
+				Containers: []ContainerDescription{{
+					Name:  "api",
+					State: ContainerState{State: "Running", StartedAt: at(30 * time.Second)},
+				}},
+			},
+			want: []TimelineEntry{
+				{Time: at(0), Subject: "pod", What: "Created"},
+				{Time: at(2 * time.Second), Subject: "pod", What: "Started on node-a"},
+				{Time: at(30 * time.Second), Subject: "container api", What: "Running"},
+			},
+		},
+		{
+			name: "creating container waits from the pod's start",
+			description: PodDescription{
+				Pod:       pod,
+				StartTime: at(2 * time.Second),
+				Containers: []ContainerDescription{{
+					Name:  "api",
+					State: ContainerState{State: "Waiting", Reason: "ContainerCreating"},
+				}},
+			},
+			want: []TimelineEntry{
+				{Time: at(0), Subject: "pod", What: "Created"},
+				{Time: at(2 * time.Second), Subject: "pod", What: "Started on node-a"},
+				{Time: at(2 * time.Second), Subject: "container api", What: "Waiting: ContainerCreating"},
+			},
+		},
+		{
+			// The last run lasted a minute and the back-off began as it ended
+			name: "crash looping",
+			description: PodDescription{
+				Pod:       pod,
+				StartTime: at(2 * time.Second),
+				Containers: []ContainerDescription{{
+					Name:         "api",
+					RestartCount: 3,
+					State:        ContainerState{State: "Waiting", Reason: "CrashLoopBackOff", Message: "back-off 40s"},
+					LastState: ContainerState{State: "Terminated", Reason: "Error", ExitCode: 1, Message: "panic",
+						StartedAt: at(9 * time.Minute), FinishedAt: at(10 * time.Minute)},
+				}},
+			},
+			want: []TimelineEntry{
+				{Time: at(0), Subject: "pod", What: "Created"},
+				{Time: at(2 * time.Second), Subject: "pod", What: "Started on node-a"},
+				{Time: at(9 * time.Minute), Subject: "container api", What: "Running"},
+				{Time: at(10 * time.Minute), Subject: "container api", What: "Terminated: Error (exit code 1)", Detail: "panic", Warning: true},
+				{Time: at(10 * time.Minute), Subject: "container api", What: "Waiting: CrashLoopBackOff (restart 3)", Detail: "back-off 40s", Warning: true},
+			},
+		},
+		{
+			name: "restarted after being killed",
+			description: PodDescription{
+				Pod:       pod,
+				StartTime: at(2 * time.Second),
+				Containers: []ContainerDescription{{
+					Name:         "api",
+					RestartCount: 1,
+					State:        ContainerState{State: "Running", StartedAt: at(5 * time.Minute)},
+					LastState: ContainerState{State: "Terminated", Reason: "OOMKilled", ExitCode: 137,
+						StartedAt: at(time.Minute), FinishedAt: at(4 * time.Minute)},
+				}},
+			},
+			want: []TimelineEntry{
+				{Time: at(0), Subject: "pod", What: "Created"},
+				{Time: at(2 * time.Second), Subject: "pod", What: "Started on node-a"},
+				{Time: at(time.Minute), Subject: "container api", What: "Running"},
+				{Time: at(4 * time.Minute), Subject: "container api", What: "Terminated: OOMKilled (exit code 137)", Warning: true},
+				{Time: at(5 * time.Minute), Subject: "container api", What: "Running (restart 1)"},
+			},
+		},
+		{
+			name: "init container runs before the app",
+			description: PodDescription{
+				Pod:       pod,
+				StartTime: at(2 * time.Second),
+				Containers: []ContainerDescription{{
+					Name:  "api",
+					State: ContainerState{State: "Running", StartedAt: at(45 * time.Second)},
+				}},
+				InitContainers: []ContainerDescription{{
+					Name: "migrate",
+					State: ContainerState{State: "Terminated", Reason: "Completed",
+						StartedAt: at(5 * time.Second), FinishedAt: at(40 * time.Second)},
+				}},
+			},
+			want: []TimelineEntry{
+				{Time: at(0), Subject: "pod", What: "Created"},
+				{Time: at(2 * time.Second), Subject: "pod", What: "Started on node-a"},
+				{Time: at(5 * time.Second), Subject: "init container migrate", What: "Running"},
+				{Time: at(40 * time.Second), Subject: "init container migrate", What: "Terminated: Completed (exit code 0)"},
+				{Time: at(45 * time.Second), Subject: "container api", What: "Running"},
+			},
+		},
+		{
+			name: "conditions and events interleave",
+			description: PodDescription{
+				Pod:       pod,
+				StartTime: at(2 * time.Second),
+				Conditions: []PodCondition{
+					{Type: "Ready", Status: "False", Reason: "ContainersNotReady", Message: "containers with unready status: [api]", LastTransition: at(3 * time.Minute)},
+					{Type: "PodScheduled", Status: "True", LastTransition: at(time.Second)},
+					{Type: "Initialized", Status: "True"},
+				},
+				Events: []Event{
+					{Type: EventNormal, Reason: "Scheduled", Message: "Successfully assigned payments/api-7d9f to node-a", Count: 1,
+						FirstSeen: at(time.Second), LastSeen: at(time.Second)},
+					{Type: EventWarning, Reason: "Unhealthy", Message: "Readiness probe failed:\nconnection refused", Count: 4,
+						FirstSeen: at(2 * time.Minute), LastSeen: at(6 * time.Minute)},
+					{Type: EventNormal, Reason: "Pulled"},
+				},
+			},
+			want: []TimelineEntry{
+				{Time: at(0), Subject: "pod", What: "Created"},
+				{Time: at(time.Second), Subject: "condition", What: "PodScheduled=True"},
+				{Time: at(time.Second), Subject: "event", What: "Scheduled", Detail: "Successfully assigned payments/api-7d9f to node-a"},
+				{Time: at(2 * time.Second), Subject: "pod", What: "Started on node-a"},
+				{Time: at(3 * time.Minute), Subject: "condition", What: "Ready=False (ContainersNotReady)", Detail: "containers with unready status: [api]", Warning: true},
+				{Time: at(6 * time.Minute), Subject: "event", What: "Unhealthy (x4 since 09:02:00)", Detail: "Readiness probe failed: connection refused", Warning: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.description.Timeline()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Timeline() =\n%+v\nwant\n%+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,248 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+type PortForwardServiceImpl struct {
+	client kubernetes.Interface
+	config *rest.Config
+}
+
+// NewPortForwardService creates a port-forward service. Besides the client
+// it needs the REST config the client was built from, to open streaming
+// connections.
+func NewPortForwardService(client kubernetes.Interface, config *rest.Config) PortForwardService {
+	return &PortForwardServiceImpl{
+		client: client,
+		config: config,
+	}
+}
+
+// PortForward is a running forward of a local port to a port of a pod. It
+// runs until stopped or until the connection to the pod is lost.
+type PortForward struct {
+	PortForwardRequest
+	StartedAt time.Time
+
+	sent     atomic.Int64
+	received atomic.Int64
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+	err      error
+}
+
+// BytesSent returns how many bytes were sent to the pod
+func (f *PortForward) BytesSent() int64 {
+	return f.sent.Load()
+}
+
+// BytesReceived returns how many bytes came back from the pod
+func (f *PortForward) BytesReceived() int64 {
+	return f.received.Load()
+}
+
+// Stop ends the forward, closing the local port
+func (f *PortForward) Stop() {
+	f.stopOnce.Do(func() { close(f.stop) })
+}
+
+// Done is closed once the forward has ended
+func (f *PortForward) Done() <-chan struct{} {
+	return f.done
+}
+
+// Err returns why the forward ended, nil while it runs or when it was stopped
+func (f *PortForward) Err() error {
+	select {
+	case <-f.done:
+		return f.err
+	default:
+		return nil
+	}
+}
+
+// Forward listens on a local port of the loopback interface and forwards
+// its connections to a port of a pod. Like kubectl it tunnels over
+// WebSocket, falling back to SPDY for servers that don't support it.
+func (p *PortForwardServiceImpl) Forward(ctx context.Context, req PortForwardRequest) (*PortForward, error) {
+	url := p.client.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(req.Namespace).
+		Name(req.Pod).
+		SubResource("portforward").
+		URL()
+
+	transport, upgrader, err := spdy.RoundTripperFor(p.config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to forward to %s/%s: %w", req.Namespace, req.Pod, err)
+	}
+	var dialer httpstream.Dialer = spdy.NewDialer(upgrader, &http.Client{Transport: transport}, "POST", url)
+	tunnel, err := portforward.NewSPDYOverWebsocketDialer(url, p.config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to forward to %s/%s: %w", req.Namespace, req.Pod, err)
+	}
+	dialer = portforward.NewFallbackDialer(tunnel, dialer, func(err error) bool {
+		return httpstream.IsUpgradeFailure(err) || httpstream.IsHTTPSProxyError(err)
+	})
+
+	forward := &PortForward{
+		PortForwardRequest: req,
+		StartedAt:          time.Now(),
+		stop:               make(chan struct{}),
+		done:               make(chan struct{}),
+	}
+	ready := make(chan struct{})
+	ports := []string{fmt.Sprintf("%d:%d", req.LocalPort, req.RemotePort)}
+	forwarder, err := portforward.NewOnAddresses(&countingDialer{dialer: dialer, forward: forward},
+		[]string{"localhost"}, ports, forward.stop, ready, io.Discard, io.Discard)
+	if err != nil {
+		return nil, fmt.Errorf("failed to forward to %s/%s: %w", req.Namespace, req.Pod, err)
+	}
+
+	go func() {
+		defer close(forward.done)
+		forward.err = forwarder.ForwardPorts()
+	}()
+
+	select {
+	case <-ready:
+	case <-forward.done:
+		return nil, fmt.Errorf("failed to forward port %d to %s/%s: %w", req.RemotePort, req.Namespace, req.Pod, forward.err)
+	case <-ctx.Done():
+		forward.Stop()
+		return nil, ctx.Err()
+	}
+
+	// A zero local port was given a free one
+	if forwarded, err := forwarder.GetPorts(); err == nil && len(forwarded) > 0 {
+		forward.LocalPort = int(forwarded[0].Local)
+	}
+	return forward, nil
+}
+
+// ResolveService picks the pod a forward to a Service goes to, the way
+// kubectl port-forward svc/name does: a running, ready pod matching the
+// Service's selector, and the port the Service's port targets on it
+func (p *PortForwardServiceImpl) ResolveService(ctx context.Context, namespace, name string, port int) (string, int, error) {
+	svc, err := p.client.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to get service %s/%s: %w", namespace, name, err)
+	}
+	if len(svc.Spec.Selector) == 0 {
+		return "", 0, fmt.Errorf("service %s/%s has no selector, so no pods to forward to", namespace, name)
+	}
+
+	var servicePort *corev1.ServicePort
+	for i := range svc.Spec.Ports {
+		if port == 0 || int(svc.Spec.Ports[i].Port) == port {
+			servicePort = &svc.Spec.Ports[i]
+			break
+		}
+	}
+	if servicePort == nil {
+		return "", 0, fmt.Errorf("service %s/%s has no port %d", namespace, name, port)
+	}
+
+	pods, err := p.client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(svc.Spec.Selector).String(),
+	})
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to list pods of service %s/%s: %w", namespace, name, err)
+	}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.Phase != corev1.PodRunning || pod.DeletionTimestamp != nil || !hasCondition(pod, corev1.PodReady) {
+			continue
+		}
+		if podPort, ok := targetPort(pod, servicePort); ok {
+			return pod.Name, podPort, nil
+		}
+	}
+	return "", 0, fmt.Errorf("service %s/%s has no ready pods", namespace, name)
+}
+
+// targetPort finds the pod's port behind a Service port, which may name a
+// container port rather than give its number
+func targetPort(pod *corev1.Pod, servicePort *corev1.ServicePort) (int, bool) {
+	target := servicePort.TargetPort
+	switch {
+	case target.Type == intstr.String && target.StrVal != "":
+		for _, c := range pod.Spec.Containers {
+			for _, port := range c.Ports {
+				if port.Name == target.StrVal {
+					return int(port.ContainerPort), true
+				}
+			}
+		}
+		return 0, false
+	case target.IntValue() != 0:
+		return target.IntValue(), true
+	default:
+		// An unset target port is the Service's own port
+		return int(servicePort.Port), true
+	}
+}
+
+// countingDialer counts the bytes of the data streams of the connections it
+// dials, leaving out the streams that carry errors
+type countingDialer struct {
+	dialer  httpstream.Dialer
+	forward *PortForward
+}
+
+func (d *countingDialer) Dial(protocols ...string) (httpstream.Connection, string, error) {
+	conn, protocol, err := d.dialer.Dial(protocols...)
+	if err != nil {
+		return nil, protocol, err
+	}
+	return &countingConnection{Connection: conn, forward: d.forward}, protocol, nil
+}
+
+type countingConnection struct {
+	httpstream.Connection
+	forward *PortForward
+}
+
+func (c *countingConnection) CreateStream(headers http.Header) (httpstream.Stream, error) {
+	stream, err := c.Connection.CreateStream(headers)
+	if err != nil || headers.Get(corev1.StreamType) != corev1.StreamTypeData {
+		return stream, err
+	}
+	return &countingStream{Stream: stream, forward: c.forward}, nil
+}
+
+type countingStream struct {
+	httpstream.Stream
+	forward *PortForward
+}
+
+func (s *countingStream) Read(p []byte) (int, error) {
+	n, err := s.Stream.Read(p)
+	s.forward.received.Add(int64(n))
+	return n, err
+}
+
+func (s *countingStream) Write(p []byte) (int, error) {
+	n, err := s.Stream.Write(p)
+	s.forward.sent.Add(int64(n))
+	return n, err
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/httpstream"
+)
+
+// parseRequestTimeout reads a --request-timeout value the way kubectl does:
+// a bare number is seconds, anything else a duration, and 0 means no timeout
+func parseRequestTimeout(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	duration := value
+	if seconds, err := strconv.Atoi(value); err == nil {
+		duration = fmt.Sprintf("%ds", seconds)
+	}
+	timeout, err := time.ParseDuration(duration)
+	if err != nil || timeout < 0 {
+		return 0, fmt.Errorf("invalid request timeout %q: give a positive duration such as 1s or 2m, or 0 for none", value)
+	}
+	return timeout, nil
+}
+
+// requestTimeoutRoundTripper bounds each request, including reading its
+// response, by a deadline on the request's context. Watches, followed logs
+// and upgraded connections such as exec and port-forward are left alone,
+// since they are meant to stay open.
+type requestTimeoutRoundTripper struct {
+	next    http.RoundTripper
+	timeout time.Duration
+}
+
+func (r *requestTimeoutRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if isStreamingRequest(req) {
+		return r.next.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), r.timeout)
+	resp, err := r.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// isStreamingRequest reports whether a request keeps its connection open
+// for as long as the caller wants. Exec, attach and port-forward negotiate
+// their SPDY or websocket protocol before the upgrade headers are added.
+func isStreamingRequest(req *http.Request) bool {
+	query := req.URL.Query()
+	if query.Get("watch") == "true" || query.Get("follow") == "true" {
+		return true
+	}
+	return req.Header.Get("Upgrade") != "" ||
+		req.Header.Get(httpstream.HeaderProtocolVersion) != "" ||
+		req.Header.Get("Sec-WebSocket-Protocol") != ""
+}
+
+// cancelOnClose releases a request's context once its body has been read
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
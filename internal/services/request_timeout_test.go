@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestParseRequestTimeout(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{value: "", want: 0},
+		{value: "0", want: 0},
+		{value: "5", want: 5 * time.Second},
+		{value: "1m30s", want: 90 * time.Second},
+		{value: "-1s", wantErr: true},
+		{value: "soon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseRequestTimeout(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseRequestTimeout(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseRequestTimeout(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequestTimeoutRoundTripper(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Answer at once, then keep the body open like a stream
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &requestTimeoutRoundTripper{next: http.DefaultTransport, timeout: 50 * time.Millisecond}}
+
+	tests := []struct {
+		name     string
+		path     string
+		header   string
+		wantOpen bool
+	}{
+		{name: "request", path: "/api/v1/pods"},
+		{name: "watch", path: "/api/v1/pods?watch=true", wantOpen: true},
+		{name: "followed logs", path: "/api/v1/namespaces/default/pods/api/log?follow=true", wantOpen: true},
+		{name: "exec", path: "/api/v1/namespaces/default/pods/api/exec", header: "v4.channel.k8s.io", wantOpen: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, server.URL+tt.path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.header != "" {
+				req.Header.Set("X-Stream-Protocol-Version", tt.header)
+			}
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("Do() error = %v", err)
+			}
+			defer resp.Body.Close()
+
+			// Read until the deadline would have cut the body off
+			read := make(chan error, 1)
+			go func() {
+				_, err := io.ReadAll(resp.Body)
+				read <- err
+			}()
+			select {
+			case err := <-read:
+				if tt.wantOpen {
+					t.Fatalf("stream ended early: %v", err)
+				}
+				if !errors.Is(err, context.DeadlineExceeded) {
+					t.Errorf("read error = %v, want the request timeout", err)
+				}
+			case <-time.After(500 * time.Millisecond):
+				if !tt.wantOpen {
+					t.Fatal("request outlived its timeout")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package services
+
+import (
+	"sort"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ResourceWatcher keeps live caches of one context's namespaces, pods and
+// events using informers, so every view of that context sees changes as they
+// happen instead of only on refresh. Pods and events are watched for one
+// namespace at a time.
+type ResourceWatcher struct {
+	client   kubernetes.Interface
+	onChange func()
+
+	mu                sync.Mutex
+	namespaceInformer cache.SharedIndexInformer
+	namespaceStop     chan struct{}
+	podNamespace      string
+	podInformer       cache.SharedIndexInformer
+	podStop           chan struct{}
+	eventNamespace    string
+	eventInformer     cache.SharedIndexInformer
+	eventStop         chan struct{}
+}
+
+// NewResourceWatcher creates a watcher for a client. onChange is called from
+// informer goroutines whenever a watched resource is added, updated or deleted.
+func NewResourceWatcher(client kubernetes.Interface, onChange func()) *ResourceWatcher {
+	return &ResourceWatcher{
+		client:   client,
+		onChange: onChange,
+	}
+}
+
+// WatchNamespaces starts watching namespaces, if not already watching
+func (w *ResourceWatcher) WatchNamespaces() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.namespaceInformer != nil {
+		return
+	}
+
+	w.namespaceInformer = coreinformers.NewNamespaceInformer(w.client, 0, cache.Indexers{})
+	w.namespaceStop = make(chan struct{})
+	w.run(w.namespaceInformer, w.namespaceStop)
+}
+
+// WatchPods starts watching the pods of a namespace, replacing the watch on
+// any other namespace
+func (w *ResourceWatcher) WatchPods(namespace string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.podInformer != nil {
+		if w.podNamespace == namespace {
+			return
+		}
+		close(w.podStop)
+	}
+
+	w.podNamespace = namespace
+	w.podInformer = coreinformers.NewPodInformer(w.client, namespace, 0, cache.Indexers{})
+	w.podStop = make(chan struct{})
+	w.run(w.podInformer, w.podStop)
+}
+
+// WatchEvents starts watching the events of a namespace, replacing the watch
+// on any other namespace
+func (w *ResourceWatcher) WatchEvents(namespace string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.eventInformer != nil {
+		if w.eventNamespace == namespace {
+			return
+		}
+		close(w.eventStop)
+	}
+
+	w.eventNamespace = namespace
+	w.eventInformer = coreinformers.NewEventInformer(w.client, namespace, 0, cache.Indexers{})
+	w.eventStop = make(chan struct{})
+	w.run(w.eventInformer, w.eventStop)
+}
+
+// Namespaces returns the cached namespaces sorted by name. The second result
+// is false until the namespace cache has synced.
+func (w *ResourceWatcher) Namespaces() ([]Namespace, bool) {
+	w.mu.Lock()
+	informer := w.namespaceInformer
+	w.mu.Unlock()
+
+	if informer == nil || !informer.HasSynced() {
+		return nil, false
+	}
+
+	objects := informer.GetStore().List()
+	namespaces := make([]Namespace, 0, len(objects))
+	for _, obj := range objects {
+		if ns, ok := obj.(*corev1.Namespace); ok {
+			namespaces = append(namespaces, newNamespace(ns))
+		}
+	}
+	sort.Slice(namespaces, func(i, j int) bool {
+		return namespaces[i].Name < namespaces[j].Name
+	})
+	return namespaces, true
+}
+
+// Pods returns the cached pods of a namespace that match a query, sorted by
+// name. The second result is false unless that namespace is watched and its
+// cache has synced.
+func (w *ResourceWatcher) Pods(namespace string, query PodQuery) ([]Pod, bool) {
+	w.mu.Lock()
+	informer := w.podInformer
+	watched := w.podNamespace == namespace
+	w.mu.Unlock()
+
+	if informer == nil || !watched || !informer.HasSynced() {
+		return nil, false
+	}
+
+	objects := informer.GetStore().List()
+	pods := make([]Pod, 0, len(objects))
+	for _, obj := range objects {
+		if pod, ok := obj.(*corev1.Pod); ok && query.Matches(pod) {
+			pods = append(pods, newPod(pod))
+		}
+	}
+	sort.Slice(pods, func(i, j int) bool {
+		return pods[i].Name < pods[j].Name
+	})
+	return pods, true
+}
+
+// Events returns the cached events of a namespace, oldest first. The second
+// result is false unless that namespace's events are watched and its cache
+// has synced.
+func (w *ResourceWatcher) Events(namespace string) ([]Event, bool) {
+	w.mu.Lock()
+	informer := w.eventInformer
+	watched := w.eventNamespace == namespace
+	w.mu.Unlock()
+
+	if informer == nil || !watched || !informer.HasSynced() {
+		return nil, false
+	}
+
+	objects := informer.GetStore().List()
+	events := make([]Event, 0, len(objects))
+	for _, obj := range objects {
+		if ev, ok := obj.(*corev1.Event); ok {
+			events = append(events, newEvent(ev))
+		}
+	}
+	sortEvents(events)
+	return events, true
+}
+
+// Stop ends all watches
+func (w *ResourceWatcher) Stop() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.namespaceInformer != nil {
+		close(w.namespaceStop)
+		w.namespaceInformer = nil
+	}
+	if w.podInformer != nil {
+		close(w.podStop)
+		w.podInformer = nil
+		w.podNamespace = ""
+	}
+	if w.eventInformer != nil {
+		close(w.eventStop)
+		w.eventInformer = nil
+		w.eventNamespace = ""
+	}
+}
+
+// run starts an informer that reports every change through onChange
+func (w *ResourceWatcher) run(informer cache.SharedIndexInformer, stop chan struct{}) {
+	notify := func() {
+		if w.onChange != nil {
+			w.onChange()
+		}
+	}
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(interface{}) { notify() },
+		UpdateFunc: func(interface{}, interface{}) { notify() },
+		DeleteFunc: func(interface{}) { notify() },
+	})
+
+	// The reflector retries failed lists and watches with backoff; failures
+	// are reported by the one-shot loads, so don't let it log over the TUI
+	informer.SetWatchErrorHandler(func(*cache.Reflector, error) {})
+
+	go informer.Run(stop)
+}
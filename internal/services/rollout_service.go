@@ -0,0 +1,279 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// revisionAnnotation numbers the revision a Deployment's ReplicaSet holds
+	revisionAnnotation = "deployment.kubernetes.io/revision"
+
+	// changeCauseAnnotation says why a revision was made
+	changeCauseAnnotation = "kubernetes.io/change-cause"
+)
+
+type RolloutServiceImpl struct {
+	client kubernetes.Interface
+}
+
+func NewRolloutService(client kubernetes.Interface) RolloutService {
+	return &RolloutServiceImpl{
+		client: client,
+	}
+}
+
+// History lists the revisions of a workload, oldest first, the way kubectl
+// rollout history does
+func (r *RolloutServiceImpl) History(ctx context.Context, namespace string, workload OwnerRef) ([]Revision, error) {
+	var revisions []Revision
+	switch workload.Kind {
+	case KindDeployment:
+		d, err := r.client.AppsV1().Deployments(namespace).Get(ctx, workload.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get deployment %s/%s: %w", namespace, workload.Name, err)
+		}
+		replicaSets, err := deploymentReplicaSets(ctx, r.client, d)
+		if err != nil {
+			return nil, err
+		}
+		for _, rs := range replicaSets {
+			revision := Revision{
+				Number:      replicaSetRevision(&rs),
+				Name:        rs.Name,
+				CreatedAt:   rs.CreationTimestamp.Time,
+				ChangeCause: rs.Annotations[changeCauseAnnotation],
+				Images:      templateImages(&rs.Spec.Template),
+			}
+			revision.Current = d.Annotations[revisionAnnotation] == strconv.FormatInt(revision.Number, 10)
+			revisions = append(revisions, revision)
+		}
+
+	case KindStatefulSet, KindDaemonSet:
+		history, updateRevision, err := controllerRevisions(ctx, r.client, namespace, workload)
+		if err != nil {
+			return nil, err
+		}
+		for _, rev := range history {
+			revisions = append(revisions, Revision{
+				Number:      rev.Revision,
+				Name:        rev.Name,
+				CreatedAt:   rev.CreationTimestamp.Time,
+				ChangeCause: rev.Annotations[changeCauseAnnotation],
+				Images:      revisionImages(&rev),
+				Current:     rev.Name == updateRevision,
+			})
+		}
+		// Without an update revision the newest is the one running
+		if updateRevision == "" && len(revisions) > 0 {
+			revisions[len(revisions)-1].Current = true
+		}
+
+	default:
+		return nil, fmt.Errorf("a %s has no rollout history", strings.ToLower(workload.Kind))
+	}
+
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Number < revisions[j].Number
+	})
+	return revisions, nil
+}
+
+// Status reports the progress of a workload's rollout with the messages
+// kubectl rollout status prints
+func (r *RolloutServiceImpl) Status(ctx context.Context, namespace string, workload OwnerRef) (RolloutStatus, error) {
+	opts := metav1.GetOptions{}
+	switch workload.Kind {
+	case KindDeployment:
+		d, err := r.client.AppsV1().Deployments(namespace).Get(ctx, workload.Name, opts)
+		if err != nil {
+			return RolloutStatus{}, fmt.Errorf("failed to get deployment %s/%s: %w", namespace, workload.Name, err)
+		}
+		return deploymentRolloutStatus(d), nil
+	case KindStatefulSet:
+		sts, err := r.client.AppsV1().StatefulSets(namespace).Get(ctx, workload.Name, opts)
+		if err != nil {
+			return RolloutStatus{}, fmt.Errorf("failed to get statefulset %s/%s: %w", namespace, workload.Name, err)
+		}
+		return statefulSetRolloutStatus(sts), nil
+	case KindDaemonSet:
+		ds, err := r.client.AppsV1().DaemonSets(namespace).Get(ctx, workload.Name, opts)
+		if err != nil {
+			return RolloutStatus{}, fmt.Errorf("failed to get daemonset %s/%s: %w", namespace, workload.Name, err)
+		}
+		return daemonSetRolloutStatus(ds), nil
+	default:
+		return RolloutStatus{}, fmt.Errorf("a %s has no rollout", strings.ToLower(workload.Kind))
+	}
+}
+
+func deploymentRolloutStatus(d *appsv1.Deployment) RolloutStatus {
+	if d.Generation > d.Status.ObservedGeneration {
+		return RolloutStatus{Message: "Waiting for deployment spec update to be observed"}
+	}
+	for _, condition := range d.Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing && condition.Reason == "ProgressDeadlineExceeded" {
+			return RolloutStatus{Message: fmt.Sprintf("deployment %q exceeded its progress deadline", d.Name), Done: true, Failed: true}
+		}
+	}
+
+	desired := int32(1)
+	if d.Spec.Replicas != nil {
+		desired = *d.Spec.Replicas
+	}
+	status := d.Status
+	switch {
+	case status.UpdatedReplicas < desired:
+		return RolloutStatus{Message: fmt.Sprintf("Waiting for rollout to finish: %d out of %d new replicas have been updated", status.UpdatedReplicas, desired)}
+	case status.Replicas > status.UpdatedReplicas:
+		return RolloutStatus{Message: fmt.Sprintf("Waiting for rollout to finish: %d old replicas are pending termination", status.Replicas-status.UpdatedReplicas)}
+	case status.AvailableReplicas < status.UpdatedReplicas:
+		return RolloutStatus{Message: fmt.Sprintf("Waiting for rollout to finish: %d of %d updated replicas are available", status.AvailableReplicas, status.UpdatedReplicas)}
+	}
+	return RolloutStatus{Message: fmt.Sprintf("deployment %q successfully rolled out", d.Name), Done: true}
+}
+
+func statefulSetRolloutStatus(sts *appsv1.StatefulSet) RolloutStatus {
+	if sts.Spec.UpdateStrategy.Type != appsv1.RollingUpdateStatefulSetStrategyType {
+		return RolloutStatus{Message: "rollout status is only available for the RollingUpdate strategy", Done: true}
+	}
+	if sts.Status.ObservedGeneration == 0 || sts.Generation > sts.Status.ObservedGeneration {
+		return RolloutStatus{Message: "Waiting for statefulset spec update to be observed"}
+	}
+
+	desired := int32(1)
+	if sts.Spec.Replicas != nil {
+		desired = *sts.Spec.Replicas
+	}
+	status := sts.Status
+	if status.ReadyReplicas < desired {
+		return RolloutStatus{Message: fmt.Sprintf("Waiting for %d pods to be ready", desired-status.ReadyReplicas)}
+	}
+	if rolling := sts.Spec.UpdateStrategy.RollingUpdate; rolling != nil && rolling.Partition != nil && *rolling.Partition > 0 {
+		updating := desired - *rolling.Partition
+		if status.UpdatedReplicas < updating {
+			return RolloutStatus{Message: fmt.Sprintf("Waiting for partitioned roll out to finish: %d out of %d new pods have been updated", status.UpdatedReplicas, updating)}
+		}
+		return RolloutStatus{Message: fmt.Sprintf("partitioned roll out complete: %d new pods have been updated", status.UpdatedReplicas), Done: true}
+	}
+	if status.UpdateRevision != status.CurrentRevision {
+		return RolloutStatus{Message: fmt.Sprintf("Waiting for statefulset rolling update to complete %d pods at revision %s", status.UpdatedReplicas, status.UpdateRevision)}
+	}
+	return RolloutStatus{Message: fmt.Sprintf("statefulset rolling update complete %d pods at revision %s", status.CurrentReplicas, status.CurrentRevision), Done: true}
+}
+
+func daemonSetRolloutStatus(ds *appsv1.DaemonSet) RolloutStatus {
+	if ds.Spec.UpdateStrategy.Type != appsv1.RollingUpdateDaemonSetStrategyType {
+		return RolloutStatus{Message: "rollout status is only available for the RollingUpdate strategy", Done: true}
+	}
+	if ds.Generation > ds.Status.ObservedGeneration {
+		return RolloutStatus{Message: "Waiting for daemon set spec update to be observed"}
+	}
+
+	status := ds.Status
+	switch {
+	case status.UpdatedNumberScheduled < status.DesiredNumberScheduled:
+		return RolloutStatus{Message: fmt.Sprintf("Waiting for rollout to finish: %d out of %d new pods have been updated", status.UpdatedNumberScheduled, status.DesiredNumberScheduled)}
+	case status.NumberAvailable < status.DesiredNumberScheduled:
+		return RolloutStatus{Message: fmt.Sprintf("Waiting for rollout to finish: %d of %d updated pods are available", status.NumberAvailable, status.DesiredNumberScheduled)}
+	}
+	return RolloutStatus{Message: fmt.Sprintf("daemon set %q successfully rolled out", ds.Name), Done: true}
+}
+
+// deploymentReplicaSets lists the ReplicaSets a Deployment controls, each
+// holding one revision of its pod template
+func deploymentReplicaSets(ctx context.Context, client kubernetes.Interface, d *appsv1.Deployment) ([]appsv1.ReplicaSet, error) {
+	selector, err := metav1.LabelSelectorAsSelector(d.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("deployment %s/%s has an invalid selector: %w", d.Namespace, d.Name, err)
+	}
+	list, err := client.AppsV1().ReplicaSets(d.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list replicasets of deployment %s/%s: %w", d.Namespace, d.Name, err)
+	}
+
+	var owned []appsv1.ReplicaSet
+	for _, rs := range list.Items {
+		if controllerOf(&rs.ObjectMeta) == (OwnerRef{Kind: KindDeployment, Name: d.Name}) {
+			owned = append(owned, rs)
+		}
+	}
+	return owned, nil
+}
+
+// controllerRevisions lists the ControllerRevisions of a StatefulSet or
+// DaemonSet, with the name of the one being rolled out if the workload
+// says which
+func controllerRevisions(ctx context.Context, client kubernetes.Interface, namespace string, workload OwnerRef) ([]appsv1.ControllerRevision, string, error) {
+	var labelSelector *metav1.LabelSelector
+	var updateRevision string
+	switch workload.Kind {
+	case KindStatefulSet:
+		sts, err := client.AppsV1().StatefulSets(namespace).Get(ctx, workload.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to get statefulset %s/%s: %w", namespace, workload.Name, err)
+		}
+		labelSelector, updateRevision = sts.Spec.Selector, sts.Status.UpdateRevision
+	case KindDaemonSet:
+		ds, err := client.AppsV1().DaemonSets(namespace).Get(ctx, workload.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to get daemonset %s/%s: %w", namespace, workload.Name, err)
+		}
+		labelSelector = ds.Spec.Selector
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s %s/%s has an invalid selector: %w", strings.ToLower(workload.Kind), namespace, workload.Name, err)
+	}
+	list, err := client.AppsV1().ControllerRevisions(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list revisions of %s %s/%s: %w", strings.ToLower(workload.Kind), namespace, workload.Name, err)
+	}
+
+	var owned []appsv1.ControllerRevision
+	for _, rev := range list.Items {
+		if controllerOf(&rev.ObjectMeta) == workload {
+			owned = append(owned, rev)
+		}
+	}
+	return owned, updateRevision, nil
+}
+
+// replicaSetRevision reads the revision number a Deployment gave a ReplicaSet
+func replicaSetRevision(rs *appsv1.ReplicaSet) int64 {
+	revision, _ := strconv.ParseInt(rs.Annotations[revisionAnnotation], 10, 64)
+	return revision
+}
+
+// templateImages lists the images of a pod template's containers
+func templateImages(template *corev1.PodTemplateSpec) []ContainerImage {
+	images := make([]ContainerImage, 0, len(template.Spec.Containers))
+	for _, c := range template.Spec.Containers {
+		images = append(images, ContainerImage{Container: c.Name, Image: c.Image})
+	}
+	return images
+}
+
+// revisionImages reads the images of the pod template a ControllerRevision
+// keeps, which is stored as a patch of the workload's spec
+func revisionImages(rev *appsv1.ControllerRevision) []ContainerImage {
+	var data struct {
+		Spec struct {
+			Template corev1.PodTemplateSpec `json:"template"`
+		} `json:"spec"`
+	}
+	if err := json.Unmarshal(rev.Data.Raw, &data); err != nil {
+		return nil
+	}
+	return templateImages(&data.Spec.Template)
+}
@@ -0,0 +1,389 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type WorkloadServiceImpl struct {
+	client kubernetes.Interface
+}
+
+func NewWorkloadService(client kubernetes.Interface) WorkloadService {
+	return &WorkloadServiceImpl{
+		client: client,
+	}
+}
+
+// ListWorkloads lists the top-level controllers of a namespace's pods,
+// resolving ReplicaSets to their Deployments and Jobs to their CronJobs.
+// Kinds the user may not list are left out rather than failing the list.
+func (w *WorkloadServiceImpl) ListWorkloads(ctx context.Context, namespace string) ([]Workload, error) {
+	var workloads []Workload
+	for _, kind := range []string{KindDeployment, KindStatefulSet, KindDaemonSet, KindCronJob} {
+		list, _, err := w.list(ctx, namespace, kind)
+		if err := ignoreForbidden(err); err != nil {
+			return nil, err
+		}
+		workloads = append(workloads, list...)
+	}
+
+	// ReplicaSets and Jobs stand alone unless a Deployment or CronJob owns them
+	for _, kind := range []string{KindReplicaSet, KindJob} {
+		list, owners, err := w.list(ctx, namespace, kind)
+		if err := ignoreForbidden(err); err != nil {
+			return nil, err
+		}
+		workloads = append(workloads, adopt(workloads, list, owners)...)
+	}
+
+	// A CronJob's pods are found through the Jobs it created
+	for i := range workloads {
+		if workloads[i].Kind == KindCronJob {
+			workloads[i].Selector = jobsSelector(workloads[i].Controllers)
+		}
+	}
+
+	sortWorkloads(workloads)
+	return workloads, nil
+}
+
+// ListWorkloadsOfKind lists the workloads of one kind in a namespace
+func (w *WorkloadServiceImpl) ListWorkloadsOfKind(ctx context.Context, namespace, kind string) ([]Workload, error) {
+	workloads, _, err := w.list(ctx, namespace, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	// A CronJob's pods are found through the Jobs it created
+	if kind == KindCronJob {
+		jobs, owners, err := w.list(ctx, namespace, KindJob)
+		if err := ignoreForbidden(err); err != nil {
+			return nil, err
+		}
+		adopt(workloads, jobs, owners)
+		for i := range workloads {
+			workloads[i].Selector = jobsSelector(workloads[i].Controllers)
+		}
+	}
+
+	sortWorkloads(workloads)
+	return workloads, nil
+}
+
+// list fetches the workloads of a kind along with the controller of each
+func (w *WorkloadServiceImpl) list(ctx context.Context, namespace, kind string) ([]Workload, []OwnerRef, error) {
+	opts := metav1.ListOptions{}
+	var workloads []Workload
+	var owners []OwnerRef
+	var err error
+
+	switch kind {
+	case KindDeployment:
+		var list *appsv1.DeploymentList
+		if list, err = w.client.AppsV1().Deployments(namespace).List(ctx, opts); err == nil {
+			for i := range list.Items {
+				workloads = append(workloads, newDeploymentWorkload(&list.Items[i]))
+				owners = append(owners, controllerOf(&list.Items[i].ObjectMeta))
+			}
+		}
+	case KindStatefulSet:
+		var list *appsv1.StatefulSetList
+		if list, err = w.client.AppsV1().StatefulSets(namespace).List(ctx, opts); err == nil {
+			for i := range list.Items {
+				workloads = append(workloads, newStatefulSetWorkload(&list.Items[i]))
+				owners = append(owners, controllerOf(&list.Items[i].ObjectMeta))
+			}
+		}
+	case KindDaemonSet:
+		var list *appsv1.DaemonSetList
+		if list, err = w.client.AppsV1().DaemonSets(namespace).List(ctx, opts); err == nil {
+			for i := range list.Items {
+				workloads = append(workloads, newDaemonSetWorkload(&list.Items[i]))
+				owners = append(owners, controllerOf(&list.Items[i].ObjectMeta))
+			}
+		}
+	case KindReplicaSet:
+		var list *appsv1.ReplicaSetList
+		if list, err = w.client.AppsV1().ReplicaSets(namespace).List(ctx, opts); err == nil {
+			for i := range list.Items {
+				workloads = append(workloads, newReplicaSetWorkload(&list.Items[i]))
+				owners = append(owners, controllerOf(&list.Items[i].ObjectMeta))
+			}
+		}
+	case KindJob:
+		var list *batchv1.JobList
+		if list, err = w.client.BatchV1().Jobs(namespace).List(ctx, opts); err == nil {
+			for i := range list.Items {
+				workloads = append(workloads, newJobWorkload(&list.Items[i]))
+				owners = append(owners, controllerOf(&list.Items[i].ObjectMeta))
+			}
+		}
+	case KindCronJob:
+		var list *batchv1.CronJobList
+		if list, err = w.client.BatchV1().CronJobs(namespace).List(ctx, opts); err == nil {
+			for i := range list.Items {
+				workloads = append(workloads, newCronJobWorkload(&list.Items[i]))
+				owners = append(owners, controllerOf(&list.Items[i].ObjectMeta))
+			}
+		}
+	default:
+		return nil, nil, fmt.Errorf("unknown workload kind %s", kind)
+	}
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list %ss in namespace %s: %w", strings.ToLower(kind), namespace, err)
+	}
+	return workloads, owners, nil
+}
+
+// adopt records children owned by one of parents as that parent's
+// controllers, returning the children no parent owns
+func adopt(parents, children []Workload, owners []OwnerRef) []Workload {
+	index := make(map[OwnerRef]int)
+	for i, parent := range parents {
+		index[OwnerRef{Kind: parent.Kind, Name: parent.Name}] = i
+	}
+
+	var orphans []Workload
+	for i, child := range children {
+		if p, ok := index[owners[i]]; ok {
+			parents[p].Controllers = append(parents[p].Controllers, OwnerRef{Kind: child.Kind, Name: child.Name})
+			continue
+		}
+		orphans = append(orphans, child)
+	}
+	return orphans
+}
+
+func newDeploymentWorkload(d *appsv1.Deployment) Workload {
+	desired := int(replicasOrOne(d.Spec.Replicas))
+	status := "Progressing"
+	switch {
+	case d.Spec.Paused:
+		status = "Paused"
+	case hasDeploymentReason(d, appsv1.DeploymentProgressing, "ProgressDeadlineExceeded"):
+		status = "Failed"
+	case d.Status.ObservedGeneration >= d.Generation &&
+		int(d.Status.UpdatedReplicas) == desired &&
+		int(d.Status.Replicas) == desired &&
+		int(d.Status.AvailableReplicas) == desired:
+		status = "Complete"
+	}
+
+	return Workload{
+		Kind:      KindDeployment,
+		Name:      d.Name,
+		Namespace: d.Namespace,
+		CreatedAt: d.CreationTimestamp.Time,
+		Ready:     int(d.Status.ReadyReplicas),
+		Desired:   desired,
+		UpToDate:  int(d.Status.UpdatedReplicas),
+		Available: int(d.Status.AvailableReplicas),
+		Status:    status,
+		Selector:  formatSelector(d.Spec.Selector),
+	}
+}
+
+func newStatefulSetWorkload(ss *appsv1.StatefulSet) Workload {
+	desired := int(replicasOrOne(ss.Spec.Replicas))
+	status := "Progressing"
+	if ss.Status.ObservedGeneration >= ss.Generation &&
+		int(ss.Status.UpdatedReplicas) == desired &&
+		int(ss.Status.ReadyReplicas) == desired {
+		status = "Complete"
+	}
+
+	return Workload{
+		Kind:        KindStatefulSet,
+		Name:        ss.Name,
+		Namespace:   ss.Namespace,
+		CreatedAt:   ss.CreationTimestamp.Time,
+		Ready:       int(ss.Status.ReadyReplicas),
+		Desired:     desired,
+		UpToDate:    int(ss.Status.UpdatedReplicas),
+		Available:   int(ss.Status.AvailableReplicas),
+		Status:      status,
+		Selector:    formatSelector(ss.Spec.Selector),
+		Controllers: []OwnerRef{{Kind: KindStatefulSet, Name: ss.Name}},
+	}
+}
+
+func newDaemonSetWorkload(ds *appsv1.DaemonSet) Workload {
+	desired := int(ds.Status.DesiredNumberScheduled)
+	status := "Progressing"
+	if ds.Status.ObservedGeneration >= ds.Generation &&
+		int(ds.Status.UpdatedNumberScheduled) == desired &&
+		int(ds.Status.NumberAvailable) == desired {
+		status = "Complete"
+	}
+
+	return Workload{
+		Kind:        KindDaemonSet,
+		Name:        ds.Name,
+		Namespace:   ds.Namespace,
+		CreatedAt:   ds.CreationTimestamp.Time,
+		Ready:       int(ds.Status.NumberReady),
+		Desired:     desired,
+		UpToDate:    int(ds.Status.UpdatedNumberScheduled),
+		Available:   int(ds.Status.NumberAvailable),
+		Status:      status,
+		Selector:    formatSelector(ds.Spec.Selector),
+		Controllers: []OwnerRef{{Kind: KindDaemonSet, Name: ds.Name}},
+	}
+}
+
+func newReplicaSetWorkload(rs *appsv1.ReplicaSet) Workload {
+	desired := int(replicasOrOne(rs.Spec.Replicas))
+	status := "Progressing"
+	if int(rs.Status.AvailableReplicas) == desired {
+		status = "Complete"
+	}
+
+	return Workload{
+		Kind:        KindReplicaSet,
+		Name:        rs.Name,
+		Namespace:   rs.Namespace,
+		CreatedAt:   rs.CreationTimestamp.Time,
+		Ready:       int(rs.Status.ReadyReplicas),
+		Desired:     desired,
+		UpToDate:    int(rs.Status.Replicas),
+		Available:   int(rs.Status.AvailableReplicas),
+		Status:      status,
+		Selector:    formatSelector(rs.Spec.Selector),
+		Controllers: []OwnerRef{{Kind: KindReplicaSet, Name: rs.Name}},
+	}
+}
+
+func newJobWorkload(job *batchv1.Job) Workload {
+	status := "Running"
+	switch {
+	case hasJobCondition(job, batchv1.JobComplete):
+		status = "Complete"
+	case hasJobCondition(job, batchv1.JobFailed):
+		status = "Failed"
+	case job.Spec.Suspend != nil && *job.Spec.Suspend:
+		status = "Suspended"
+	}
+
+	return Workload{
+		Kind:        KindJob,
+		Name:        job.Name,
+		Namespace:   job.Namespace,
+		CreatedAt:   job.CreationTimestamp.Time,
+		Ready:       int(job.Status.Succeeded),
+		Desired:     int(replicasOrOne(job.Spec.Completions)),
+		Available:   int(job.Status.Active),
+		Status:      status,
+		Selector:    formatSelector(job.Spec.Selector),
+		Controllers: []OwnerRef{{Kind: KindJob, Name: job.Name}},
+	}
+}
+
+func newCronJobWorkload(cj *batchv1.CronJob) Workload {
+	status := "Scheduled"
+	if cj.Spec.Suspend != nil && *cj.Spec.Suspend {
+		status = "Suspended"
+	}
+
+	workload := Workload{
+		Kind:      KindCronJob,
+		Name:      cj.Name,
+		Namespace: cj.Namespace,
+		CreatedAt: cj.CreationTimestamp.Time,
+		Ready:     len(cj.Status.Active),
+		Status:    status,
+		Schedule:  cj.Spec.Schedule,
+	}
+	if cj.Status.LastScheduleTime != nil {
+		workload.LastSchedule = cj.Status.LastScheduleTime.Time
+	}
+	return workload
+}
+
+func hasDeploymentReason(d *appsv1.Deployment, condition appsv1.DeploymentConditionType, reason string) bool {
+	for _, c := range d.Status.Conditions {
+		if c.Type == condition && c.Reason == reason {
+			return true
+		}
+	}
+	return false
+}
+
+func hasJobCondition(job *batchv1.Job, condition batchv1.JobConditionType) bool {
+	for _, c := range job.Status.Conditions {
+		if c.Type == condition && c.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// formatSelector renders a label selector in the syntax of ParsePodQuery
+func formatSelector(selector *metav1.LabelSelector) string {
+	if selector == nil {
+		return ""
+	}
+	// An empty selector is shown as <none> and an invalid one as <error>
+	formatted := metav1.FormatLabelSelector(selector)
+	if strings.HasPrefix(formatted, "<") {
+		return ""
+	}
+	return formatted
+}
+
+// jobsSelector selects the pods of a set of Jobs by the job-name label
+func jobsSelector(jobs []OwnerRef) string {
+	if len(jobs) == 0 {
+		return ""
+	}
+	names := make([]string, len(jobs))
+	for i, job := range jobs {
+		names[i] = job.Name
+	}
+	sort.Strings(names)
+	return "job-name in (" + strings.Join(names, ",") + ")"
+}
+
+func sortWorkloads(workloads []Workload) {
+	sort.SliceStable(workloads, func(i, j int) bool {
+		if workloads[i].Kind != workloads[j].Kind {
+			return workloads[i].Kind < workloads[j].Kind
+		}
+		return workloads[i].Name < workloads[j].Name
+	})
+}
+
+// controllerOf returns the controller owning an object, if any
+func controllerOf(meta *metav1.ObjectMeta) OwnerRef {
+	if ref := metav1.GetControllerOfNoCopy(meta); ref != nil {
+		return OwnerRef{Kind: ref.Kind, Name: ref.Name}
+	}
+	return OwnerRef{}
+}
+
+// replicasOrOne reads an optional count that the API server defaults to 1
+func replicasOrOne(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
+
+// ignoreForbidden drops authorization errors so workloads the user can see
+// are still listed
+func ignoreForbidden(err error) error {
+	if apierrors.IsForbidden(err) {
+		return nil
+	}
+	return err
+}
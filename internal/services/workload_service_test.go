@@ -0,0 +1,160 @@
+package services
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// ownedBy returns object metadata with a controller reference to an owner
+func ownedBy(name, kind, owner string) metav1.ObjectMeta {
+	yes := true
+	return metav1.ObjectMeta{
+		Name:      name,
+		Namespace: "default",
+		OwnerReferences: []metav1.OwnerReference{
+			{Kind: kind, Name: owner, Controller: &yes},
+		},
+	}
+}
+
+func workloadObjects() []runtime.Object {
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "api"}}
+	return []runtime.Object{
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+			Spec:       appsv1.DeploymentSpec{Selector: selector},
+		},
+		&appsv1.ReplicaSet{ObjectMeta: ownedBy("api-7d4b9", KindDeployment, "api"), Spec: appsv1.ReplicaSetSpec{Selector: selector}},
+		&appsv1.ReplicaSet{ObjectMeta: ownedBy("api-5c8f2", KindDeployment, "api"), Spec: appsv1.ReplicaSetSpec{Selector: selector}},
+		&appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "legacy", Namespace: "default"},
+			Spec:       appsv1.ReplicaSetSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "legacy"}}},
+		},
+		&batchv1.CronJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "default"},
+			Spec:       batchv1.CronJobSpec{Schedule: "0 * * * *"},
+		},
+		&batchv1.Job{ObjectMeta: ownedBy("backup-29000060", KindCronJob, "backup")},
+		&batchv1.Job{ObjectMeta: ownedBy("backup-29000000", KindCronJob, "backup")},
+		&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "migrate", Namespace: "default"}},
+	}
+}
+
+func TestListWorkloads(t *testing.T) {
+	svc := NewWorkloadService(fake.NewClientset(workloadObjects()...))
+
+	workloads, err := svc.ListWorkloads(context.Background(), "default")
+	if err != nil {
+		t.Fatalf("ListWorkloads() error = %v", err)
+	}
+
+	type summary struct {
+		kind, name, selector string
+		controllers          []OwnerRef
+	}
+	var got []summary
+	for _, w := range workloads {
+		got = append(got, summary{w.Kind, w.Name, w.Selector, w.Controllers})
+	}
+	want := []summary{
+		{KindCronJob, "backup", "job-name in (backup-29000000,backup-29000060)", []OwnerRef{
+			{Kind: KindJob, Name: "backup-29000000"},
+			{Kind: KindJob, Name: "backup-29000060"},
+		}},
+		{KindDeployment, "api", "app=api", []OwnerRef{
+			{Kind: KindReplicaSet, Name: "api-5c8f2"},
+			{Kind: KindReplicaSet, Name: "api-7d4b9"},
+		}},
+		{KindJob, "migrate", "", []OwnerRef{{Kind: KindJob, Name: "migrate"}}},
+		{KindReplicaSet, "legacy", "app=legacy", []OwnerRef{{Kind: KindReplicaSet, Name: "legacy"}}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ListWorkloads() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestListWorkloadsSkipsForbiddenKinds(t *testing.T) {
+	client := fake.NewClientset(workloadObjects()...)
+	client.PrependReactor("list", "cronjobs", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(schema.GroupResource{Group: "batch", Resource: "cronjobs"}, "", nil)
+	})
+
+	workloads, err := NewWorkloadService(client).ListWorkloads(context.Background(), "default")
+	if err != nil {
+		t.Fatalf("ListWorkloads() error = %v", err)
+	}
+
+	// Without their CronJob, its Jobs stand alone
+	var jobs []string
+	for _, w := range workloads {
+		if w.Kind == KindCronJob {
+			t.Errorf("Expected no CronJobs, got %s", w.Name)
+		}
+		if w.Kind == KindJob {
+			jobs = append(jobs, w.Name)
+		}
+	}
+	if want := []string{"backup-29000000", "backup-29000060", "migrate"}; !reflect.DeepEqual(jobs, want) {
+		t.Errorf("jobs = %v, want %v", jobs, want)
+	}
+}
+
+func TestListWorkloadsOfKind(t *testing.T) {
+	svc := NewWorkloadService(fake.NewClientset(workloadObjects()...))
+
+	tests := []struct {
+		kind     string
+		want     []string
+		selector string
+	}{
+		{kind: KindCronJob, want: []string{"backup"}, selector: "job-name in (backup-29000000,backup-29000060)"},
+		{kind: KindReplicaSet, want: []string{"api-5c8f2", "api-7d4b9", "legacy"}, selector: "app=api"},
+		{kind: KindJob, want: []string{"backup-29000000", "backup-29000060", "migrate"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.kind, func(t *testing.T) {
+			workloads, err := svc.ListWorkloadsOfKind(context.Background(), "default", tt.kind)
+			if err != nil {
+				t.Fatalf("ListWorkloadsOfKind() error = %v", err)
+			}
+			var names []string
+			for _, w := range workloads {
+				names = append(names, w.Name)
+			}
+			if !reflect.DeepEqual(names, tt.want) {
+				t.Errorf("names = %v, want %v", names, tt.want)
+			}
+			if workloads[0].Selector != tt.selector {
+				t.Errorf("selector = %q, want %q", workloads[0].Selector, tt.selector)
+			}
+		})
+	}
+
+	if _, err := svc.ListWorkloadsOfKind(context.Background(), "default", "Pod"); err == nil {
+		t.Error("ListWorkloadsOfKind() with an unknown kind: want an error")
+	}
+}
+
+func TestJobsSelector(t *testing.T) {
+	if got := jobsSelector(nil); got != "" {
+		t.Errorf("jobsSelector(nil) = %q, want none", got)
+	}
+	jobs := []OwnerRef{{Kind: KindJob, Name: "b"}, {Kind: KindJob, Name: "a"}}
+	if got, want := jobsSelector(jobs), "job-name in (a,b)"; got != want {
+		t.Errorf("jobsSelector() = %q, want %q", got, want)
+	}
+	if _, err := ParsePodQuery(jobsSelector(jobs)); err != nil {
+		t.Errorf("jobsSelector() is not a valid pod query: %v", err)
+	}
+}
@@ -1,24 +1,63 @@
 package tui
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
 	"kubeoptic/internal/models"
 	"kubeoptic/internal/services"
 	"kubeoptic/internal/tui/styles"
+	"kubeoptic/pkg/config"
 )
 
 // ViewMode represents different layout modes for the application
 type ViewMode int
 
 const (
-	ThreePanelView ViewMode = iota // Context | Namespace/Pod | Status
-	LogFullScreen                  // Full-screen log view
+	ThreePanelView     ViewMode = iota // Context | Namespace/Pod | Status
+	LogFullScreen                      // Full-screen log view
+	DescribeFullScreen                 // Full-screen pod details
+	ManifestFullScreen                 // Full-screen YAML view
+	RolloutFullScreen                  // Full-screen revision history
+	FilesFullScreen                    // Full-screen container file browser
+	EditFullScreen                     // Full-screen review of an edit
 )
 
+// kubeconfigPollInterval is how often the kubeconfig file(s) are checked for changes
+const kubeconfigPollInterval = 2 * time.Second
+
+// metricsPollInterval is how often pod resource usage is refreshed;
+// metrics-server itself scrapes about every 15 seconds
+const metricsPollInterval = 15 * time.Second
+
+// portForwardRefreshInterval is how often the port-forward panel updates
+// its traffic counts
+const portForwardRefreshInterval = time.Second
+
+// rolloutPollInterval is how often a rollout started from the UI is checked
+// until it settles
+const rolloutPollInterval = 2 * time.Second
+
+// downloadRefreshInterval is how often the progress of running downloads
+// is redrawn
+const downloadRefreshInterval = 250 * time.Millisecond
+
+// statusDisplayTime is how long a status message stays in the status bar
+const statusDisplayTime = 5 * time.Second
+
+// statusExpiredMsg clears a status message once shown long enough, unless
+// another replaced it
+type statusExpiredMsg struct {
+	seq int
+}
+
 // FocusedPanel represents which panel currently has focus
 type FocusedPanel int
 
@@ -27,6 +66,14 @@ const (
 	NamespacePanel
 	PodPanel
 	LogPanel
+	WorkloadPanel
+	EventPanel
+	DescribePanel
+	ManifestPanel
+	PortForwardPanel
+	RolloutPanel
+	FilesPanel
+	EditPanel
 )
 
 // App represents the main TUI application
@@ -41,7 +88,8 @@ type App struct {
 	height       int
 
 	// Enhanced event handling
-	helpVisible bool
+	helpVisible    bool
+	paletteVisible bool
 
 	// Components as interfaces to avoid import cycle
 	contextList   ComponentRenderer
@@ -49,6 +97,36 @@ type App struct {
 	podList       ComponentRenderer
 	logView       ComponentRenderer
 	statusBar     ComponentRenderer
+	podPalette    ComponentRenderer
+	workloadList  ComponentRenderer
+	eventList     ComponentRenderer
+	podDescribe   ComponentRenderer
+	manifestView  ComponentRenderer
+	portForwards  ComponentRenderer
+	rolloutView   ComponentRenderer
+	fileBrowser   ComponentRenderer
+	editView      ComponentRenderer
+
+	// User preferences, saved when changed from the UI
+	settings     *config.Config
+	settingsPath string
+
+	// Whether the pod list groups pods by workload, which needs workloads loaded
+	groupByWorkload bool
+
+	// Action waiting for confirmation before it changes the cluster, and
+	// where the context's name is typed to confirm it in production
+	pendingAction *ActionRequestedMsg
+	confirmInput  textinput.Model
+
+	// Status message shown in the status bar, and a count telling it
+	// apart from the ones before it
+	status    *StatusMsg
+	statusSeq int
+
+	// Rollout started from the UI, followed in the status bar until it
+	// settles
+	rollout *RolloutStartedMsg
 
 	// Layout
 	theme       styles.Theme
@@ -61,10 +139,15 @@ type App struct {
 func NewApp(kubeoptic *models.Kubeoptic) *App {
 	theme := styles.DefaultTheme()
 
+	confirmInput := textinput.New()
+	confirmInput.Prompt = "> "
+	confirmInput.CharLimit = 256
+
 	app := &App{
 		kubeoptic:    kubeoptic,
 		viewMode:     ThreePanelView,
 		focusedPanel: ContextPanel,
+		confirmInput: confirmInput,
 		theme:        theme,
 		ready:        false,
 		initialized:  false,
@@ -82,6 +165,60 @@ func (a *App) SetComponents(contextList, namespaceList, podList, logView, status
 	a.statusBar = statusBar
 }
 
+// SetPodPalette sets the palette for jumping to a pod in any namespace
+func (a *App) SetPodPalette(podPalette ComponentRenderer) {
+	a.podPalette = podPalette
+}
+
+// SetWorkloadList sets the list shown in the workload view
+func (a *App) SetWorkloadList(workloadList ComponentRenderer) {
+	a.workloadList = workloadList
+}
+
+// SetEventList sets the events panel
+func (a *App) SetEventList(eventList ComponentRenderer) {
+	a.eventList = eventList
+}
+
+// SetPodDescribe sets the pod describe view
+func (a *App) SetPodDescribe(podDescribe ComponentRenderer) {
+	a.podDescribe = podDescribe
+}
+
+// SetManifestViewer sets the YAML view
+func (a *App) SetManifestViewer(manifestView ComponentRenderer) {
+	a.manifestView = manifestView
+}
+
+// SetRolloutHistory sets the revision history view
+func (a *App) SetRolloutHistory(rolloutView ComponentRenderer) {
+	a.rolloutView = rolloutView
+}
+
+// SetFileBrowser sets the container file browser
+func (a *App) SetFileBrowser(fileBrowser ComponentRenderer) {
+	a.fileBrowser = fileBrowser
+}
+
+// SetEditReview sets the review of edits made in the editor
+func (a *App) SetEditReview(editView ComponentRenderer) {
+	a.editView = editView
+}
+
+// SetPortForwardList sets the port-forward panel
+func (a *App) SetPortForwardList(portForwards ComponentRenderer) {
+	a.portForwards = portForwards
+}
+
+// SetSettings sets the user preferences and the file they are saved to
+func (a *App) SetSettings(settings *config.Config, path string) {
+	a.settings = settings
+	a.settingsPath = path
+	if settings != nil {
+		a.groupByWorkload = settings.PodTable.GroupByWorkload
+	}
+}
+
 // Init implements tea.Model interface
 func (a *App) Init() tea.Cmd {
 	var cmds []tea.Cmd
@@ -108,6 +245,15 @@ func (a *App) Init() tea.Cmd {
 		return InitCompleteMsg{}
 	})
 
+	// Start watching the kubeconfig for external changes
+	cmds = append(cmds, a.watchKubeconfig())
+
+	// Keep namespace and pod lists live as the cluster changes
+	cmds = append(cmds, a.kubeoptic.WatchResourcesCmd())
+
+	// Show resource usage where the cluster serves metrics
+	cmds = append(cmds, a.kubeoptic.LoadMetricsCmd(), a.watchMetrics())
+
 	return tea.Batch(cmds...)
 }
 
@@ -130,6 +276,11 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return a, nil
 		}
 
+		// A pending action takes only its confirmation
+		if a.pendingAction != nil {
+			return a, a.handleConfirmationKey(msg)
+		}
+
 		// Handle help overlay
 		if a.helpVisible {
 			switch msg.String() {
@@ -140,15 +291,49 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return a, nil
 		}
 
+		// The jump palette takes every key while it is open
+		if a.paletteVisible && msg.String() != "ctrl+c" {
+			model, cmd := a.podPalette.Update(msg)
+			storeComponent(&a.podPalette, model)
+			return a, cmd
+		}
+
+		// Keys typed into a search box go to it, not to global bindings
+		if msg.String() != "ctrl+c" && a.capturingInput() {
+			return a.routeKeyEvent(msg)
+		}
+
 		// Handle global key bindings
 		switch msg.String() {
 		case "ctrl+c", "q":
+			a.kubeoptic.StopAllPortForwards()
 			return a, tea.Quit
 
 		case "?":
 			a.helpVisible = true
 			return a, nil
 
+		case "ctrl+p":
+			return a, a.openPodPalette()
+
+		case "W":
+			// Workloads of the namespace, or back to its pods
+			return a, a.toggleWorkloadView()
+
+		case "E":
+			// Events of the namespace, or back to its pods
+			if a.kubeoptic.GetFocusedView() == models.EventView {
+				return a, a.closeEvents()
+			}
+			return a, a.openEvents(EventsRequestedMsg{})
+
+		case "P":
+			// Port-forwards, or back to where they were opened from
+			if a.kubeoptic.GetFocusedView() == models.PortForwardView {
+				return a, a.closePortForwards()
+			}
+			return a, a.openPortForwards()
+
 		case "tab":
 			a.nextPanel()
 			return a, a.updateFocus()
@@ -159,6 +344,21 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		case "f", "F11":
 			// Toggle full-screen log view
+			if a.viewMode == DescribeFullScreen {
+				return a, a.closeDescribe()
+			}
+			if a.viewMode == ManifestFullScreen {
+				return a, a.closeManifest()
+			}
+			if a.viewMode == RolloutFullScreen {
+				return a, a.closeRolloutHistory()
+			}
+			if a.viewMode == FilesFullScreen {
+				return a, a.closeFiles()
+			}
+			if a.viewMode == EditFullScreen {
+				return a, a.closeEdit()
+			}
 			if a.viewMode == ThreePanelView {
 				a.viewMode = LogFullScreen
 				a.focusedPanel = LogPanel
@@ -189,25 +389,558 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return a, a.updateFocus()
 
 	case ContextSelectedMsg:
-		// Handle context selection
+		// Handle context selection - namespaces load in the background
 		if msg.Context != nil {
+			// Rollouts of the previous context are no longer followed
+			a.rollout = nil
 			a.focusedPanel = NamespacePanel
-			if a.namespaceList != nil {
-				if refreshable, ok := a.namespaceList.(DataProvider); ok {
-					cmds = append(cmds, refreshable.RefreshData())
-				}
-			}
+			cmds = append(cmds, a.kubeoptic.SelectContextCmd(msg.Context.Name))
+			cmds = append(cmds, a.updateFocus())
 		}
 		return a, tea.Batch(cmds...)
 
+	case WorkloadSelectedMsg:
+		// Tail every pod of the workload in the log view
+		if msg.Workload != nil {
+			a.viewMode = LogFullScreen
+			a.focusedPanel = LogPanel
+			a.updateComponentSizes()
+			cmds = append(cmds, a.updateFocus())
+			cmds = append(cmds, a.kubeoptic.TailWorkloadCmd(*msg.Workload, msg.Pods))
+		}
+		return a, tea.Batch(cmds...)
+
+	case WorkloadListLoadedMsg:
+		if msg.RequestID != 0 && !a.kubeoptic.ApplyWorkloadList(msg) {
+			return a, nil
+		}
+		if msg.Error != nil {
+			a.err = msg.Error
+			return a, nil
+		}
+		return a.updateComponents(msg)
+
+	case WorkloadKindSelectedMsg:
+		return a, a.kubeoptic.ShowWorkloadsCmd(msg.Kind)
+
+	case WorkloadDrillDownMsg:
+		// Show the workload's pods, from where their logs are one key away
+		if msg.Workload != nil {
+			a.focusedPanel = PodPanel
+			cmds = append(cmds, a.kubeoptic.DrillDownCmd(*msg.Workload))
+			cmds = append(cmds, a.updateFocus())
+		}
+		return a, tea.Batch(cmds...)
+
+	case EventsRequestedMsg:
+		return a, a.openEvents(msg)
+
+	case DescribeRequestedMsg:
+		if msg.Pod == nil || a.podDescribe == nil {
+			return a, nil
+		}
+		a.viewMode = DescribeFullScreen
+		a.focusedPanel = DescribePanel
+		a.updateComponentSizes()
+		_, cmd := a.updateComponents(msg)
+		return a, tea.Batch(cmd, a.kubeoptic.DescribePodCmd(*msg.Pod), a.updateFocus())
+
+	case ManifestRequestedMsg:
+		if a.manifestView == nil {
+			return a, nil
+		}
+		a.viewMode = ManifestFullScreen
+		a.focusedPanel = ManifestPanel
+		a.updateComponentSizes()
+		_, cmd := a.updateComponents(msg)
+		return a, tea.Batch(cmd, a.kubeoptic.ShowManifestCmd(msg.Kind, msg.Namespace, msg.Name), a.updateFocus())
+
+	case ManifestLoadedMsg:
+		// Errors are shown in the YAML view, which can fetch again
+		if msg.RequestID != 0 && !a.kubeoptic.ApplyManifest(msg) {
+			return a, nil
+		}
+		return a.updateComponents(msg)
+
+	case RolloutHistoryRequestedMsg:
+		if msg.Workload == nil || a.rolloutView == nil {
+			return a, nil
+		}
+		a.viewMode = RolloutFullScreen
+		a.focusedPanel = RolloutPanel
+		a.updateComponentSizes()
+		_, cmd := a.updateComponents(msg)
+		workload := msg.Workload
+		return a, tea.Batch(cmd,
+			a.kubeoptic.ShowRolloutHistoryCmd(*workload),
+			a.kubeoptic.RolloutStatusCmd(workload.Namespace, workload.Kind, workload.Name),
+			a.updateFocus())
+
+	case RolloutHistoryLoadedMsg:
+		// Errors are shown in the history view, which can fetch again
+		if msg.RequestID != 0 && !a.kubeoptic.ApplyRolloutHistory(msg) {
+			return a, nil
+		}
+		return a.updateComponents(msg)
+
+	case FilesRequestedMsg:
+		if msg.Pod == nil || a.fileBrowser == nil {
+			return a, nil
+		}
+		a.viewMode = FilesFullScreen
+		a.focusedPanel = FilesPanel
+		a.updateComponentSizes()
+		_, cmd := a.updateComponents(msg)
+		_, downloadsCmd := a.updateComponents(a.downloadsChanged())
+		return a, tea.Batch(cmd, downloadsCmd, a.kubeoptic.ShowFilesCmd(*msg.Pod, msg.Container, msg.Path), a.updateFocus())
+
+	case FilesLoadedMsg:
+		// Errors are shown in the file browser, which can list again
+		if msg.RequestID != 0 && !a.kubeoptic.ApplyFiles(msg) {
+			return a, nil
+		}
+		return a.updateComponents(msg)
+
+	case DownloadRequestedMsg:
+		return a, a.kubeoptic.StartDownloadCmd(msg.Request)
+
+	case DownloadStartedMsg:
+		if msg.Error != nil {
+			a.err = msg.Error
+			return a, nil
+		}
+		cmds = append(cmds, a.kubeoptic.ApplyDownload(msg), a.watchDownloads())
+		_, cmd := a.updateComponents(a.downloadsChanged())
+		cmds = append(cmds, cmd)
+		return a, tea.Batch(cmds...)
+
+	case DownloadEndedMsg:
+		_, cmd := a.updateComponents(a.downloadsChanged())
+		_, statusCmd := a.Update(downloadStatus(msg.Download))
+		return a, tea.Batch(cmd, statusCmd)
+
+	case DownloadCancelMsg:
+		msg.Download.Cancel()
+		return a, nil
+
+	case DownloadTickMsg:
+		// Progress is redrawn only while something is copying
+		if !a.kubeoptic.DownloadsRunning() {
+			return a, nil
+		}
+		cmds = append(cmds, a.watchDownloads())
+		_, cmd := a.updateComponents(a.downloadsChanged())
+		cmds = append(cmds, cmd)
+		return a, tea.Batch(cmds...)
+
+	case PodDescribedMsg:
+		// Errors are shown in the describe view, which refreshes as the pod changes
+		if msg.RequestID != 0 && !a.kubeoptic.ApplyPodDescription(msg) {
+			return a, nil
+		}
+		return a.updateComponents(msg)
+
+	case EventsLoadedMsg:
+		if msg.RequestID != 0 && !a.kubeoptic.ApplyEvents(msg) {
+			return a, nil
+		}
+		if msg.Error != nil {
+			a.err = msg.Error
+			return a, nil
+		}
+		return a.updateComponents(msg)
+
+	case LogEventsToggledMsg:
+		// Interleaving needs the streamed pod's namespace events kept live
+		if msg.Enabled {
+			return a, a.kubeoptic.LoadEventsCmd()
+		}
+		return a, nil
+
+	case WorkloadsLoadedMsg:
+		if msg.RequestID != 0 && !a.kubeoptic.ApplyWorkloads(msg) {
+			return a, nil
+		}
+		if msg.Error != nil {
+			a.err = msg.Error
+			return a, nil
+		}
+		return a.updateComponents(msg)
+
 	case PodSelectedMsg:
-		// Handle pod selection - switch to log view
+		// Handle pod selection - switch to log view and start streaming
+		if msg.Pod != nil {
+			a.viewMode = LogFullScreen
+			a.focusedPanel = LogPanel
+			a.updateComponentSizes()
+			cmds = append(cmds, a.updateFocus())
+			cmds = append(cmds, a.kubeoptic.SelectPodCmd(*msg.Pod))
+		}
+		return a, tea.Batch(cmds...)
+
+	case AllPodsLoadedMsg:
+		if !a.kubeoptic.ApplyAllPods(msg) || !a.paletteVisible {
+			return a, nil
+		}
+		model, cmd := a.podPalette.Update(msg)
+		storeComponent(&a.podPalette, model)
+		return a, cmd
+
+	case PaletteClosedMsg:
+		a.paletteVisible = false
+		a.kubeoptic.CancelLoad(LoadingAllPods)
+		return a, nil
+
+	case PodJumpMsg:
+		// Jump straight to the pod's logs, wherever it runs
+		a.paletteVisible = false
+		a.kubeoptic.CancelLoad(LoadingAllPods)
 		if msg.Pod != nil {
 			a.viewMode = LogFullScreen
 			a.focusedPanel = LogPanel
 			a.updateComponentSizes()
 			cmds = append(cmds, a.updateFocus())
-			// TODO: Start log streaming when LogView component is ready
+			cmds = append(cmds, a.kubeoptic.JumpToPodCmd(*msg.Pod))
+		}
+		return a, tea.Batch(cmds...)
+
+	case NamespacesLoadedMsg:
+		// Results of superseded or cancelled loads are dropped
+		if msg.RequestID != 0 && !a.kubeoptic.ApplyNamespaces(msg) {
+			return a, nil
+		}
+		if msg.Error != nil {
+			a.err = msg.Error
+			return a, nil
+		}
+		// Another cluster has other metrics, if any
+		if msg.RequestID != 0 {
+			cmds = append(cmds, a.kubeoptic.LoadMetricsCmd())
+		}
+		_, cmd := a.updateComponents(msg)
+		cmds = append(cmds, cmd)
+		return a, tea.Batch(cmds...)
+
+	case PodsLoadedMsg:
+		if msg.RequestID != 0 && !a.kubeoptic.ApplyPods(msg) {
+			return a, nil
+		}
+		if msg.Error != nil {
+			a.err = msg.Error
+			return a, nil
+		}
+		// Move on to the pods once the selected namespace has loaded
+		if a.focusedPanel == NamespacePanel && a.kubeoptic.GetFocusedView() == models.PodView {
+			a.focusedPanel = PodPanel
+			cmds = append(cmds, a.updateFocus())
+		}
+		if a.groupByWorkload && msg.RequestID != 0 {
+			cmds = append(cmds, a.kubeoptic.LoadWorkloadsCmd())
+		}
+		_, cmd := a.updateComponents(msg)
+		cmds = append(cmds, cmd)
+		return a, tea.Batch(cmds...)
+
+	case LoadingCompletedMsg:
+		// A newer load of the same component is still running
+		if a.kubeoptic.IsLoading(msg.Component) {
+			return a, nil
+		}
+		return a.updateComponents(msg)
+
+	case LogStreamStartedMsg:
+		if msg.RequestID != 0 && !a.kubeoptic.ApplyLogStream(msg) {
+			return a, nil
+		}
+		return a.updateComponents(msg)
+
+	case KubeconfigTickMsg:
+		// The files are read in the background; the next check is scheduled
+		// once this one is done
+		return a, a.kubeoptic.CheckConfigCmd()
+
+	case KubeconfigLoadedMsg:
+		cmds = append(cmds, a.watchKubeconfig())
+		if !msg.Changed {
+			return a, tea.Batch(cmds...)
+		}
+		rebuilt, switched, err := a.kubeoptic.ApplyConfig(msg)
+		reloaded := KubeconfigReloadedMsg{
+			Contexts:       a.kubeoptic.GetContexts(),
+			CurrentContext: a.kubeoptic.GetSelectedContext(),
+			ClientRebuilt:  rebuilt,
+			ContextChanged: switched,
+			Error:          err,
+		}
+		cmds = append(cmds, func() tea.Msg { return reloaded })
+		return a, tea.Batch(cmds...)
+
+	case ExecRequestedMsg:
+		// A shell can change anything in the container
+		if a.kubeoptic.IsReadOnly() {
+			return a.refuseReadOnly("shell")
+		}
+		// The UI is suspended while the shell runs and comes back as it was
+		if msg.Pod != nil {
+			return a, a.kubeoptic.ShellCmd(*msg.Pod, msg.Container, msg.Shell)
+		}
+		return a, nil
+
+	case EditRequestedMsg:
+		if a.kubeoptic.IsReadOnly() {
+			return a.refuseReadOnly("edit")
+		}
+		return a, a.kubeoptic.EditCmd(msg.Kind, msg.Namespace, msg.Name)
+
+	case EditorRequestedMsg:
+		// The UI is suspended while the editor runs, as for a shell
+		if msg.Edit == nil || a.editView == nil {
+			return a, nil
+		}
+		return a, a.kubeoptic.OpenEditorCmd(*msg.Edit)
+
+	case EditorClosedMsg:
+		if msg.Error != nil {
+			a.err = msg.Error
+			return a, nil
+		}
+		// Quitting the editor without saving a change drops the edit
+		if msg.Edit == nil || msg.Edit.Edited == msg.Edit.Original {
+			if a.viewMode == EditFullScreen {
+				cmd := a.closeEdit()
+				_, statusCmd := a.Update(StatusMsg{Message: "Edit cancelled: nothing changed", Type: StatusInfo})
+				return a, tea.Batch(cmd, statusCmd)
+			}
+			return a.Update(StatusMsg{Message: "Edit cancelled: nothing changed", Type: StatusInfo})
+		}
+		a.viewMode = EditFullScreen
+		a.focusedPanel = EditPanel
+		a.updateComponentSizes()
+		_, cmd := a.updateComponents(msg)
+		return a, tea.Batch(cmd, a.kubeoptic.CheckEditCmd(*msg.Edit), a.updateFocus())
+
+	case EditCheckedMsg:
+		// Errors are shown in the review, which can edit again
+		if msg.RequestID != 0 && !a.kubeoptic.ApplyEditCheck(msg) {
+			return a, nil
+		}
+		return a.updateComponents(msg)
+
+	case EditAppliedMsg:
+		if msg.Error != nil {
+			return a.updateComponents(msg)
+		}
+		cmd := a.closeEdit()
+		edit := msg.Edit
+		_, statusCmd := a.Update(StatusMsg{
+			Message: fmt.Sprintf("Edited %s %s/%s", strings.ToLower(edit.Kind), edit.Namespace, edit.Name),
+			Type:    StatusSuccess,
+		})
+		// The YAML view shows the resource as saved
+		if a.viewMode == ManifestFullScreen {
+			cmd = tea.Batch(cmd, a.kubeoptic.ShowManifestCmd(edit.Kind, edit.Namespace, edit.Name))
+		}
+		return a, tea.Batch(cmd, statusCmd)
+
+	case DebugContainerStartedMsg:
+		// The UI is suspended while attached, as for a shell
+		return a, a.kubeoptic.AttachCmd(msg.Namespace, msg.Pod, msg.Container)
+
+	case ExecFinishedMsg:
+		if msg.Error != nil {
+			a.err = msg.Error
+		}
+		return a, nil
+
+	case ActionRequestedMsg:
+		if a.kubeoptic.IsReadOnly() {
+			return a.refuseReadOnly(msg.Action)
+		}
+		// A restart asked for from a pod first finds the pod's workload
+		if msg.Action == ActionRestart && !restartable(msg.Kind) {
+			return a, a.kubeoptic.ResolveActionCmd(msg)
+		}
+		a.pendingAction = &msg
+		if a.kubeoptic.IsProduction() {
+			// Production contexts take the context's name typed out
+			a.confirmInput.SetValue("")
+			return a, a.confirmInput.Focus()
+		}
+		return a, nil
+
+	case StatusMsg:
+		a.status = &msg
+		a.statusSeq++
+		seq := a.statusSeq
+		return a, tea.Tick(statusDisplayTime, func(time.Time) tea.Msg {
+			return statusExpiredMsg{seq: seq}
+		})
+
+	case statusExpiredMsg:
+		if msg.seq == a.statusSeq {
+			a.status = nil
+		}
+		return a, nil
+
+	case RolloutStartedMsg:
+		a.rollout = &msg
+		_, cmd := a.Update(StatusMsg{Message: msg.Message, Type: StatusInfo})
+		return a, tea.Batch(cmd, a.watchRollout(msg))
+
+	case RolloutTickMsg:
+		if !a.following(msg.Namespace, msg.Kind, msg.Name) {
+			return a, nil
+		}
+		return a, a.kubeoptic.RolloutStatusCmd(msg.Namespace, msg.Kind, msg.Name)
+
+	case RolloutStatusMsg:
+		_, cmd := a.updateComponents(msg)
+		if !a.following(msg.Namespace, msg.Kind, msg.Name) {
+			return a, cmd
+		}
+		return a, tea.Batch(cmd, a.followRollout(msg))
+
+	case PortForwardRequestedMsg:
+		return a, a.kubeoptic.StartPortForwardCmd(msg)
+
+	case PortForwardStartedMsg:
+		if msg.Error != nil {
+			a.err = msg.Error
+			return a, nil
+		}
+		cmds = append(cmds, a.kubeoptic.ApplyPortForward(msg))
+		_, cmd := a.updateComponents(a.portForwardsChanged())
+		cmds = append(cmds, cmd)
+		return a, tea.Batch(cmds...)
+
+	case PortForwardEndedMsg:
+		// The forward stays listed, showing why it ended, until stopped
+		return a.updateComponents(a.portForwardsChanged())
+
+	case PortForwardStopMsg:
+		a.kubeoptic.StopPortForward(msg.Forward)
+		return a.updateComponents(a.portForwardsChanged())
+
+	case PortForwardTickMsg:
+		// Traffic counts refresh only while the panel shows them
+		if a.kubeoptic.GetFocusedView() != models.PortForwardView {
+			return a, nil
+		}
+		cmds = append(cmds, a.watchPortForwards())
+		_, cmd := a.updateComponents(a.portForwardsChanged())
+		cmds = append(cmds, cmd)
+		return a, tea.Batch(cmds...)
+
+	case MetricsTickMsg:
+		cmds = append(cmds, a.watchMetrics())
+		if !a.kubeoptic.IsLoading(LoadingMetrics) {
+			cmds = append(cmds, a.kubeoptic.LoadMetricsCmd())
+		}
+		return a, tea.Batch(cmds...)
+
+	case MetricsLoadedMsg:
+		// Errors aren't reported: usage is hidden where it can't be loaded
+		if msg.RequestID != 0 && !a.kubeoptic.ApplyMetrics(msg) {
+			return a, nil
+		}
+		return a.updateComponents(msg)
+
+	case SearchQueryChangedMsg:
+		// Selectors are sent to the API server; the pod list shows the query meanwhile
+		cmds = append(cmds, a.kubeoptic.SearchPodsCmd(msg.Query))
+		_, cmd := a.updateComponents(msg)
+		cmds = append(cmds, cmd)
+		return a, tea.Batch(cmds...)
+
+	case ClearSearchMsg:
+		a.kubeoptic.CancelLoad(LoadingPods)
+		a.kubeoptic.ClearSearch()
+		_, cmd := a.updateComponents(msg)
+		cmds = append(cmds, cmd)
+		_, cmd = a.updateComponents(PodsLoadedMsg{
+			Pods:      a.kubeoptic.GetPods(),
+			Namespace: a.kubeoptic.GetSelectedNamespace(),
+		})
+		cmds = append(cmds, cmd)
+		return a, tea.Batch(cmds...)
+
+	case ResourcesChangedMsg:
+		cmds = append(cmds, a.kubeoptic.WatchResourcesCmd())
+		namespacesChanged, podsChanged := a.kubeoptic.SyncWatchedResources()
+		if namespacesChanged {
+			_, cmd := a.updateComponents(NamespacesLoadedMsg{Detailed: a.kubeoptic.GetNamespaces()})
+			cmds = append(cmds, cmd)
+		}
+		// New ReplicaSets and Jobs appear as workloads roll out and run
+		if podsChanged && a.groupByWorkload && a.kubeoptic.WorkloadsOutdated() &&
+			!a.kubeoptic.IsLoading(LoadingWorkloads) {
+			cmds = append(cmds, a.kubeoptic.LoadWorkloadsCmd())
+		}
+		// Rollout status follows the pods the workloads own
+		if podsChanged && a.kubeoptic.GetFocusedView() == models.WorkloadView &&
+			!a.kubeoptic.IsLoading(LoadingWorkloadList) {
+			cmds = append(cmds, a.kubeoptic.LoadWorkloadListCmd())
+		}
+		if podsChanged && a.kubeoptic.GetFocusedView() == models.DescribeView &&
+			!a.kubeoptic.IsLoading(LoadingDescribe) {
+			cmds = append(cmds, a.kubeoptic.RefreshDescriptionCmd())
+		}
+		if a.kubeoptic.SyncWatchedEvents() {
+			_, cmd := a.updateComponents(EventsLoadedMsg{
+				Namespace: a.kubeoptic.GetSelectedNamespace(),
+				Events:    a.kubeoptic.GetEvents(),
+			})
+			cmds = append(cmds, cmd)
+		}
+		if podsChanged {
+			_, cmd := a.updateComponents(PodsLoadedMsg{
+				Pods:      a.kubeoptic.GetPods(),
+				Namespace: a.kubeoptic.GetSelectedNamespace(),
+				Query:     a.kubeoptic.GetSearchQuery(),
+			})
+			cmds = append(cmds, cmd)
+		}
+		return a, tea.Batch(cmds...)
+
+	case KubeconfigReloadedMsg:
+		if msg.Error != nil {
+			a.err = msg.Error
+			return a, nil
+		}
+		// Reload namespaces when the active context now talks to the cluster differently
+		if msg.ClientRebuilt {
+			cmds = append(cmds, a.kubeoptic.LoadNamespacesCmd())
+		}
+		// Nothing shown from the removed context's cluster is kept, as when
+		// switching contexts by hand
+		if msg.ContextChanged {
+			a.rollout = nil
+			a.viewMode = ThreePanelView
+			a.focusedPanel = NamespacePanel
+			a.updateComponentSizes()
+			cmds = append(cmds, a.updateFocus())
+		}
+		_, cmd := a.updateComponents(msg)
+		cmds = append(cmds, cmd)
+		return a, tea.Batch(cmds...)
+
+	case PodTableChangedMsg:
+		if msg.GroupByWorkload && !a.groupByWorkload {
+			cmds = append(cmds, a.kubeoptic.LoadWorkloadsCmd())
+		}
+		a.groupByWorkload = msg.GroupByWorkload
+
+		if a.settings == nil || a.settingsPath == "" {
+			return a, tea.Batch(cmds...)
+		}
+		a.settings.PodTable = config.PodTable{
+			Columns:         msg.Columns,
+			SortBy:          msg.SortBy,
+			SortDescending:  msg.SortDescending,
+			GroupByWorkload: msg.GroupByWorkload,
+		}
+		if err := config.Save(a.settingsPath, a.settings); err != nil {
+			a.err = err
 		}
 		return a, tea.Batch(cmds...)
 
@@ -235,21 +968,100 @@ func (a *App) View() string {
 		return a.renderError()
 	}
 
+	if a.pendingAction != nil {
+		return a.renderConfirmation()
+	}
+
 	// Render help overlay if visible
 	if a.helpVisible {
 		return a.renderHelpOverlay()
 	}
 
+	if a.paletteVisible {
+		return a.podPalette.View()
+	}
+
 	switch a.viewMode {
 	case ThreePanelView:
 		return a.renderThreePanelView()
 	case LogFullScreen:
 		return a.renderLogFullScreen()
+	case DescribeFullScreen:
+		return a.podDescribe.View()
+	case ManifestFullScreen:
+		return a.manifestView.View()
+	case RolloutFullScreen:
+		return a.rolloutView.View()
+	case FilesFullScreen:
+		return a.fileBrowser.View()
+	case EditFullScreen:
+		return a.editView.View()
 	default:
 		return "Unknown view mode"
 	}
 }
 
+// watchKubeconfig schedules the next kubeconfig change check
+func (a *App) watchKubeconfig() tea.Cmd {
+	return tea.Tick(kubeconfigPollInterval, func(time.Time) tea.Msg {
+		return KubeconfigTickMsg{}
+	})
+}
+
+// watchMetrics schedules the next refresh of pod resource usage
+func (a *App) watchMetrics() tea.Cmd {
+	return tea.Tick(metricsPollInterval, func(time.Time) tea.Msg {
+		return MetricsTickMsg{}
+	})
+}
+
+// watchPortForwards schedules the next refresh of the port-forward panel
+func (a *App) watchPortForwards() tea.Cmd {
+	return tea.Tick(portForwardRefreshInterval, func(time.Time) tea.Msg {
+		return PortForwardTickMsg{}
+	})
+}
+
+// watchDownloads schedules the next redraw of the progress of downloads
+func (a *App) watchDownloads() tea.Cmd {
+	return tea.Tick(downloadRefreshInterval, func(time.Time) tea.Msg {
+		return DownloadTickMsg{}
+	})
+}
+
+// watchRollout schedules the next check of a followed rollout
+func (a *App) watchRollout(rollout RolloutStartedMsg) tea.Cmd {
+	return tea.Tick(rolloutPollInterval, func(time.Time) tea.Msg {
+		return RolloutTickMsg{Namespace: rollout.Namespace, Kind: rollout.Kind, Name: rollout.Name}
+	})
+}
+
+// following reports whether the rollout of a workload is being followed
+func (a *App) following(namespace, kind, name string) bool {
+	return a.rollout != nil && a.rollout.Namespace == namespace && a.rollout.Kind == kind && a.rollout.Name == name
+}
+
+// followRollout shows the progress of the followed rollout in the status
+// bar, checking it again until it settles
+func (a *App) followRollout(msg RolloutStatusMsg) tea.Cmd {
+	var status StatusMsg
+	switch {
+	case msg.Error != nil:
+		status = StatusMsg{Message: msg.Error.Error(), Type: StatusError}
+	case msg.Status.Failed:
+		status = StatusMsg{Message: msg.Status.Message, Type: StatusError}
+	case msg.Status.Done:
+		status = StatusMsg{Message: msg.Status.Message, Type: StatusSuccess}
+	default:
+		_, cmd := a.Update(StatusMsg{Message: msg.Status.Message, Type: StatusInfo})
+		return tea.Batch(cmd, a.watchRollout(*a.rollout))
+	}
+
+	a.rollout = nil
+	_, cmd := a.Update(status)
+	return cmd
+}
+
 // updateComponentSizes adjusts component sizes based on current layout
 func (a *App) updateComponentSizes() {
 	if !a.ready {
@@ -263,7 +1075,7 @@ func (a *App) updateComponentSizes() {
 		panelHeight := a.height - 3 // Leave space for status bar
 
 		// Update component sizes if they support it
-		components := []ComponentRenderer{a.contextList, a.namespaceList, a.podList}
+		components := []ComponentRenderer{a.contextList, a.namespaceList, a.podList, a.workloadList, a.eventList, a.portForwards}
 		for _, comp := range components {
 			if comp != nil {
 				if resizable, ok := comp.(Resizable); ok {
@@ -279,6 +1091,36 @@ func (a *App) updateComponentSizes() {
 				resizable.SetSize(a.width, a.height)
 			}
 		}
+
+	case DescribeFullScreen:
+		if resizable, ok := a.podDescribe.(Resizable); ok {
+			resizable.SetSize(a.width, a.height)
+		}
+
+	case ManifestFullScreen:
+		if resizable, ok := a.manifestView.(Resizable); ok {
+			resizable.SetSize(a.width, a.height)
+		}
+
+	case RolloutFullScreen:
+		if resizable, ok := a.rolloutView.(Resizable); ok {
+			resizable.SetSize(a.width, a.height)
+		}
+
+	case FilesFullScreen:
+		if resizable, ok := a.fileBrowser.(Resizable); ok {
+			resizable.SetSize(a.width, a.height)
+		}
+
+	case EditFullScreen:
+		if resizable, ok := a.editView.(Resizable); ok {
+			resizable.SetSize(a.width, a.height)
+		}
+	}
+
+	// The jump palette covers the whole screen in every layout
+	if resizable, ok := a.podPalette.(Resizable); ok {
+		resizable.SetSize(a.width, a.height)
 	}
 }
 
@@ -287,7 +1129,7 @@ func (a *App) updateFocus() tea.Cmd {
 	var cmds []tea.Cmd
 
 	// Blur all components first
-	components := []ComponentRenderer{a.contextList, a.namespaceList, a.podList, a.logView}
+	components := []ComponentRenderer{a.contextList, a.namespaceList, a.podList, a.logView, a.workloadList, a.eventList, a.podDescribe, a.manifestView, a.portForwards, a.rolloutView, a.fileBrowser, a.editView}
 	for _, comp := range components {
 		if comp != nil {
 			if focusable, ok := comp.(Focusable); ok {
@@ -307,6 +1149,22 @@ func (a *App) updateFocus() tea.Cmd {
 		activeComponent = a.podList
 	case LogPanel:
 		activeComponent = a.logView
+	case WorkloadPanel:
+		activeComponent = a.workloadList
+	case EventPanel:
+		activeComponent = a.eventList
+	case DescribePanel:
+		activeComponent = a.podDescribe
+	case ManifestPanel:
+		activeComponent = a.manifestView
+	case PortForwardPanel:
+		activeComponent = a.portForwards
+	case RolloutPanel:
+		activeComponent = a.rolloutView
+	case FilesPanel:
+		activeComponent = a.fileBrowser
+	case EditPanel:
+		activeComponent = a.editView
 	}
 
 	if activeComponent != nil {
@@ -327,17 +1185,33 @@ func (a *App) nextPanel() {
 			a.focusedPanel = NamespacePanel
 		case NamespacePanel:
 			// Check if we have pods to show
-			if len(a.kubeoptic.GetPods()) > 0 {
+			if a.kubeoptic.GetFocusedView() == models.WorkloadView {
+				a.focusedPanel = WorkloadPanel
+			} else if a.kubeoptic.GetFocusedView() == models.EventView {
+				a.focusedPanel = EventPanel
+			} else if a.kubeoptic.GetFocusedView() == models.PortForwardView {
+				a.focusedPanel = PortForwardPanel
+			} else if len(a.kubeoptic.GetPods()) > 0 {
 				a.focusedPanel = PodPanel
 			} else {
 				a.focusedPanel = ContextPanel
 			}
-		case PodPanel:
+		case PodPanel, WorkloadPanel, EventPanel, PortForwardPanel:
 			a.focusedPanel = ContextPanel
 		}
 	case LogFullScreen:
 		// In full-screen mode, stay on log panel
 		a.focusedPanel = LogPanel
+	case DescribeFullScreen:
+		a.focusedPanel = DescribePanel
+	case ManifestFullScreen:
+		a.focusedPanel = ManifestPanel
+	case RolloutFullScreen:
+		a.focusedPanel = RolloutPanel
+	case FilesFullScreen:
+		a.focusedPanel = FilesPanel
+	case EditFullScreen:
+		a.focusedPanel = EditPanel
 	}
 }
 
@@ -347,19 +1221,35 @@ func (a *App) prevPanel() {
 	case ThreePanelView:
 		switch a.focusedPanel {
 		case ContextPanel:
-			if len(a.kubeoptic.GetPods()) > 0 {
+			if a.kubeoptic.GetFocusedView() == models.WorkloadView {
+				a.focusedPanel = WorkloadPanel
+			} else if a.kubeoptic.GetFocusedView() == models.EventView {
+				a.focusedPanel = EventPanel
+			} else if a.kubeoptic.GetFocusedView() == models.PortForwardView {
+				a.focusedPanel = PortForwardPanel
+			} else if len(a.kubeoptic.GetPods()) > 0 {
 				a.focusedPanel = PodPanel
 			} else {
 				a.focusedPanel = NamespacePanel
 			}
 		case NamespacePanel:
 			a.focusedPanel = ContextPanel
-		case PodPanel:
+		case PodPanel, WorkloadPanel, EventPanel, PortForwardPanel:
 			a.focusedPanel = NamespacePanel
 		}
 	case LogFullScreen:
 		// In full-screen mode, stay on log panel
 		a.focusedPanel = LogPanel
+	case DescribeFullScreen:
+		a.focusedPanel = DescribePanel
+	case ManifestFullScreen:
+		a.focusedPanel = ManifestPanel
+	case RolloutFullScreen:
+		a.focusedPanel = RolloutPanel
+	case FilesFullScreen:
+		a.focusedPanel = FilesPanel
+	case EditFullScreen:
+		a.focusedPanel = EditPanel
 	}
 }
 
@@ -367,19 +1257,67 @@ func (a *App) prevPanel() {
 func (a *App) navigateBack() tea.Cmd {
 	switch a.kubeoptic.GetFocusedView() {
 	case models.LogView:
-		// Go back from log view to pod view
+		// Go back from log view to pod view, ending the log stream
+		a.kubeoptic.StopLogStream()
 		a.viewMode = ThreePanelView
 		a.focusedPanel = PodPanel
 		a.updateComponentSizes()
-		return a.updateFocus()
+		return tea.Batch(a.updateFocus(), func() tea.Msg {
+			return LogStreamStoppedMsg{Reason: "navigated back"}
+		})
 
 	case models.PodView:
-		// Go back from pod view to namespace view
+		// Pods of a workload go back to the workload view
+		if a.kubeoptic.BackToWorkloads() {
+			a.focusedPanel = WorkloadPanel
+			_, cmd := a.updateComponents(ClearSearchMsg{})
+			_, podsCmd := a.updateComponents(PodsLoadedMsg{
+				Pods:      a.kubeoptic.GetPods(),
+				Namespace: a.kubeoptic.GetSelectedNamespace(),
+			})
+			return tea.Batch(a.updateFocus(), cmd, podsCmd)
+		}
+		// Go back from pod view to namespace view, abandoning a pending pod load
+		a.kubeoptic.CancelLoad(LoadingPods)
 		a.focusedPanel = NamespacePanel
 		return a.updateFocus()
 
+	case models.WorkloadView:
+		// Go back from workload view to the pods, abandoning a pending workload load
+		return a.toggleWorkloadView()
+
+	case models.EventView:
+		// Go back from events view to the pods, abandoning a pending events load
+		return a.closeEvents()
+
+	case models.DescribeView:
+		// Go back from describe view to the pods
+		return a.closeDescribe()
+
+	case models.ManifestView:
+		// Go back from the YAML view to where it was opened
+		return a.closeManifest()
+
+	case models.RolloutView:
+		// Go back from the revision history to the workloads
+		return a.closeRolloutHistory()
+
+	case models.FilesView:
+		// Go back from the file browser to the pods; downloads go on
+		return a.closeFiles()
+
+	case models.EditView:
+		// Go back from the review to where the edit started, dropping it
+		return a.closeEdit()
+
+	case models.PortForwardView:
+		// Go back from the port-forwards to where they were opened; the
+		// forwards keep running
+		return a.closePortForwards()
+
 	case models.NamespaceView:
-		// Go back from namespace view to context view
+		// Go back from namespace view to context view, abandoning a pending namespace load
+		a.kubeoptic.CancelLoad(LoadingNamespaces)
 		a.focusedPanel = ContextPanel
 		return a.updateFocus()
 
@@ -393,38 +1331,271 @@ func (a *App) navigateBack() tea.Cmd {
 func (a *App) routeKeyEvent(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
-	var activeComponent ComponentRenderer
+	var activeComponent *ComponentRenderer
 	switch a.focusedPanel {
 	case ContextPanel:
-		activeComponent = a.contextList
+		activeComponent = &a.contextList
 	case NamespacePanel:
-		activeComponent = a.namespaceList
+		activeComponent = &a.namespaceList
 	case PodPanel:
-		activeComponent = a.podList
+		activeComponent = &a.podList
 	case LogPanel:
-		activeComponent = a.logView
+		activeComponent = &a.logView
+	case WorkloadPanel:
+		activeComponent = &a.workloadList
+	case EventPanel:
+		activeComponent = &a.eventList
+	case DescribePanel:
+		activeComponent = &a.podDescribe
+	case ManifestPanel:
+		activeComponent = &a.manifestView
+	case PortForwardPanel:
+		activeComponent = &a.portForwards
+	case RolloutPanel:
+		activeComponent = &a.rolloutView
+	case FilesPanel:
+		activeComponent = &a.fileBrowser
+	case EditPanel:
+		activeComponent = &a.editView
 	}
 
-	if activeComponent != nil {
-		if eventHandler, ok := activeComponent.(EventHandler); ok {
-			_, cmd = eventHandler.HandleKeyEvent(msg)
+	if activeComponent != nil && *activeComponent != nil {
+		var model tea.Model
+		if eventHandler, ok := (*activeComponent).(EventHandler); ok {
+			model, cmd = eventHandler.HandleKeyEvent(msg)
 		} else {
 			// Fallback to direct Update if EventHandler not implemented
-			_, cmd = activeComponent.Update(msg)
+			model, cmd = (*activeComponent).Update(msg)
 		}
+		storeComponent(activeComponent, model)
 	}
 
 	return a, cmd
 }
 
+// openPodPalette shows the jump palette and loads the pods of every namespace
+func (a *App) openPodPalette() tea.Cmd {
+	if a.podPalette == nil {
+		return nil
+	}
+	a.paletteVisible = true
+	model, cmd := a.podPalette.Update(PaletteOpenedMsg{})
+	storeComponent(&a.podPalette, model)
+	return tea.Batch(cmd, a.kubeoptic.LoadAllPodsCmd())
+}
+
+// toggleWorkloadView opens the workload view of the selected namespace, or
+// returns from it to the namespace's pods
+func (a *App) toggleWorkloadView() tea.Cmd {
+	if a.workloadList == nil || a.kubeoptic.GetSelectedNamespace() == "" {
+		return nil
+	}
+
+	if a.kubeoptic.GetFocusedView() == models.WorkloadView {
+		a.kubeoptic.HideWorkloads()
+		a.focusedPanel = PodPanel
+		return a.updateFocus()
+	}
+
+	kind := a.kubeoptic.GetWorkloadKind()
+	if kind == "" {
+		kind = services.KindDeployment
+	}
+	a.viewMode = ThreePanelView
+	a.focusedPanel = WorkloadPanel
+	a.updateComponentSizes()
+	return tea.Batch(a.kubeoptic.ShowWorkloadsCmd(kind), a.updateFocus())
+}
+
+// openEvents shows the events panel for the selected namespace, or for one
+// pod when the request names it
+func (a *App) openEvents(msg EventsRequestedMsg) tea.Cmd {
+	if a.eventList == nil || a.kubeoptic.GetSelectedNamespace() == "" {
+		return nil
+	}
+
+	a.viewMode = ThreePanelView
+	a.focusedPanel = EventPanel
+	a.updateComponentSizes()
+	_, cmd := a.updateComponents(msg)
+	_, eventsCmd := a.updateComponents(EventsLoadedMsg{
+		Namespace: a.kubeoptic.GetSelectedNamespace(),
+		Events:    a.kubeoptic.GetEvents(),
+	})
+	return tea.Batch(cmd, eventsCmd, a.kubeoptic.ShowEventsCmd(), a.updateFocus())
+}
+
+// closeEvents returns from the events panel to the namespace's pods
+func (a *App) closeEvents() tea.Cmd {
+	a.kubeoptic.HideEvents()
+	a.focusedPanel = PodPanel
+	return a.updateFocus()
+}
+
+// openPortForwards shows the port-forward panel
+func (a *App) openPortForwards() tea.Cmd {
+	if a.portForwards == nil {
+		return nil
+	}
+
+	a.kubeoptic.ShowPortForwards()
+	a.viewMode = ThreePanelView
+	a.focusedPanel = PortForwardPanel
+	a.updateComponentSizes()
+	_, cmd := a.updateComponents(a.portForwardsChanged())
+	return tea.Batch(cmd, a.watchPortForwards(), a.updateFocus())
+}
+
+// closePortForwards returns from the port-forward panel to the view it was
+// opened from
+func (a *App) closePortForwards() tea.Cmd {
+	switch a.kubeoptic.HidePortForwards() {
+	case models.ContextView:
+		a.focusedPanel = ContextPanel
+	case models.NamespaceView:
+		a.focusedPanel = NamespacePanel
+	case models.WorkloadView:
+		a.focusedPanel = WorkloadPanel
+	case models.EventView:
+		a.focusedPanel = EventPanel
+	default:
+		a.focusedPanel = PodPanel
+	}
+	return a.updateFocus()
+}
+
+// portForwardsChanged tells the port-forward panel what it lists
+func (a *App) portForwardsChanged() PortForwardsChangedMsg {
+	return PortForwardsChangedMsg{
+		Forwards:  a.kubeoptic.GetPortForwards(),
+		Namespace: a.kubeoptic.GetSelectedNamespace(),
+	}
+}
+
+// closeDescribe returns from the describe view to the namespace's pods
+func (a *App) closeDescribe() tea.Cmd {
+	a.kubeoptic.HideDescription()
+	a.viewMode = ThreePanelView
+	a.focusedPanel = PodPanel
+	a.updateComponentSizes()
+	return a.updateFocus()
+}
+
+// closeManifest returns from the YAML view to the pods or workloads it was
+// opened from
+func (a *App) closeManifest() tea.Cmd {
+	a.viewMode = ThreePanelView
+	switch a.kubeoptic.HideManifest() {
+	case models.WorkloadView:
+		a.focusedPanel = WorkloadPanel
+	default:
+		a.focusedPanel = PodPanel
+	}
+	a.updateComponentSizes()
+	return a.updateFocus()
+}
+
+// closeRolloutHistory returns from the revision history to the workloads it
+// was opened from
+func (a *App) closeRolloutHistory() tea.Cmd {
+	a.viewMode = ThreePanelView
+	switch a.kubeoptic.HideRolloutHistory() {
+	case models.WorkloadView:
+		a.focusedPanel = WorkloadPanel
+	default:
+		a.focusedPanel = PodPanel
+	}
+	a.updateComponentSizes()
+	return a.updateFocus()
+}
+
+// closeFiles returns from the file browser to the pods it was opened from
+func (a *App) closeFiles() tea.Cmd {
+	a.kubeoptic.HideFiles()
+	a.viewMode = ThreePanelView
+	a.focusedPanel = PodPanel
+	a.updateComponentSizes()
+	return a.updateFocus()
+}
+
+// closeEdit returns from the review of an edit to the YAML view the edit
+// started from
+func (a *App) closeEdit() tea.Cmd {
+	switch a.kubeoptic.HideEdit() {
+	case models.ManifestView:
+		a.viewMode = ManifestFullScreen
+		a.focusedPanel = ManifestPanel
+	case models.WorkloadView:
+		a.viewMode = ThreePanelView
+		a.focusedPanel = WorkloadPanel
+	default:
+		a.viewMode = ThreePanelView
+		a.focusedPanel = PodPanel
+	}
+	a.updateComponentSizes()
+	return a.updateFocus()
+}
+
+// downloadsChanged tells the file browser what it lists
+func (a *App) downloadsChanged() DownloadsChangedMsg {
+	return DownloadsChangedMsg{Downloads: a.kubeoptic.GetDownloads()}
+}
+
+// downloadStatus reports how a download ended in the status bar
+func downloadStatus(download *services.Download) StatusMsg {
+	err := download.Err()
+	switch {
+	case errors.Is(err, context.Canceled):
+		return StatusMsg{Message: "Cancelled download of " + download.Path, Type: StatusWarning}
+	case err != nil:
+		return StatusMsg{Message: err.Error(), Type: StatusError}
+	}
+	return StatusMsg{Message: fmt.Sprintf("Downloaded %s to %s", download.Path, download.Local), Type: StatusSuccess}
+}
+
+// capturingInput reports whether the focused component is taking typed text
+func (a *App) capturingInput() bool {
+	var activeComponent ComponentRenderer
+	switch a.focusedPanel {
+	case ContextPanel:
+		activeComponent = a.contextList
+	case NamespacePanel:
+		activeComponent = a.namespaceList
+	case PodPanel:
+		activeComponent = a.podList
+	case LogPanel:
+		activeComponent = a.logView
+	case WorkloadPanel:
+		activeComponent = a.workloadList
+	case EventPanel:
+		activeComponent = a.eventList
+	case DescribePanel:
+		activeComponent = a.podDescribe
+	case ManifestPanel:
+		activeComponent = a.manifestView
+	case PortForwardPanel:
+		activeComponent = a.portForwards
+	case RolloutPanel:
+		activeComponent = a.rolloutView
+	case FilesPanel:
+		activeComponent = a.fileBrowser
+	case EditPanel:
+		activeComponent = a.editView
+	}
+
+	capturer, ok := activeComponent.(InputCapturer)
+	return ok && capturer.CapturingInput()
+}
+
 // updateComponents updates all components with the given message
 func (a *App) updateComponents(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
-	components := []ComponentRenderer{a.contextList, a.namespaceList, a.podList, a.logView, a.statusBar}
+	components := []*ComponentRenderer{&a.contextList, &a.namespaceList, &a.podList, &a.logView, &a.statusBar, &a.workloadList, &a.eventList, &a.podDescribe, &a.manifestView, &a.portForwards, &a.rolloutView, &a.fileBrowser, &a.editView}
 	for _, comp := range components {
-		if comp != nil {
-			_, cmd := comp.Update(msg)
+		if *comp != nil {
+			model, cmd := (*comp).Update(msg)
+			storeComponent(comp, model)
 			if cmd != nil {
 				cmds = append(cmds, cmd)
 			}
@@ -434,6 +1605,14 @@ func (a *App) updateComponents(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return a, tea.Batch(cmds...)
 }
 
+// storeComponent keeps the model returned by a component's Update, so
+// value-type components such as ContextList retain their new state
+func storeComponent(slot *ComponentRenderer, model tea.Model) {
+	if updated, ok := model.(ComponentRenderer); ok && updated != nil {
+		*slot = updated
+	}
+}
+
 // renderThreePanelView renders the three-panel layout
 func (a *App) renderThreePanelView() string {
 	if !a.initialized {
@@ -448,7 +1627,15 @@ func (a *App) renderThreePanelView() string {
 
 	middleView := ""
 	// Show namespace list by default, or pod list if namespace is selected
-	if a.kubeoptic.GetSelectedNamespace() != "" && len(a.kubeoptic.GetPods()) > 0 {
+	// A query matching nothing still shows the pod list, so it can be changed
+	showPods := len(a.kubeoptic.GetPods()) > 0 || a.kubeoptic.IsLoading(LoadingPods) || a.kubeoptic.GetSearchQuery() != ""
+	if a.kubeoptic.GetFocusedView() == models.WorkloadView && a.workloadList != nil {
+		middleView = a.workloadList.View()
+	} else if a.kubeoptic.GetFocusedView() == models.EventView && a.eventList != nil {
+		middleView = a.eventList.View()
+	} else if a.kubeoptic.GetFocusedView() == models.PortForwardView && a.portForwards != nil {
+		middleView = a.portForwards.View()
+	} else if a.kubeoptic.GetSelectedNamespace() != "" && showPods {
 		if a.podList != nil {
 			middleView = a.podList.View()
 		}
@@ -469,7 +1656,7 @@ func (a *App) renderThreePanelView() string {
 	mainContent := lipgloss.JoinHorizontal(
 		lipgloss.Top,
 		a.addPanelBorder(contextView, a.focusedPanel == ContextPanel),
-		a.addPanelBorder(middleView, a.focusedPanel == NamespacePanel || a.focusedPanel == PodPanel),
+		a.addPanelBorder(middleView, a.focusedPanel == NamespacePanel || a.focusedPanel == PodPanel || a.focusedPanel == WorkloadPanel || a.focusedPanel == EventPanel || a.focusedPanel == PortForwardPanel),
 		a.addPanelBorder(rightView, false), // Third panel
 	)
 
@@ -503,14 +1690,26 @@ func (a *App) renderStatusBar() string {
 	leftStatus := fmt.Sprintf("Context: %s | Namespace: %s",
 		a.kubeoptic.GetSelectedContext(),
 		a.kubeoptic.GetSelectedNamespace())
+	if a.kubeoptic.IsProduction() {
+		leftStatus = "PRODUCTION | " + leftStatus
+	}
+	if a.kubeoptic.IsReadOnly() {
+		leftStatus += " | read-only"
+	}
 
 	rightStatus := "Tab: switch panels | f: full-screen | q: quit"
+	if a.status != nil {
+		rightStatus = lipgloss.NewStyle().Foreground(a.statusColor(a.status.Type)).Bold(true).Render(a.status.Message)
+	}
 
 	statusStyle := lipgloss.NewStyle().
 		Foreground(styles.Gray).
 		Background(styles.DarkGray).
 		Width(a.width).
 		Padding(0, 1)
+	if a.kubeoptic.IsProduction() {
+		statusStyle = statusStyle.Foreground(styles.Black).Background(a.theme.Production).Bold(true)
+	}
 
 	// Create status bar with left and right aligned content
 	gap := a.width - lipgloss.Width(leftStatus) - lipgloss.Width(rightStatus) - 4
@@ -537,6 +1736,148 @@ func (a *App) renderError() string {
 	return errorStyle.Render(content)
 }
 
+// statusColor returns the colour of a kind of status message
+func (a *App) statusColor(statusType StatusType) lipgloss.TerminalColor {
+	switch statusType {
+	case StatusSuccess:
+		return a.theme.Success
+	case StatusWarning:
+		return a.theme.Warning
+	case StatusError:
+		return a.theme.Error
+	default:
+		return a.theme.Info
+	}
+}
+
+// handleConfirmationKey carries out the pending action on y and drops it
+// on n or esc; other keys are ignored so a stray key changes nothing. In
+// production the action is carried out on enter once the context's name is
+// typed.
+func (a *App) handleConfirmationKey(msg tea.KeyMsg) tea.Cmd {
+	if a.kubeoptic.IsProduction() {
+		switch msg.Type {
+		case tea.KeyEsc:
+			a.pendingAction = nil
+			a.confirmInput.Blur()
+			return nil
+		case tea.KeyEnter:
+			if a.confirmInput.Value() != a.kubeoptic.GetSelectedContext() {
+				return nil
+			}
+			action := *a.pendingAction
+			a.pendingAction = nil
+			a.confirmInput.Blur()
+			return a.kubeoptic.RunActionCmd(action)
+		}
+		var cmd tea.Cmd
+		a.confirmInput, cmd = a.confirmInput.Update(msg)
+		return cmd
+	}
+
+	switch msg.String() {
+	case "y", "Y":
+		action := *a.pendingAction
+		a.pendingAction = nil
+		return a.kubeoptic.RunActionCmd(action)
+	case "n", "N", "esc":
+		a.pendingAction = nil
+	}
+	return nil
+}
+
+// renderConfirmation asks whether to carry out the pending action, naming
+// the context and namespace it happens in
+func (a *App) renderConfirmation() string {
+	action := a.pendingAction
+	kind := strings.ToLower(action.Kind)
+
+	var question, consequence string
+	switch action.Action {
+	case ActionDeletePod:
+		question = fmt.Sprintf("Delete %s %s?", kind, action.Name)
+		consequence = "Its containers are stopped gracefully; a controller owning it starts a replacement."
+	case ActionForceDeletePod:
+		question = fmt.Sprintf("Force-delete %s %s?", kind, action.Name)
+		consequence = "It is removed without waiting for its containers to stop, which may still be running on the node. Meant for pods stuck Terminating."
+	case ActionRestart:
+		question = fmt.Sprintf("Restart %s %s?", kind, action.Name)
+		consequence = "Its pods are replaced following its update strategy."
+	case ActionScale:
+		question = fmt.Sprintf("Scale %s %s to %d replicas?", kind, action.Name, action.Replicas)
+		consequence = "Pods are added or removed until it runs that many."
+		if action.Replicas == 0 {
+			consequence = "All of its pods are stopped."
+		}
+	case ActionRollback:
+		question = fmt.Sprintf("Roll back %s %s to revision %d?", kind, action.Name, action.Revision)
+		consequence = "Its pod template is put back as it was in that revision, and its pods are replaced following its update strategy."
+	case ActionEdit:
+		question = fmt.Sprintf("Apply your changes to %s %s?", kind, action.Name)
+		consequence = "The resource is replaced with your edit, changing it as the diff shows. It fails if the resource changed since it was fetched."
+	case ActionDebug:
+		question = fmt.Sprintf("Debug %s %s with %s?", kind, action.Name, action.Image)
+		consequence = "An ephemeral container running the image is added to the pod and attached to. It shares the pod's network"
+		if action.Container != "" {
+			consequence += " and sees the processes of container " + action.Container
+		}
+		consequence += ". It stays in the pod's spec until the pod is deleted."
+	default:
+		question = fmt.Sprintf("%s %s %s?", action.Action, kind, action.Name)
+	}
+
+	color := a.theme.Warning
+	contextName := a.kubeoptic.GetSelectedContext()
+	production := a.kubeoptic.IsProduction()
+	if production {
+		color = a.theme.Production
+		contextName += " (production)"
+	}
+
+	labelStyle := lipgloss.NewStyle().Foreground(a.theme.Secondary)
+	valueStyle := lipgloss.NewStyle().Foreground(a.theme.Foreground).Bold(true)
+	hintStyle := lipgloss.NewStyle().Foreground(styles.Gray)
+	content := []string{
+		lipgloss.NewStyle().Bold(true).Foreground(color).Render(question),
+		"",
+		labelStyle.Render("Context:   ") + valueStyle.Render(contextName),
+		labelStyle.Render("Namespace: ") + valueStyle.Render(action.Namespace),
+		"",
+		consequence,
+		"",
+	}
+	if production {
+		content = append(content,
+			"Type "+valueStyle.Render(a.kubeoptic.GetSelectedContext())+" to confirm:",
+			a.confirmInput.View(),
+			"",
+			hintStyle.Render("enter: confirm • esc: cancel"))
+	} else {
+		content = append(content, hintStyle.Render("y: confirm • n/esc: cancel"))
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(color).
+		Padding(1, 2).
+		Width(a.width - 4).
+		Render(lipgloss.JoinVertical(lipgloss.Left, content...))
+}
+
+// refuseReadOnly tells that an action is disabled in read-only mode
+func (a *App) refuseReadOnly(action string) (tea.Model, tea.Cmd) {
+	return a.Update(StatusMsg{Message: "Read-only mode: " + action + " is disabled", Type: StatusWarning})
+}
+
+// restartable reports whether a kind of workload can be restarted
+func restartable(kind string) bool {
+	switch kind {
+	case services.KindDeployment, services.KindStatefulSet, services.KindDaemonSet:
+		return true
+	}
+	return false
+}
+
 // addPanelBorder adds a border to a panel with focus indication
 func (a *App) addPanelBorder(content string, focused bool) string {
 	borderColor := styles.BorderInactive
@@ -544,6 +1885,16 @@ func (a *App) addPanelBorder(content string, focused bool) string {
 		borderColor = a.theme.Primary
 	}
 
+	// Production contexts are framed in their own colour, with the focused
+	// panel's border drawn thicker
+	border := lipgloss.RoundedBorder()
+	if a.kubeoptic.IsProduction() {
+		borderColor = a.theme.Production
+		if focused {
+			border = lipgloss.ThickBorder()
+		}
+	}
+
 	panelWidth := a.width/3 - 2
 	panelHeight := a.height - 3
 
@@ -556,7 +1907,7 @@ func (a *App) addPanelBorder(content string, focused bool) string {
 	}
 
 	return lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(border).
 		BorderForeground(borderColor).
 		Width(panelWidth).
 		Height(panelHeight).
@@ -577,36 +1928,18 @@ func (a *App) GetSelectedContext() string {
 	return a.kubeoptic.GetSelectedContext()
 }
 
-func (a *App) SetSelectedContext(context string) {
-	a.kubeoptic.SelectContext(context)
-}
-
 func (a *App) GetSelectedNamespace() string {
 	return a.kubeoptic.GetSelectedNamespace()
 }
 
-func (a *App) SetSelectedNamespace(namespace string) {
-	a.kubeoptic.SelectNamespace(namespace)
-}
-
 func (a *App) GetSelectedPod() *services.Pod {
 	return a.kubeoptic.GetSelectedPod()
 }
 
-func (a *App) SetSelectedPod(pod *services.Pod) {
-	if pod != nil {
-		a.kubeoptic.SelectPod(pod.Name)
-	}
-}
-
 func (a *App) GetPodSearchQuery() string {
 	return a.kubeoptic.GetSearchQuery()
 }
 
-func (a *App) SetPodSearchQuery(query string) {
-	a.kubeoptic.SearchPods(query)
-}
-
 func (a *App) GetFilteredPods() []services.Pod {
 	pods := a.kubeoptic.GetPods()
 	if pods == nil {
@@ -644,6 +1977,7 @@ func (a *App) renderHelpOverlay() string {
 		a.formatKeyBinding("tab", "next panel"),
 		a.formatKeyBinding("shift+tab", "prev panel"),
 		a.formatKeyBinding("f", "toggle fullscreen"),
+		a.formatKeyBinding("ctrl+p", "jump to a pod in any namespace"),
 		a.formatKeyBinding("esc", "back"),
 		"",
 		lipgloss.NewStyle().Bold(true).Foreground(a.theme.Secondary).Render("Navigation"),
@@ -651,6 +1985,44 @@ func (a *App) renderHelpOverlay() string {
 		a.formatKeyBinding("enter", "select"),
 		a.formatKeyBinding("/", "search"),
 		"",
+		lipgloss.NewStyle().Bold(true).Foreground(a.theme.Secondary).Render("Pods"),
+		a.formatKeyBinding("N/S/R/A/O", "sort by name/status/restarts/age/node"),
+		a.formatKeyBinding("C/M", "sort by CPU/memory usage"),
+		a.formatKeyBinding("c", "choose columns"),
+		a.formatKeyBinding("w", "group by workload"),
+		a.formatKeyBinding("space", "fold workload group"),
+		a.formatKeyBinding("enter", "pod logs, or all logs of a workload"),
+		a.formatKeyBinding("/", "query, e.g. app=api status.phase=Failed web"),
+		a.formatKeyBinding("esc", "clear query"),
+		a.formatKeyBinding("i", "describe pod"),
+		a.formatKeyBinding("t", "pod timeline"),
+		a.formatKeyBinding("y", "view YAML"),
+		a.formatKeyBinding("s", "shell into a container"),
+		a.formatKeyBinding("D", "debug with an ephemeral container"),
+		a.formatKeyBinding("p", "port-forward to the pod"),
+		a.formatKeyBinding("F", "browse and download the pod's files"),
+		a.formatKeyBinding("ctrl+x", "delete the pod"),
+		a.formatKeyBinding("X", "force-delete the pod"),
+		a.formatKeyBinding("B", "restart the pod's workload"),
+		"",
+		lipgloss.NewStyle().Bold(true).Foreground(a.theme.Secondary).Render("Workloads"),
+		a.formatKeyBinding("W", "show workloads of the namespace"),
+		a.formatKeyBinding("←/→, h/l", "switch kind"),
+		a.formatKeyBinding("enter", "show the workload's pods"),
+		a.formatKeyBinding("y", "view the workload's YAML"),
+		a.formatKeyBinding("s", "scale the workload"),
+		a.formatKeyBinding("r", "restart the workload"),
+		a.formatKeyBinding("H", "revision history; enter rolls back"),
+		a.formatKeyBinding("esc", "back to pods"),
+		"",
+		lipgloss.NewStyle().Bold(true).Foreground(a.theme.Secondary).Render("Events"),
+		a.formatKeyBinding("E", "show events of the namespace"),
+		a.formatKeyBinding("P", "show port-forwards"),
+		a.formatKeyBinding("e", "show events of the selected pod"),
+		a.formatKeyBinding("a", "switch between pod and namespace events"),
+		a.formatKeyBinding("e (logs)", "show pod events between log lines"),
+		a.formatKeyBinding("e (YAML)", "edit in $EDITOR, review the dry-run diff, a to apply"),
+		"",
 		"Press '?' or 'esc' to close help",
 	}
 
@@ -677,6 +2049,22 @@ func (a *App) getCurrentViewName() string {
 		return "Pods"
 	case models.LogView:
 		return "Logs"
+	case models.WorkloadView:
+		return "Workloads"
+	case models.EventView:
+		return "Events"
+	case models.DescribeView:
+		return "Describe"
+	case models.ManifestView:
+		return "YAML"
+	case models.PortForwardView:
+		return "Port forwards"
+	case models.RolloutView:
+		return "Revision history"
+	case models.FilesView:
+		return "Files"
+	case models.EditView:
+		return "Edit"
 	default:
 		return "Unknown"
 	}
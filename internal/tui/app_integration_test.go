@@ -114,7 +114,6 @@ func TestAppFullWorkflow(t *testing.T) {
 	}
 
 	// Test search functionality (without actually calling K8s operations)
-	// app.SetPodSearchQuery("test-query") // Skip this as it calls K8s
 	query := app.GetPodSearchQuery()
 	if query != "" {
 		// This is fine - should be empty initially
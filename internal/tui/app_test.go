@@ -1,12 +1,16 @@
 package tui
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
 
 	"kubeoptic/internal/models"
 	"kubeoptic/internal/services"
+	"kubeoptic/pkg/config"
 )
 
 // MockKubeoptic creates a mock kubeoptic instance for testing
@@ -353,3 +357,335 @@ func TestAppMessageRouting(t *testing.T) {
 		t.Error("PodSelectedMsg should switch focus to LogPanel")
 	}
 }
+
+func TestAppDropsSupersededLoads(t *testing.T) {
+	kubeoptic := createMockKubeoptic()
+	app := NewApp(kubeoptic)
+
+	// Results tagged with a request the model never issued (or cancelled) are ignored
+	stale := PodsLoadedMsg{Error: fmt.Errorf("context canceled"), RequestID: 42}
+	newModel, cmd := app.Update(stale)
+	app = newModel.(*App)
+
+	if app.err != nil {
+		t.Error("Superseded load errors should not be shown")
+	}
+	if cmd != nil {
+		t.Error("Superseded loads should not produce commands")
+	}
+
+	staleNamespaces := NamespacesLoadedMsg{Error: fmt.Errorf("timeout"), RequestID: 42}
+	newModel, _ = app.Update(staleNamespaces)
+	app = newModel.(*App)
+	if app.err != nil {
+		t.Error("Superseded namespace load errors should not be shown")
+	}
+}
+
+func TestAppActionConfirmation(t *testing.T) {
+	newApp := func() *App {
+		app := NewApp(createMockKubeoptic())
+		app.width = 100
+		app.height = 30
+		app.ready = true
+		return app
+	}
+	deletePod := ActionRequestedMsg{Action: ActionDeletePod, Namespace: "payments", Kind: services.KindPod, Name: "api-7d9f"}
+	key := func(app *App, k string) tea.Cmd {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		if k == "esc" {
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		}
+		_, cmd := app.Update(msg)
+		return cmd
+	}
+
+	t.Run("asks_first", func(t *testing.T) {
+		app := newApp()
+		app.Update(deletePod)
+		view := app.View()
+		for _, want := range []string{"Delete pod api-7d9f?", "Namespace: payments", "y: confirm"} {
+			if !strings.Contains(view, want) {
+				t.Errorf("Expected %q in the confirmation:\n%s", want, view)
+			}
+		}
+
+		// Other keys neither confirm nor cancel
+		if cmd := key(app, "q"); cmd != nil || app.pendingAction == nil {
+			t.Error("Expected a stray key to be ignored")
+		}
+		if key(app, "esc"); app.pendingAction != nil {
+			t.Error("Expected esc to cancel")
+		}
+	})
+
+	t.Run("confirmed", func(t *testing.T) {
+		app := newApp()
+		app.Update(deletePod)
+		cmd := key(app, "y")
+		if app.pendingAction != nil || cmd == nil {
+			t.Fatal("Expected y to carry out the action")
+		}
+
+		// Without a cluster the failure shows in the status bar
+		status, ok := cmd().(StatusMsg)
+		if !ok || status.Type != StatusError {
+			t.Fatalf("Expected an error status, got %+v", status)
+		}
+		app.Update(status)
+		if !strings.Contains(app.renderStatusBar(), "no cluster connection") {
+			t.Error("Expected the status in the status bar")
+		}
+
+		// A later status outlives the expiry of an earlier one
+		app.Update(StatusMsg{Message: "Deleted pod payments/api-7d9f", Type: StatusSuccess})
+		app.Update(statusExpiredMsg{seq: 1})
+		if app.status == nil || app.status.Type != StatusSuccess {
+			t.Error("Expected the newer status to stay")
+		}
+		app.Update(statusExpiredMsg{seq: 2})
+		if app.status != nil {
+			t.Error("Expected the status to expire")
+		}
+	})
+
+	t.Run("production", func(t *testing.T) {
+		app := newApp()
+		// Every context is production, including the unnamed one of the mock
+		app.kubeoptic.SetSafety(config.Safety{ProductionContexts: []string{"*"}})
+		app.Update(deletePod)
+		if view := app.View(); !strings.Contains(view, "(production)") || !strings.Contains(view, "to confirm:") {
+			t.Errorf("Expected the context name to be asked for:\n%s", view)
+		}
+		if !strings.Contains(app.renderStatusBar(), "PRODUCTION") {
+			t.Error("Expected the status bar to mark production")
+		}
+
+		// y is typed rather than confirming, and a wrong name confirms nothing
+		key(app, "y")
+		if _, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil || app.pendingAction == nil {
+			t.Fatal("Expected a mistyped context name not to confirm")
+		}
+		app.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+		if _, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd == nil || app.pendingAction != nil {
+			t.Error("Expected the context name to confirm")
+		}
+	})
+
+	t.Run("read_only", func(t *testing.T) {
+		app := newApp()
+		app.kubeoptic.SetReadOnly(true)
+		app.Update(deletePod)
+		if app.pendingAction != nil {
+			t.Fatal("Expected no confirmation in read-only mode")
+		}
+		if app.status == nil || app.status.Type != StatusWarning || !strings.Contains(app.status.Message, "Read-only") {
+			t.Errorf("Expected a read-only warning, got %+v", app.status)
+		}
+	})
+
+	t.Run("debug", func(t *testing.T) {
+		debug := ActionRequestedMsg{Action: ActionDebug, Namespace: "payments", Kind: services.KindPod, Name: "api-7d9f", Container: "api", Image: "busybox:1.36"}
+
+		app := newApp()
+		app.Update(debug)
+		view := app.View()
+		for _, want := range []string{"Debug pod api-7d9f with busybox:1.36?", "processes of container api", "stays in the pod's spec"} {
+			if !strings.Contains(view, want) {
+				t.Errorf("Expected %q in the confirmation:\n%s", want, view)
+			}
+		}
+
+		app = newApp()
+		app.kubeoptic.SetReadOnly(true)
+		app.Update(debug)
+		if app.pendingAction != nil || app.status == nil || !strings.Contains(app.status.Message, "debug is disabled") {
+			t.Errorf("Expected debugging refused in read-only mode, got %+v", app.status)
+		}
+	})
+
+	t.Run("edit", func(t *testing.T) {
+		app := newApp()
+		app.Update(ActionRequestedMsg{Action: ActionEdit, Namespace: "payments", Kind: services.KindDeployment, Name: "api", Manifest: "kind: Deployment\n"})
+		view := app.View()
+		for _, want := range []string{"Apply your changes to deployment api?", "as the diff shows"} {
+			if !strings.Contains(view, want) {
+				t.Errorf("Expected %q in the confirmation:\n%s", want, view)
+			}
+		}
+	})
+}
+
+func TestAppRolloutFollowing(t *testing.T) {
+	newApp := func() *App {
+		app := NewApp(createMockKubeoptic())
+		app.width = 100
+		app.height = 30
+		app.ready = true
+		return app
+	}
+	started := RolloutStartedMsg{Namespace: "payments", Kind: services.KindDeployment, Name: "api", Message: "Scaling deployment payments/api to 5"}
+	status := func(name string, rollout services.RolloutStatus) RolloutStatusMsg {
+		return RolloutStatusMsg{Namespace: "payments", Kind: services.KindDeployment, Name: name, Status: rollout}
+	}
+
+	t.Run("asks_first", func(t *testing.T) {
+		app := newApp()
+		app.Update(ActionRequestedMsg{Action: ActionScale, Namespace: "payments", Kind: services.KindDeployment, Name: "api", Replicas: 5})
+		if view := app.View(); !strings.Contains(view, "Scale deployment api to 5 replicas?") {
+			t.Errorf("Expected the scale to be confirmed:\n%s", view)
+		}
+		app.pendingAction = nil
+
+		app.Update(ActionRequestedMsg{Action: ActionRollback, Namespace: "payments", Kind: services.KindDeployment, Name: "api", Revision: 2})
+		if view := app.View(); !strings.Contains(view, "Roll back deployment api to revision 2?") {
+			t.Errorf("Expected the rollback to be confirmed:\n%s", view)
+		}
+	})
+
+	t.Run("progress_until_settled", func(t *testing.T) {
+		app := newApp()
+		if _, cmd := app.Update(started); cmd == nil || app.rollout == nil {
+			t.Fatal("Expected the rollout to be followed")
+		}
+		if !strings.Contains(app.renderStatusBar(), "Scaling deployment payments/api to 5") {
+			t.Error("Expected the action in the status bar")
+		}
+
+		// Ticks for other workloads are dropped
+		if _, cmd := app.Update(RolloutTickMsg{Namespace: "payments", Kind: services.KindDeployment, Name: "worker"}); cmd != nil {
+			t.Error("Expected no check of a rollout not followed")
+		}
+
+		app.Update(status("api", services.RolloutStatus{Message: "Waiting for rollout to finish: 2 of 5 updated replicas are available"}))
+		if app.rollout == nil || app.status == nil || app.status.Type != StatusInfo || !strings.Contains(app.status.Message, "2 of 5") {
+			t.Fatalf("Expected the progress in the status bar, got %+v", app.status)
+		}
+
+		app.Update(status("worker", services.RolloutStatus{Message: "worker done", Done: true}))
+		if app.rollout == nil || strings.Contains(app.status.Message, "worker") {
+			t.Error("Expected another workload's rollout to be ignored")
+		}
+
+		app.Update(status("api", services.RolloutStatus{Message: `deployment "api" successfully rolled out`, Done: true}))
+		if app.rollout != nil {
+			t.Error("Expected the rollout no longer followed once settled")
+		}
+		if app.status == nil || app.status.Type != StatusSuccess {
+			t.Errorf("Expected a success status, got %+v", app.status)
+		}
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		app := newApp()
+		app.Update(started)
+		app.Update(status("api", services.RolloutStatus{Message: `deployment "api" exceeded its progress deadline`, Done: true, Failed: true}))
+		if app.rollout != nil || app.status == nil || app.status.Type != StatusError {
+			t.Errorf("Expected an error status, got %+v", app.status)
+		}
+	})
+
+	t.Run("history_view", func(t *testing.T) {
+		app := newApp()
+		app.SetRolloutHistory(&mockComponentRenderer{})
+		app.Update(RolloutHistoryRequestedMsg{Workload: &services.Workload{Kind: services.KindDeployment, Namespace: "payments", Name: "api"}})
+		if app.viewMode != RolloutFullScreen || app.focusedPanel != RolloutPanel {
+			t.Fatalf("Expected the revision history full screen, got mode %v panel %v", app.viewMode, app.focusedPanel)
+		}
+
+		app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+		if app.viewMode != ThreePanelView {
+			t.Error("Expected f to leave the revision history")
+		}
+	})
+}
+
+func TestAppFileBrowser(t *testing.T) {
+	app := NewApp(createMockKubeoptic())
+	app.width = 100
+	app.height = 30
+	app.ready = true
+	app.SetFileBrowser(&mockComponentRenderer{})
+
+	pod := &services.Pod{Name: "api-7d9f", Namespace: "payments", Containers: []string{"api"}}
+	app.Update(FilesRequestedMsg{Pod: pod, Container: "api", Path: "/"})
+	if app.viewMode != FilesFullScreen || app.focusedPanel != FilesPanel {
+		t.Fatalf("Expected the file browser full screen, got mode %v panel %v", app.viewMode, app.focusedPanel)
+	}
+
+	// A download that ended without an error
+	download := &services.Download{DownloadRequest: services.DownloadRequest{Path: "/tmp/heap.hprof", Local: "heap.hprof"}}
+	app.Update(DownloadEndedMsg{Download: download})
+	if app.status == nil || app.status.Type != StatusSuccess || !strings.Contains(app.status.Message, "Downloaded /tmp/heap.hprof to heap.hprof") {
+		t.Errorf("Expected a success status, got %+v", app.status)
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	if app.viewMode != ThreePanelView || app.focusedPanel != PodPanel {
+		t.Error("Expected f to return to the pods")
+	}
+}
+
+func TestAppEdit(t *testing.T) {
+	newApp := func() *App {
+		app := NewApp(createMockKubeoptic())
+		app.width = 100
+		app.height = 30
+		app.ready = true
+		app.SetEditReview(&mockComponentRenderer{})
+		return app
+	}
+	edit := &services.ManifestEdit{Kind: services.KindDeployment, Namespace: "payments", Name: "api", Original: "replicas: 2\n", Edited: "replicas: 3\n"}
+
+	t.Run("read_only", func(t *testing.T) {
+		app := newApp()
+		app.kubeoptic.SetReadOnly(true)
+		_, cmd := app.Update(EditRequestedMsg{Kind: services.KindDeployment, Namespace: "payments", Name: "api"})
+		if app.status == nil || !strings.Contains(app.status.Message, "edit is disabled") {
+			t.Errorf("Expected editing refused in read-only mode, got %+v", app.status)
+		}
+		if cmd == nil {
+			t.Error("Expected the status to expire")
+		}
+	})
+
+	t.Run("nothing_changed", func(t *testing.T) {
+		app := newApp()
+		unchanged := *edit
+		unchanged.Edited = unchanged.Original
+		app.Update(EditorClosedMsg{Edit: &unchanged})
+		if app.viewMode != ThreePanelView || app.status == nil || !strings.Contains(app.status.Message, "nothing changed") {
+			t.Errorf("Expected an unchanged edit dropped, got mode %v status %+v", app.viewMode, app.status)
+		}
+	})
+
+	t.Run("review_and_apply", func(t *testing.T) {
+		app := newApp()
+		app.Update(EditorClosedMsg{Edit: edit})
+		if app.viewMode != EditFullScreen || app.focusedPanel != EditPanel {
+			t.Fatalf("Expected the review full screen, got mode %v panel %v", app.viewMode, app.focusedPanel)
+		}
+
+		// A failure keeps the review open to show it
+		app.Update(EditAppliedMsg{Edit: edit, Error: errors.New("the object has been modified")})
+		if app.viewMode != EditFullScreen {
+			t.Error("Expected a failed edit to stay in review")
+		}
+
+		app.Update(EditAppliedMsg{Edit: edit})
+		if app.viewMode == EditFullScreen {
+			t.Error("Expected a saved edit to leave the review")
+		}
+		if app.status == nil || app.status.Type != StatusSuccess || app.status.Message != "Edited deployment payments/api" {
+			t.Errorf("Expected a success status, got %+v", app.status)
+		}
+	})
+
+	t.Run("editor_error", func(t *testing.T) {
+		app := newApp()
+		app.Update(EditorClosedMsg{Edit: edit, Error: errors.New("editor failed: exit status 1")})
+		if app.err == nil || app.viewMode == EditFullScreen {
+			t.Errorf("Expected the editor's failure reported, got %v", app.err)
+		}
+	})
+}
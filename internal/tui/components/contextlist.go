@@ -91,6 +91,12 @@ func (cl ContextList) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 		}
+
+	case messages.KubeconfigReloadedMsg:
+		if msg.Error == nil {
+			cmd := cl.SetContexts(msg.Contexts, msg.CurrentContext)
+			return tea.Model(cl), cmd
+		}
 	}
 
 	var cmd tea.Cmd
@@ -113,6 +119,35 @@ func (cl ContextList) SelectedContext() *services.Context {
 	return nil
 }
 
+// SetContexts replaces the listed contexts, keeping the cursor on the same
+// context name when it still exists
+func (cl *ContextList) SetContexts(contexts []services.Context, currentContext string) tea.Cmd {
+	var selectedName string
+	if selected := cl.SelectedContext(); selected != nil {
+		selectedName = selected.Name
+	}
+
+	items := make([]list.Item, len(contexts))
+	selectedIndex := -1
+	for i, ctx := range contexts {
+		items[i] = ContextItem{
+			context:   ctx,
+			isCurrent: ctx.Name == currentContext,
+		}
+		if ctx.Name == selectedName {
+			selectedIndex = i
+		}
+	}
+
+	cl.contexts = contexts
+	cl.current = currentContext
+	cmd := cl.list.SetItems(items)
+	if selectedIndex >= 0 {
+		cl.list.Select(selectedIndex)
+	}
+	return cmd
+}
+
 // SetSize sets the dimensions of the context list
 func (cl *ContextList) SetSize(width, height int) {
 	cl.list.SetSize(width, height)
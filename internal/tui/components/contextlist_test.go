@@ -1,6 +1,7 @@
 package components
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 
@@ -98,7 +99,7 @@ func TestContextListUpdate(t *testing.T) {
 	}
 
 	// Verify the component is returned
-	if updatedCl.contexts == nil {
+	if updatedCl.(ContextList).contexts == nil {
 		t.Error("updated component should maintain context data")
 	}
 }
@@ -230,3 +231,41 @@ func TestContextListNonExistentCurrentContext(t *testing.T) {
 		t.Errorf("expected current context 'nonexistent', got '%s'", cl.current)
 	}
 }
+
+func TestContextListKubeconfigReload(t *testing.T) {
+	contexts := []services.Context{
+		{Name: "dev"},
+		{Name: "staging"},
+	}
+	cl := NewContextList(contexts, "dev")
+
+	// Move the cursor to "staging"
+	model, _ := cl.Update(tea.KeyMsg{Type: tea.KeyDown})
+	cl = model.(ContextList)
+	if selected := cl.SelectedContext(); selected == nil || selected.Name != "staging" {
+		t.Fatalf("expected cursor on 'staging', got %v", selected)
+	}
+
+	// A new context sorted before the selected one must not move the cursor
+	reloaded := []services.Context{
+		{Name: "dev"},
+		{Name: "prod"},
+		{Name: "staging"},
+	}
+	model, _ = cl.Update(tui.KubeconfigReloadedMsg{Contexts: reloaded, CurrentContext: "dev"})
+	cl = model.(ContextList)
+
+	if len(cl.contexts) != 3 {
+		t.Errorf("expected 3 contexts after reload, got %d", len(cl.contexts))
+	}
+	if selected := cl.SelectedContext(); selected == nil || selected.Name != "staging" {
+		t.Errorf("expected cursor to stay on 'staging', got %v", selected)
+	}
+
+	// A failed reload keeps the existing contexts
+	model, _ = cl.Update(tui.KubeconfigReloadedMsg{Error: fmt.Errorf("parse error")})
+	cl = model.(ContextList)
+	if len(cl.contexts) != 3 {
+		t.Errorf("failed reload should keep contexts, got %d", len(cl.contexts))
+	}
+}
@@ -0,0 +1,104 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"kubeoptic/internal/services"
+	"kubeoptic/internal/tui"
+)
+
+// debugPicker chooses the container of a pod a debug container targets,
+// whose processes it sees, and the image it runs. The first container and
+// the configured image are chosen until changed; the entry after the
+// containers targets none, sharing only the pod's network.
+type debugPicker struct {
+	pod    services.Pod
+	cursor int
+	input  textinput.Model
+}
+
+func newDebugPicker(pod services.Pod, image string) *debugPicker {
+	input := textinput.New()
+	input.Prompt = "Image: "
+	input.CharLimit = 256
+	input.SetValue(image)
+	input.CursorEnd()
+	input.Focus()
+
+	return &debugPicker{pod: pod, input: input}
+}
+
+// handleKey moves through the containers and edits the image. It reports
+// whether the picker is done, with the command asking to debug the pod if
+// it was chosen to.
+func (d *debugPicker) handleKey(msg tea.KeyMsg) (bool, tea.Cmd) {
+	switch msg.String() {
+	case "up":
+		if d.cursor > 0 {
+			d.cursor--
+		}
+		return false, nil
+	case "down":
+		if d.cursor < len(d.pod.Containers) {
+			d.cursor++
+		}
+		return false, nil
+	case "enter":
+		image := strings.TrimSpace(d.input.Value())
+		if image == "" {
+			return false, nil
+		}
+		request := tui.ActionRequestedMsg{
+			Action:    tui.ActionDebug,
+			Namespace: d.pod.Namespace,
+			Kind:      services.KindPod,
+			Name:      d.pod.Name,
+			Container: d.target(),
+			Image:     image,
+		}
+		return true, func() tea.Msg { return request }
+	case "esc":
+		return true, nil
+	}
+
+	var cmd tea.Cmd
+	d.input, cmd = d.input.Update(msg)
+	return false, cmd
+}
+
+// target returns the chosen container, or none for the entry after them
+func (d *debugPicker) target() string {
+	if d.cursor < len(d.pod.Containers) {
+		return d.pod.Containers[d.cursor]
+	}
+	return ""
+}
+
+// view renders the containers to target with the image below them
+func (d *debugPicker) view(width int, styles podDelegateStyles) string {
+	titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("86")).Bold(true)
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	lines := []string{titleStyle.Render("Debug container in " + d.pod.Name), "", mutedStyle.Render("Processes of:")}
+	targets := append(append([]string{}, d.pod.Containers...), "(no container, network only)")
+	for i, target := range targets {
+		if i == d.cursor {
+			lines = append(lines, styles.selected.Render("> "+target))
+		} else {
+			lines = append(lines, styles.normal.Render("  "+target))
+		}
+	}
+
+	lines = append(lines, "", d.input.View(), "",
+		mutedStyle.Render("enter: start and attach • ↑/↓: target container • esc: cancel"))
+
+	return lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("86")).
+		Width(max(width-2, 0)).
+		Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
@@ -0,0 +1,287 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"kubeoptic/internal/services"
+	"kubeoptic/internal/tui"
+	"kubeoptic/internal/tui/styles"
+)
+
+// editDiffContext is how many unchanged lines are shown around a change
+const editDiffContext = 3
+
+// EditReview shows what an edit made in the user's editor changes, as a
+// diff of the resource against a server-side dry run of the edit. The edit
+// can be applied, once confirmed, or opened in the editor again; errors of
+// the dry run or of applying it, such as failed validation or an admission
+// webhook's denial, are shown as the server words them.
+type EditReview struct {
+	viewport viewport.Model
+	keyMap   LogViewerKeyMap
+
+	edit     *services.ManifestEdit
+	checked  bool
+	checking bool
+	err      error
+
+	focused bool
+	width   int
+	height  int
+
+	theme  styles.Theme
+	styles editReviewStyles
+}
+
+type editReviewStyles struct {
+	title   lipgloss.Style
+	muted   lipgloss.Style
+	normal  lipgloss.Style
+	added   lipgloss.Style
+	removed lipgloss.Style
+}
+
+// NewEditReview creates a new edit review
+func NewEditReview(width, height int) *EditReview {
+	theme := styles.DefaultTheme()
+	return &EditReview{
+		viewport: viewport.New(width, max(height-2, 0)),
+		keyMap:   DefaultLogViewerKeyMap(),
+		width:    width,
+		height:   height,
+		theme:    theme,
+		styles: editReviewStyles{
+			title:   lipgloss.NewStyle().Bold(true).Foreground(theme.Primary).Padding(0, 1),
+			muted:   lipgloss.NewStyle().Foreground(lipgloss.Color("240")),
+			normal:  lipgloss.NewStyle().Foreground(lipgloss.Color("252")),
+			added:   lipgloss.NewStyle().Foreground(lipgloss.Color("114")),
+			removed: lipgloss.NewStyle().Foreground(lipgloss.Color("196")),
+		},
+	}
+}
+
+// Init implements tea.Model interface
+func (er *EditReview) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model interface
+func (er *EditReview) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		er.SetSize(msg.Width, msg.Height)
+		return er, nil
+
+	case tea.KeyMsg:
+		return er, er.handleKeyPress(msg)
+
+	case tui.EditorClosedMsg:
+		// A saved edit is checked again before anything else is shown
+		if msg.Error == nil && msg.Edit != nil {
+			er.edit = msg.Edit
+			er.checked = false
+			er.err = nil
+			er.viewport.SetContent("")
+			er.viewport.GotoTop()
+		}
+		return er, nil
+
+	case tui.EditCheckedMsg:
+		er.edit = msg.Edit
+		er.checked = msg.Error == nil
+		er.err = msg.Error
+		er.render()
+		return er, nil
+
+	case tui.EditAppliedMsg:
+		// A failure to save, such as a conflict, is shown over the diff
+		if msg.Error != nil {
+			er.err = msg.Error
+		}
+		return er, nil
+
+	case tui.LoadingStartedMsg:
+		if msg.Component == tui.LoadingEdit {
+			er.checking = true
+		}
+		return er, nil
+
+	case tui.LoadingCompletedMsg:
+		if msg.Component == tui.LoadingEdit {
+			er.checking = false
+		}
+		return er, nil
+	}
+
+	return er, nil
+}
+
+func (er *EditReview) handleKeyPress(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, er.keyMap.Up):
+		er.viewport.ScrollUp(1)
+	case key.Matches(msg, er.keyMap.Down):
+		er.viewport.ScrollDown(1)
+	case key.Matches(msg, er.keyMap.PageUp):
+		er.viewport.PageUp()
+	case key.Matches(msg, er.keyMap.PageDown):
+		er.viewport.PageDown()
+	case key.Matches(msg, er.keyMap.Home):
+		er.viewport.GotoTop()
+	case key.Matches(msg, er.keyMap.End):
+		er.viewport.GotoBottom()
+
+	case msg.String() == "a":
+		// Only a change the dry run accepted can be applied
+		if er.edit == nil || !er.checked || er.err != nil || !er.edit.Changed() {
+			return nil
+		}
+		edit := er.edit
+		return func() tea.Msg {
+			return tui.ActionRequestedMsg{
+				Action:    tui.ActionEdit,
+				Namespace: edit.Namespace,
+				Kind:      edit.Kind,
+				Name:      edit.Name,
+				Manifest:  edit.Edited,
+			}
+		}
+
+	case msg.String() == "e":
+		if er.edit == nil {
+			return nil
+		}
+		edit := *er.edit
+		return func() tea.Msg { return tui.EditorRequestedMsg{Edit: &edit} }
+	}
+	return nil
+}
+
+// render puts the changed lines of the diff into the viewport, with a few
+// unchanged lines around each change and a marker where lines are skipped
+func (er *EditReview) render() {
+	if er.edit == nil || er.err != nil {
+		er.viewport.SetContent("")
+		return
+	}
+
+	diff := er.edit.Diff
+	shown := make([]bool, len(diff))
+	for i, line := range diff {
+		if line.Op == services.DiffEqual {
+			continue
+		}
+		for j := max(i-editDiffContext, 0); j <= min(i+editDiffContext, len(diff)-1); j++ {
+			shown[j] = true
+		}
+	}
+
+	var lines []string
+	skipped := false
+	for i, line := range diff {
+		if !shown[i] {
+			skipped = true
+			continue
+		}
+		if skipped && len(lines) > 0 {
+			lines = append(lines, er.styles.muted.Render("  ⋯"))
+		}
+		skipped = false
+
+		switch line.Op {
+		case services.DiffInsert:
+			lines = append(lines, er.styles.added.Render("+ "+line.Text))
+		case services.DiffDelete:
+			lines = append(lines, er.styles.removed.Render("- "+line.Text))
+		default:
+			lines = append(lines, er.styles.normal.Render("  "+line.Text))
+		}
+	}
+	er.viewport.SetContent(strings.Join(lines, "\n"))
+}
+
+// View implements tea.Model interface
+func (er *EditReview) View() string {
+	title := "Edit"
+	if er.edit != nil {
+		title += ": " + strings.ToLower(er.edit.Kind) + " " + er.edit.Namespace + "/" + er.edit.Name
+	}
+	if er.checking {
+		title += " (dry run...)"
+	}
+
+	body := er.viewport.View()
+	hints := []string{"e edit again", "esc discard"}
+	switch {
+	case er.err != nil:
+		body = styles.ErrorMessageStyles(er.theme, er.width).Render(fmt.Sprintf("The server rejected the change:\n\n%v", er.err))
+	case er.edit == nil || !er.checked:
+		body = er.styles.muted.Render("Checking the change with a server-side dry run...")
+	case !er.edit.Changed():
+		body = er.styles.muted.Render("The dry run leaves the resource as it is: nothing to apply")
+	default:
+		added, removed := er.counts()
+		hints = append([]string{
+			fmt.Sprintf("%3.0f%%", er.viewport.ScrollPercent()*100),
+			er.styles.added.Render(fmt.Sprintf("+%d", added)) + er.styles.muted.Render(" ") + er.styles.removed.Render(fmt.Sprintf("-%d", removed)),
+			"a apply",
+		}, hints...)
+	}
+	footer := er.styles.muted.Render(strings.Join(hints, " • "))
+
+	return lipgloss.JoinVertical(lipgloss.Left, er.styles.title.Render(title), body, footer)
+}
+
+// counts returns how many lines the edit adds and removes
+func (er *EditReview) counts() (added, removed int) {
+	for _, line := range er.edit.Diff {
+		switch line.Op {
+		case services.DiffInsert:
+			added++
+		case services.DiffDelete:
+			removed++
+		}
+	}
+	return added, removed
+}
+
+// Edit returns the edit under review, nil before one is made
+func (er *EditReview) Edit() *services.ManifestEdit {
+	return er.edit
+}
+
+// Focus sets the component as focused
+func (er *EditReview) Focus() tea.Cmd {
+	er.focused = true
+	return nil
+}
+
+// Blur removes focus from the component
+func (er *EditReview) Blur() tea.Cmd {
+	er.focused = false
+	return nil
+}
+
+// IsFocused returns whether the component is focused
+func (er *EditReview) IsFocused() bool {
+	return er.focused
+}
+
+// SetSize updates the component size
+func (er *EditReview) SetSize(width, height int) {
+	er.width = width
+	er.height = height
+	er.viewport.Width = width
+	er.viewport.Height = max(height-2, 0)
+}
+
+// GetSize returns the current component size
+func (er *EditReview) GetSize() (int, int) {
+	return er.width, er.height
+}
@@ -0,0 +1,114 @@
+package components
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"kubeoptic/internal/services"
+	"kubeoptic/internal/tui"
+)
+
+func TestEditReview(t *testing.T) {
+	original := "metadata:\n  name: api\nspec:\n  replicas: 2\n  a: 1\n  b: 2\n  c: 3\n  d: 4\n  e: 5\n  f: 6\n  g: 7\n  image: api:1.0\n"
+	edited := strings.Replace(strings.Replace(original, "replicas: 2", "replicas: 3", 1), "api:1.0", "api:1.1", 1)
+	newEdit := func() *services.ManifestEdit {
+		return &services.ManifestEdit{Kind: services.KindDeployment, Namespace: "payments", Name: "api", Original: original, Edited: edited}
+	}
+	checked := func() *services.ManifestEdit {
+		edit := newEdit()
+		edit.Result = edit.Edited
+		edit.Diff = services.DiffLines(edit.Original, edit.Result)
+		return edit
+	}
+
+	newReview := func() *EditReview {
+		review := NewEditReview(100, 30)
+		review.Update(tui.EditorClosedMsg{Edit: newEdit()})
+		review.Update(tui.EditCheckedMsg{Edit: checked()})
+		return review
+	}
+	key := func(review *EditReview, k string) tea.Cmd {
+		_, cmd := review.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+		return cmd
+	}
+
+	t.Run("checking", func(t *testing.T) {
+		review := NewEditReview(100, 30)
+		review.Update(tui.EditorClosedMsg{Edit: newEdit()})
+		if view := review.View(); !strings.Contains(view, "dry run") {
+			t.Errorf("Expected the dry run to be waited for:\n%s", view)
+		}
+		if cmd := key(review, "a"); cmd != nil {
+			t.Error("Expected an unchecked edit not to be applied")
+		}
+	})
+
+	t.Run("diff", func(t *testing.T) {
+		view := newReview().View()
+		for _, want := range []string{"Edit: deployment payments/api", "-   replicas: 2", "+   replicas: 3", "+   image: api:1.1", "+2 -2", "a apply"} {
+			if !strings.Contains(view, want) {
+				t.Errorf("Expected %q in the view:\n%s", want, view)
+			}
+		}
+		// Lines far from any change are left out
+		if !strings.Contains(view, "⋯") || strings.Contains(view, "  d: 4") {
+			t.Errorf("Expected only the lines around changes:\n%s", view)
+		}
+	})
+
+	t.Run("apply", func(t *testing.T) {
+		cmd := key(newReview(), "a")
+		if cmd == nil {
+			t.Fatal("Expected a to ask to apply the edit")
+		}
+		want := tui.ActionRequestedMsg{Action: tui.ActionEdit, Namespace: "payments", Kind: services.KindDeployment, Name: "api", Manifest: edited}
+		if got := cmd(); got != want {
+			t.Errorf("Expected %+v, got %+v", want, got)
+		}
+	})
+
+	t.Run("no_change", func(t *testing.T) {
+		review := NewEditReview(100, 30)
+		edit := newEdit()
+		edit.Result = original
+		edit.Diff = services.DiffLines(original, original)
+		review.Update(tui.EditCheckedMsg{Edit: edit})
+		if view := review.View(); !strings.Contains(view, "nothing to apply") {
+			t.Errorf("Expected an edit without effect to say so:\n%s", view)
+		}
+		if cmd := key(review, "a"); cmd != nil {
+			t.Error("Expected an edit without effect not to be applied")
+		}
+	})
+
+	t.Run("edit_again", func(t *testing.T) {
+		cmd := key(newReview(), "e")
+		if cmd == nil {
+			t.Fatal("Expected e to open the editor again")
+		}
+		msg, ok := cmd().(tui.EditorRequestedMsg)
+		if !ok || msg.Edit == nil || msg.Edit.Edited != edited {
+			t.Errorf("Expected the edit to reopen as saved, got %+v", msg)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		review := NewEditReview(100, 30)
+		review.Update(tui.EditCheckedMsg{Edit: newEdit(), Error: errors.New(`admission webhook "policy.example.com" denied the request: replicas above 2`)})
+		if view := review.View(); !strings.Contains(view, "denied the request") {
+			t.Errorf("Expected the server's error in the view:\n%s", view)
+		}
+		if cmd := key(review, "a"); cmd != nil {
+			t.Error("Expected a rejected edit not to be applied")
+		}
+
+		review = newReview()
+		review.Update(tui.EditAppliedMsg{Edit: newEdit(), Error: errors.New("the object has been modified")})
+		if view := review.View(); !strings.Contains(view, "has been modified") {
+			t.Errorf("Expected the failure to save in the view:\n%s", view)
+		}
+	})
+}
@@ -0,0 +1,363 @@
+package components
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"k8s.io/apimachinery/pkg/util/duration"
+
+	"kubeoptic/internal/services"
+	"kubeoptic/internal/tui"
+)
+
+const (
+	// eventListHeaderHeight is the number of lines the title and column
+	// headings take
+	eventListHeaderHeight = 2
+
+	// eventDetailHeight is the number of lines showing the selected event's
+	// full message
+	eventDetailHeight = 3
+
+	eventLastSeenWidth = 12
+	eventTypeWidth     = 8
+	eventReasonWidth   = 20
+	eventObjectWidth   = 30
+	eventMessageMin    = 20
+)
+
+// eventListItem is a row of the events panel
+type eventListItem struct {
+	event services.Event
+}
+
+// FilterValue implements list.Item interface for filtering
+func (e eventListItem) FilterValue() string {
+	return e.event.Reason + " " + e.event.Object.Name + " " + e.event.Message
+}
+
+// EventList shows the events of a namespace or of one pod, newest first,
+// with Warning events highlighted
+type EventList struct {
+	list      list.Model
+	delegate  *eventDelegate
+	events    []services.Event
+	namespace string
+
+	// pod limits the list to one pod's events; allPods shows the whole
+	// namespace even when a pod was chosen
+	pod     string
+	allPods bool
+
+	focused bool
+	width   int
+	height  int
+}
+
+// NewEventList creates a new events panel
+func NewEventList(width, height int) *EventList {
+	delegate := newEventDelegate()
+
+	l := list.New([]list.Item{}, delegate, width, max(height-eventListHeaderHeight-eventDetailHeight, 0))
+	l.Title = "Events"
+	l.SetShowTitle(false)
+	l.SetShowStatusBar(true)
+	l.SetFilteringEnabled(true)
+	l.SetShowHelp(false)
+	l.SetStatusBarItemName("event", "events")
+
+	return &EventList{
+		list:     l,
+		delegate: delegate,
+		width:    width,
+		height:   height,
+	}
+}
+
+// Init implements tea.Model interface
+func (el *EventList) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model interface
+func (el *EventList) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		el.SetSize(msg.Width, msg.Height)
+		return el, nil
+
+	case tea.KeyMsg:
+		if el.list.FilterState() == list.Filtering {
+			break
+		}
+		if msg.String() == "a" && el.pod != "" {
+			el.allPods = !el.allPods
+			el.list.ResetSelected()
+			el.refresh()
+			return el, nil
+		}
+
+	case tui.EventsRequestedMsg:
+		el.pod = ""
+		if msg.Pod != nil {
+			el.pod = msg.Pod.Name
+		}
+		el.allPods = false
+		el.list.ResetFilter()
+		el.list.ResetSelected()
+		el.refresh()
+		return el, nil
+
+	case tui.EventsLoadedMsg:
+		if msg.Error != nil {
+			return el, nil
+		}
+		if msg.Namespace != el.namespace {
+			el.list.ResetFilter()
+			el.list.ResetSelected()
+		}
+		el.namespace = msg.Namespace
+		el.events = msg.Events
+		el.refresh()
+		return el, nil
+
+	case tui.LoadingStartedMsg:
+		if msg.Component == tui.LoadingEvents {
+			return el, el.list.StartSpinner()
+		}
+		return el, nil
+
+	case tui.LoadingCompletedMsg:
+		if msg.Component == tui.LoadingEvents {
+			el.list.StopSpinner()
+		}
+		return el, nil
+	}
+
+	var cmd tea.Cmd
+	el.list, cmd = el.list.Update(msg)
+	return el, cmd
+}
+
+// refresh lists the events in scope, newest first
+func (el *EventList) refresh() {
+	el.delegate.showObject = el.podScoped() == ""
+
+	var items []list.Item
+	for i := len(el.events) - 1; i >= 0; i-- {
+		event := el.events[i]
+		if pod := el.podScoped(); pod != "" && !event.IsAbout("Pod", pod) {
+			continue
+		}
+		items = append(items, eventListItem{event: event})
+	}
+	replaceItems(&el.list, items, func(item list.Item) string {
+		if e, ok := item.(eventListItem); ok {
+			return e.event.UID
+		}
+		return ""
+	})
+}
+
+// podScoped returns the pod whose events are shown, or "" for the namespace
+func (el *EventList) podScoped() string {
+	if el.allPods {
+		return ""
+	}
+	return el.pod
+}
+
+// SelectedEvent returns the highlighted event
+func (el *EventList) SelectedEvent() *services.Event {
+	if item, ok := el.list.SelectedItem().(eventListItem); ok {
+		return &item.event
+	}
+	return nil
+}
+
+// View implements tea.Model interface
+func (el *EventList) View() string {
+	titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("86")).Bold(true)
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	title := "Events: " + el.namespace
+	if pod := el.podScoped(); pod != "" {
+		title = "Events: pod/" + pod
+	}
+	warnings := 0
+	for _, item := range el.list.Items() {
+		if item.(eventListItem).event.IsWarning() {
+			warnings++
+		}
+	}
+	header := titleStyle.Render(title)
+	if warnings > 0 {
+		header += " " + el.delegate.styles.warning.Render(fmt.Sprintf("%d warnings", warnings))
+	}
+	if el.pod != "" {
+		scope := "a: all pods"
+		if el.allPods {
+			scope = "a: pod/" + el.pod + " only"
+		}
+		header += hintStyle.Render("  " + scope)
+	}
+
+	messageWidth, objectWidth := eventTableLayout(el.list.Width(), el.delegate.showObject)
+	columns := fitCell("  LAST SEEN", eventLastSeenWidth+2) + " " + fitCell("TYPE", eventTypeWidth) + " " + fitCell("REASON", eventReasonWidth)
+	if objectWidth > 0 {
+		columns += " " + fitCell("OBJECT", objectWidth)
+	}
+	columns += " " + fitCell("MESSAGE", messageWidth)
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		header,
+		hintStyle.Bold(true).Render(columns),
+		el.list.View(),
+		el.renderDetail(),
+	)
+}
+
+// renderDetail shows the full message of the selected event, which the
+// table truncates
+func (el *EventList) renderDetail() string {
+	event := el.SelectedEvent()
+	if event == nil {
+		return strings.Repeat("\n", eventDetailHeight-1)
+	}
+
+	text := fmt.Sprintf("%s/%s: %s", event.Object.Kind, event.Object.Name, event.Message)
+	if event.Source != "" {
+		text += " (from " + event.Source + ")"
+	}
+	style := lipgloss.NewStyle().Foreground(lipgloss.Color("252")).Width(max(el.width, 1))
+	lines := strings.Split(style.Render(text), "\n")
+	if len(lines) > eventDetailHeight {
+		lines = lines[:eventDetailHeight]
+	}
+	for len(lines) < eventDetailHeight {
+		lines = append(lines, "")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Focus sets the component as focused
+func (el *EventList) Focus() tea.Cmd {
+	el.focused = true
+	return nil
+}
+
+// Blur removes focus from the component
+func (el *EventList) Blur() tea.Cmd {
+	el.focused = false
+	return nil
+}
+
+// IsFocused returns whether the component is focused
+func (el *EventList) IsFocused() bool {
+	return el.focused
+}
+
+// CapturingInput reports whether keys are being typed into the filter
+func (el *EventList) CapturingInput() bool {
+	return el.list.FilterState() == list.Filtering
+}
+
+// SetSize updates the component size
+func (el *EventList) SetSize(width, height int) {
+	el.width = width
+	el.height = height
+	el.list.SetSize(width, max(height-eventListHeaderHeight-eventDetailHeight, 0))
+}
+
+// GetSize returns the current component size
+func (el *EventList) GetSize() (int, int) {
+	return el.width, el.height
+}
+
+// eventTableLayout returns the widths of the MESSAGE and OBJECT columns; the
+// OBJECT column is dropped when it isn't wanted or doesn't fit
+func eventTableLayout(width int, showObject bool) (messageWidth, objectWidth int) {
+	fixed := eventLastSeenWidth + 2 + 1 + eventTypeWidth + 1 + eventReasonWidth + 1
+	if showObject && width-fixed-eventObjectWidth-1 >= eventMessageMin {
+		objectWidth = eventObjectWidth
+		fixed += eventObjectWidth + 1
+	}
+	return max(width-fixed, 1), objectWidth
+}
+
+// eventAge formats when an event was last seen, with its repeat count
+func eventAge(event services.Event, now time.Time) string {
+	age := "<unknown>"
+	if !event.LastSeen.IsZero() {
+		age = duration.HumanDuration(now.Sub(event.LastSeen))
+	}
+	if event.Count > 1 {
+		age += fmt.Sprintf(" (x%d)", event.Count)
+	}
+	return age
+}
+
+// eventDelegate renders events as table rows, highlighting warnings
+type eventDelegate struct {
+	styles     eventDelegateStyles
+	showObject bool
+}
+
+type eventDelegateStyles struct {
+	normal   lipgloss.Style
+	selected lipgloss.Style
+	muted    lipgloss.Style
+	warning  lipgloss.Style
+}
+
+func newEventDelegate() *eventDelegate {
+	return &eventDelegate{
+		styles: eventDelegateStyles{
+			normal:   lipgloss.NewStyle().Foreground(lipgloss.Color("252")),
+			selected: lipgloss.NewStyle().Foreground(lipgloss.Color("86")).Bold(true),
+			muted:    lipgloss.NewStyle().Foreground(lipgloss.Color("240")),
+			warning:  lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true), // Red
+		},
+		showObject: true,
+	}
+}
+
+func (d *eventDelegate) Height() int  { return 1 }
+func (d *eventDelegate) Spacing() int { return 0 }
+
+func (d *eventDelegate) Update(msg tea.Msg, m *list.Model) tea.Cmd {
+	return nil
+}
+
+func (d *eventDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	item, ok := listItem.(eventListItem)
+	if !ok {
+		return
+	}
+	event := item.event
+
+	ageStyle, typeStyle, reasonStyle, textStyle := d.styles.muted, d.styles.muted, d.styles.normal, d.styles.normal
+	if event.IsWarning() {
+		typeStyle, reasonStyle = d.styles.warning, d.styles.warning
+	}
+	if index == m.Index() {
+		ageStyle, typeStyle, reasonStyle, textStyle = d.styles.selected, d.styles.selected, d.styles.selected, d.styles.selected
+	}
+
+	messageWidth, objectWidth := eventTableLayout(m.Width(), d.showObject)
+	row := ageStyle.Render(fitCell("  "+eventAge(event, time.Now()), eventLastSeenWidth+2)) +
+		" " + typeStyle.Render(fitCell(event.Type, eventTypeWidth)) +
+		" " + reasonStyle.Render(fitCell(event.Reason, eventReasonWidth))
+	if objectWidth > 0 {
+		object := strings.ToLower(event.Object.Kind) + "/" + event.Object.Name
+		row += " " + textStyle.Render(fitCell(object, objectWidth))
+	}
+	message := strings.ReplaceAll(event.Message, "\n", " ")
+	row += " " + textStyle.Render(fitCell(message, messageWidth))
+	fmt.Fprint(w, row)
+}
@@ -0,0 +1,79 @@
+package components
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"kubeoptic/internal/services"
+	"kubeoptic/internal/tui"
+)
+
+func TestEventList(t *testing.T) {
+	now := time.Now()
+	events := []services.Event{
+		{UID: "1", Namespace: "payments", Type: services.EventNormal, Reason: "Scheduled", Message: "Successfully assigned payments/api-0",
+			Object: services.OwnerRef{Kind: "Pod", Name: "api-0"}, Count: 1, LastSeen: now.Add(-10 * time.Minute)},
+		{UID: "2", Namespace: "payments", Type: services.EventNormal, Reason: "ScalingReplicaSet", Message: "Scaled up replica set api-7d9f8 to 2",
+			Object: services.OwnerRef{Kind: "Deployment", Name: "api"}, Count: 1, LastSeen: now.Add(-5 * time.Minute)},
+		{UID: "3", Namespace: "payments", Type: services.EventWarning, Reason: "BackOff", Message: "Back-off restarting failed container",
+			Object: services.OwnerRef{Kind: "Pod", Name: "api-0"}, Count: 12, LastSeen: now.Add(-3 * time.Minute)},
+	}
+
+	newList := func() *EventList {
+		eventList := NewEventList(140, 20)
+		eventList.Update(tui.EventsLoadedMsg{Namespace: "payments", Events: events})
+		return eventList
+	}
+
+	t.Run("newest_first", func(t *testing.T) {
+		eventList := newList()
+		if got := eventList.SelectedEvent(); got == nil || got.Reason != "BackOff" {
+			t.Errorf("Expected the newest event first, got %+v", got)
+		}
+	})
+
+	t.Run("highlights_warnings", func(t *testing.T) {
+		view := newList().View()
+		for _, want := range []string{"Events: payments", "1 warnings", "BackOff", "3m (x12)", "deployment/api", "OBJECT"} {
+			if !strings.Contains(view, want) {
+				t.Errorf("Expected %q in the view", want)
+			}
+		}
+	})
+
+	t.Run("pod_scope", func(t *testing.T) {
+		eventList := newList()
+		eventList.Update(tui.EventsRequestedMsg{Pod: &services.Pod{Name: "api-0", Namespace: "payments"}})
+
+		if got := len(eventList.list.Items()); got != 2 {
+			t.Errorf("Expected the pod's 2 events, got %d", got)
+		}
+		if view := eventList.View(); !strings.Contains(view, "Events: pod/api-0") || strings.Contains(view, "OBJECT") {
+			t.Error("Expected a pod title and no OBJECT column")
+		}
+
+		eventList.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+		if got := len(eventList.list.Items()); got != 3 {
+			t.Errorf("Expected a to show all 3 namespace events, got %d", got)
+		}
+	})
+
+	t.Run("live_updates_keep_selection", func(t *testing.T) {
+		eventList := newList()
+		eventList.Update(tea.KeyMsg{Type: tea.KeyDown})
+
+		newer := services.Event{UID: "4", Namespace: "payments", Type: services.EventWarning, Reason: "Unhealthy",
+			Object: services.OwnerRef{Kind: "Pod", Name: "api-0"}, Count: 1, LastSeen: now}
+		eventList.Update(tui.EventsLoadedMsg{Namespace: "payments", Events: append(events, newer)})
+
+		if got := eventList.SelectedEvent(); got == nil || got.UID != "2" {
+			t.Errorf("Expected the selection to stay on event 2, got %+v", got)
+		}
+		if !strings.Contains(eventList.View(), "2 warnings") {
+			t.Error("Expected the new warning to be counted")
+		}
+	})
+}
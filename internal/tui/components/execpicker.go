@@ -0,0 +1,97 @@
+package components
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"kubeoptic/internal/services"
+	"kubeoptic/internal/tui"
+)
+
+// execShells are the shells the exec picker cycles through
+var execShells = []string{services.ShellAuto, services.ShellBash, services.ShellSh}
+
+// execPicker chooses the container of a pod to open a shell in, and the
+// shell to open. The first container and bash, falling back to sh, are
+// chosen until changed.
+type execPicker struct {
+	pod    services.Pod
+	cursor int
+	shell  int
+}
+
+func newExecPicker(pod services.Pod) *execPicker {
+	return &execPicker{pod: pod}
+}
+
+// handleKey moves through the containers and shells. It reports whether
+// the picker is done, with the command opening the shell if one was chosen.
+func (e *execPicker) handleKey(msg tea.KeyMsg) (bool, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if e.cursor > 0 {
+			e.cursor--
+		}
+	case "down", "j":
+		if e.cursor < len(e.pod.Containers)-1 {
+			e.cursor++
+		}
+	case "tab", "right", "l":
+		e.shell = (e.shell + 1) % len(execShells)
+	case "shift+tab", "left", "h":
+		e.shell = (e.shell - 1 + len(execShells)) % len(execShells)
+	case "enter":
+		pod := e.pod
+		container := e.container()
+		shell := execShells[e.shell]
+		return true, func() tea.Msg {
+			return tui.ExecRequestedMsg{Pod: &pod, Container: container, Shell: shell}
+		}
+	case "esc", "q":
+		return true, nil
+	}
+	return false, nil
+}
+
+// container returns the chosen container, or none to let the cluster pick
+// when the pod's containers aren't known
+func (e *execPicker) container() string {
+	if len(e.pod.Containers) == 0 {
+		return ""
+	}
+	return e.pod.Containers[e.cursor]
+}
+
+// view renders the containers with the chosen shell below them
+func (e *execPicker) view(width int, styles podDelegateStyles) string {
+	titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("86")).Bold(true)
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	lines := []string{titleStyle.Render("Shell in " + e.pod.Name), ""}
+	containers := e.pod.Containers
+	if len(containers) == 0 {
+		containers = []string{"(default container)"}
+	}
+	for i, container := range containers {
+		if i == e.cursor {
+			lines = append(lines, styles.selected.Render("> "+container))
+		} else {
+			lines = append(lines, styles.normal.Render("  "+container))
+		}
+	}
+
+	shell := execShells[e.shell]
+	if shell == services.ShellAuto {
+		shell = "bash, or sh without bash"
+	}
+	lines = append(lines, "", styles.normal.Render(fmt.Sprintf("Shell: %s", shell)), "",
+		mutedStyle.Render("enter: open shell • tab: change shell • esc: cancel"))
+
+	return lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("86")).
+		Width(max(width-2, 0)).
+		Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
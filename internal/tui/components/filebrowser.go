@@ -0,0 +1,531 @@
+package components
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"kubeoptic/internal/services"
+	"kubeoptic/internal/tui"
+	"kubeoptic/internal/tui/styles"
+)
+
+// fileBrowserChromeHeight is the number of lines around the file rows and
+// downloads: title, column headings and the footer, which the prompt for
+// where to save a download replaces
+const fileBrowserChromeHeight = 3
+
+// fileBrowserMaxDownloads is how many downloads are listed below the
+// files, the latest ones
+const fileBrowserMaxDownloads = 3
+
+// downloadBarWidth is the width of the progress bar of a file download
+const downloadBarWidth = 20
+
+// FileBrowser browses the filesystem of a container of a pod and downloads
+// files and directories out of it, showing their progress
+type FileBrowser struct {
+	pod       services.Pod
+	container string
+	dir       string
+	files     []services.RemoteFile
+	cursor    int
+	offset    int
+	loading   bool
+	err       error
+
+	// Entry to highlight once the directory is listed, such as the
+	// directory just left
+	reselect string
+
+	// Local path typed for the entry being downloaded
+	saving      *services.RemoteFile
+	savingPath  string
+	targetInput textinput.Model
+
+	downloads []*services.Download
+
+	focused bool
+	width   int
+	height  int
+
+	theme  styles.Theme
+	styles fileBrowserStyles
+}
+
+type fileBrowserStyles struct {
+	title    lipgloss.Style
+	header   lipgloss.Style
+	muted    lipgloss.Style
+	normal   lipgloss.Style
+	dir      lipgloss.Style
+	link     lipgloss.Style
+	selected lipgloss.Style
+	done     lipgloss.Style
+	failed   lipgloss.Style
+	progress lipgloss.Style
+}
+
+// NewFileBrowser creates a new file browser
+func NewFileBrowser(width, height int) *FileBrowser {
+	theme := styles.DefaultTheme()
+
+	targetInput := textinput.New()
+	targetInput.Prompt = "Save to: "
+	targetInput.CharLimit = 1024
+
+	return &FileBrowser{
+		dir:         "/",
+		targetInput: targetInput,
+		width:       width,
+		height:      height,
+		theme:       theme,
+		styles: fileBrowserStyles{
+			title:    lipgloss.NewStyle().Bold(true).Foreground(theme.Primary).Padding(0, 1),
+			header:   lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Bold(true),
+			muted:    lipgloss.NewStyle().Foreground(lipgloss.Color("240")),
+			normal:   lipgloss.NewStyle().Foreground(lipgloss.Color("252")),
+			dir:      lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Bold(true),
+			link:     lipgloss.NewStyle().Foreground(lipgloss.Color("170")),
+			selected: lipgloss.NewStyle().Background(lipgloss.Color("62")).Foreground(lipgloss.Color("230")),
+			done:     lipgloss.NewStyle().Foreground(theme.Success),
+			failed:   lipgloss.NewStyle().Foreground(theme.Error),
+			progress: lipgloss.NewStyle().Foreground(theme.Warning),
+		},
+	}
+}
+
+// Init implements tea.Model interface
+func (fb *FileBrowser) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model interface
+func (fb *FileBrowser) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		fb.SetSize(msg.Width, msg.Height)
+		return fb, nil
+
+	case tea.KeyMsg:
+		if fb.saving != nil {
+			return fb, fb.handleSaveKey(msg)
+		}
+		return fb, fb.handleKeyPress(msg)
+
+	case tui.FilesRequestedMsg:
+		if msg.Pod == nil {
+			return fb, nil
+		}
+		// Another pod or container shows nothing until listed
+		if msg.Pod.Namespace != fb.pod.Namespace || msg.Pod.Name != fb.pod.Name || msg.Container != fb.container {
+			fb.files = nil
+			fb.reselect = ""
+		}
+		fb.pod = *msg.Pod
+		fb.container = msg.Container
+		dir := path.Clean("/" + msg.Path)
+		if dir != fb.dir {
+			fb.files = nil
+			fb.cursor, fb.offset = 0, 0
+		}
+		fb.dir = dir
+		fb.err = nil
+		fb.stopSaving()
+		return fb, nil
+
+	case tui.FilesLoadedMsg:
+		fb.err = msg.Error
+		if msg.Error == nil {
+			fb.setFiles(msg.Files)
+		}
+		return fb, nil
+
+	case tui.DownloadsChangedMsg:
+		fb.downloads = msg.Downloads
+		return fb, nil
+
+	case tui.LoadingStartedMsg:
+		if msg.Component == tui.LoadingFiles {
+			fb.loading = true
+		}
+		return fb, nil
+
+	case tui.LoadingCompletedMsg:
+		if msg.Component == tui.LoadingFiles {
+			fb.loading = false
+		}
+		return fb, nil
+	}
+
+	return fb, nil
+}
+
+// setFiles lists directories first, then files, each by name, highlighting
+// the entry asked for or keeping the highlighted one
+func (fb *FileBrowser) setFiles(files []services.RemoteFile) {
+	selected := fb.reselect
+	if selected == "" {
+		if file := fb.SelectedFile(); file != nil {
+			selected = file.Name
+		}
+	}
+	fb.reselect = ""
+
+	fb.files = append([]services.RemoteFile{}, files...)
+	sort.SliceStable(fb.files, func(i, j int) bool {
+		if fb.files[i].IsDir != fb.files[j].IsDir {
+			return fb.files[i].IsDir
+		}
+		return fb.files[i].Name < fb.files[j].Name
+	})
+
+	fb.cursor = min(fb.cursor, max(len(fb.files)-1, 0))
+	for i, file := range fb.files {
+		if file.Name == selected {
+			fb.cursor = i
+		}
+	}
+	fb.scrollToCursor()
+}
+
+func (fb *FileBrowser) handleKeyPress(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "up", "k":
+		fb.moveCursor(-1)
+	case "down", "j":
+		fb.moveCursor(1)
+	case "pgup":
+		fb.moveCursor(-fb.visibleRows())
+	case "pgdown":
+		fb.moveCursor(fb.visibleRows())
+	case "home", "g":
+		fb.moveCursor(-len(fb.files))
+	case "end", "G":
+		fb.moveCursor(len(fb.files))
+
+	case "enter", "right", "l":
+		// Directories and links, which may point to one, are opened
+		file := fb.SelectedFile()
+		if file == nil {
+			return nil
+		}
+		if file.IsDir || file.Link != "" {
+			return fb.open(path.Join(fb.dir, file.Name), "")
+		}
+		fb.startSaving(path.Join(fb.dir, file.Name), *file)
+
+	case "backspace", "left", "h":
+		if fb.dir == "/" {
+			return nil
+		}
+		return fb.open(path.Dir(fb.dir), path.Base(fb.dir))
+
+	case "d":
+		if file := fb.SelectedFile(); file != nil {
+			fb.startSaving(path.Join(fb.dir, file.Name), *file)
+		}
+	case "D":
+		// The directory shown, as a whole
+		name := path.Base(fb.dir)
+		if fb.dir == "/" {
+			name = "root"
+		}
+		fb.startSaving(fb.dir, services.RemoteFile{Name: name, IsDir: true})
+
+	case "c":
+		// The next container, from its root
+		if len(fb.pod.Containers) < 2 {
+			return nil
+		}
+		next := fb.pod.Containers[0]
+		for i, container := range fb.pod.Containers {
+			if container == fb.container {
+				next = fb.pod.Containers[(i+1)%len(fb.pod.Containers)]
+			}
+		}
+		pod := fb.pod
+		return func() tea.Msg {
+			return tui.FilesRequestedMsg{Pod: &pod, Container: next, Path: "/"}
+		}
+
+	case "x":
+		// Cancel the latest download still running
+		for i := len(fb.downloads) - 1; i >= 0; i-- {
+			if download := fb.downloads[i]; downloadRunning(download) {
+				return func() tea.Msg { return tui.DownloadCancelMsg{Download: download} }
+			}
+		}
+
+	case "r":
+		return fb.open(fb.dir, "")
+	}
+	return nil
+}
+
+// open asks to list a directory, highlighting an entry of it once listed
+func (fb *FileBrowser) open(dir, reselect string) tea.Cmd {
+	if fb.pod.Name == "" {
+		return nil
+	}
+	fb.reselect = reselect
+	pod := fb.pod
+	container := fb.container
+	return func() tea.Msg {
+		return tui.FilesRequestedMsg{Pod: &pod, Container: container, Path: dir}
+	}
+}
+
+// startSaving asks where to save the entry at a remote path, suggesting
+// its name in the working directory
+func (fb *FileBrowser) startSaving(remote string, file services.RemoteFile) {
+	fb.saving = &file
+	fb.savingPath = remote
+	fb.targetInput.SetValue(file.Name)
+	fb.targetInput.CursorEnd()
+	fb.targetInput.Focus()
+}
+
+// handleSaveKey edits the local path, starting the download on enter
+func (fb *FileBrowser) handleSaveKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyEsc:
+		fb.stopSaving()
+		return nil
+	case tea.KeyEnter:
+		local := strings.TrimSpace(fb.targetInput.Value())
+		if local == "" {
+			return nil
+		}
+		request := services.DownloadRequest{
+			Namespace: fb.pod.Namespace,
+			Pod:       fb.pod.Name,
+			Container: fb.container,
+			Path:      fb.savingPath,
+			Local:     local,
+		}
+		if !fb.saving.IsDir {
+			request.Size = fb.saving.Size
+		}
+		fb.stopSaving()
+		return func() tea.Msg { return tui.DownloadRequestedMsg{Request: request} }
+	}
+
+	var cmd tea.Cmd
+	fb.targetInput, cmd = fb.targetInput.Update(msg)
+	return cmd
+}
+
+func (fb *FileBrowser) stopSaving() {
+	fb.saving = nil
+	fb.savingPath = ""
+	fb.targetInput.Blur()
+}
+
+func (fb *FileBrowser) moveCursor(step int) {
+	if len(fb.files) == 0 {
+		return
+	}
+	fb.cursor = min(max(fb.cursor+step, 0), len(fb.files)-1)
+	fb.scrollToCursor()
+}
+
+// scrollToCursor keeps the highlighted entry among the rows shown
+func (fb *FileBrowser) scrollToCursor() {
+	rows := fb.visibleRows()
+	if fb.cursor < fb.offset {
+		fb.offset = fb.cursor
+	}
+	if fb.cursor >= fb.offset+rows {
+		fb.offset = fb.cursor - rows + 1
+	}
+	fb.offset = max(fb.offset, 0)
+}
+
+// visibleRows is how many entries fit above the downloads listed
+func (fb *FileBrowser) visibleRows() int {
+	return max(fb.height-fileBrowserChromeHeight-min(len(fb.downloads), fileBrowserMaxDownloads), 1)
+}
+
+// SelectedFile returns the highlighted entry
+func (fb *FileBrowser) SelectedFile() *services.RemoteFile {
+	if fb.cursor < len(fb.files) {
+		return &fb.files[fb.cursor]
+	}
+	return nil
+}
+
+// Dir returns the directory shown
+func (fb *FileBrowser) Dir() string {
+	return fb.dir
+}
+
+// View implements tea.Model interface
+func (fb *FileBrowser) View() string {
+	title := "Files: " + fb.pod.Namespace + "/" + fb.pod.Name
+	if fb.container != "" {
+		title += " (" + fb.container + ")"
+	}
+	title += " " + fb.dir
+	if fb.loading {
+		title += " (loading...)"
+	}
+
+	lines := []string{fb.styles.title.Render(title)}
+	switch {
+	case fb.err != nil:
+		lines = append(lines, styles.ErrorMessageStyles(fb.theme, fb.width).Render(fmt.Sprintf("Error: %v", fb.err)))
+	case fb.files == nil:
+		lines = append(lines, fb.styles.muted.Render("Listing files..."))
+	case len(fb.files) == 0:
+		lines = append(lines, fb.styles.muted.Render("Empty directory"))
+	default:
+		lines = append(lines, fb.renderFiles()...)
+	}
+
+	body := lipgloss.NewStyle().Height(fb.visibleRows() + fileBrowserChromeHeight - 1).
+		Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+	parts := []string{body}
+	parts = append(parts, fb.renderDownloads()...)
+
+	if fb.saving != nil {
+		parts = append(parts, fb.targetInput.View())
+	} else {
+		help := "enter open • ←/backspace up • d download • D download this directory • r reload • esc back"
+		if len(fb.pod.Containers) > 1 {
+			help = "c next container • " + help
+		}
+		for _, download := range fb.downloads {
+			if downloadRunning(download) {
+				help = "x cancel download • " + help
+				break
+			}
+		}
+		parts = append(parts, fb.styles.muted.Render(help))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, parts...)
+}
+
+// renderFiles lists the entries shown, with their column headings
+func (fb *FileBrowser) renderFiles() []string {
+	const sizeWidth, timeWidth, modeWidth = 8, 12, 10
+	nameWidth := max(fb.width-sizeWidth-timeWidth-modeWidth-3, 10)
+	lines := []string{fb.styles.header.Render(fitCell("  NAME", nameWidth) + " " + fitCell("SIZE", sizeWidth) + " " +
+		fitCell("MODIFIED", timeWidth) + " " + fitCell("MODE", modeWidth))}
+
+	end := min(fb.offset+fb.visibleRows(), len(fb.files))
+	for i := fb.offset; i < end; i++ {
+		file := fb.files[i]
+		name, style := file.Name, fb.styles.normal
+		size := formatByteCount(file.Size)
+		switch {
+		case file.IsDir:
+			name, style, size = name+"/", fb.styles.dir, "-"
+		case file.Link != "":
+			name, style = name+" -> "+file.Link, fb.styles.link
+		}
+		if i == fb.cursor {
+			style = fb.styles.selected
+		}
+		lines = append(lines, style.Render(fitCell("  "+name, nameWidth)+" "+fitCell(size, sizeWidth)+" "+
+			fitCell(file.ModTime, timeWidth)+" "+fitCell(file.Mode, modeWidth)))
+	}
+	return lines
+}
+
+// renderDownloads shows the latest downloads with how far they have got
+func (fb *FileBrowser) renderDownloads() []string {
+	downloads := fb.downloads[max(len(fb.downloads)-fileBrowserMaxDownloads, 0):]
+	lines := make([]string, len(downloads))
+	for i, download := range downloads {
+		line := "↓ " + download.Path + " → " + download.Local + "  "
+		err := download.Err()
+		switch {
+		case downloadRunning(download):
+			lines[i] = fb.styles.progress.Render(line + downloadProgress(download))
+		case errors.Is(err, context.Canceled):
+			lines[i] = fb.styles.muted.Render(line + "cancelled")
+		case err != nil:
+			lines[i] = fb.styles.failed.Render(line + "✗ " + err.Error())
+		default:
+			lines[i] = fb.styles.done.Render(line + "✓ " + downloadCopied(download))
+		}
+	}
+	return lines
+}
+
+// downloadRunning reports whether a download is still copying
+func downloadRunning(download *services.Download) bool {
+	select {
+	case <-download.Done():
+		return false
+	default:
+		return true
+	}
+}
+
+// downloadProgress shows a bar for a file whose size is known, and the
+// bytes and files copied otherwise
+func downloadProgress(download *services.Download) string {
+	if download.Size <= 0 {
+		return downloadCopied(download)
+	}
+	copied := download.BytesCopied()
+	percent := min(int(copied*100/download.Size), 100)
+	filled := percent * downloadBarWidth / 100
+	return fmt.Sprintf("[%s%s] %3d%% %s/%s",
+		strings.Repeat("=", filled), strings.Repeat(" ", downloadBarWidth-filled),
+		percent, formatByteCount(copied), formatByteCount(download.Size))
+}
+
+// downloadCopied tells how much was copied
+func downloadCopied(download *services.Download) string {
+	files := download.FilesCopied()
+	if files == 1 {
+		return formatByteCount(download.BytesCopied())
+	}
+	return fmt.Sprintf("%d files, %s", files, formatByteCount(download.BytesCopied()))
+}
+
+// Focus sets the component as focused
+func (fb *FileBrowser) Focus() tea.Cmd {
+	fb.focused = true
+	return nil
+}
+
+// Blur removes focus from the component
+func (fb *FileBrowser) Blur() tea.Cmd {
+	fb.focused = false
+	fb.stopSaving()
+	return nil
+}
+
+// IsFocused returns whether the component is focused
+func (fb *FileBrowser) IsFocused() bool {
+	return fb.focused
+}
+
+// CapturingInput reports whether keys are being typed into the local path
+func (fb *FileBrowser) CapturingInput() bool {
+	return fb.saving != nil
+}
+
+// SetSize updates the component size
+func (fb *FileBrowser) SetSize(width, height int) {
+	fb.width = width
+	fb.height = height
+	fb.targetInput.Width = max(width-len(fb.targetInput.Prompt)-2, 1)
+	fb.scrollToCursor()
+}
+
+// GetSize returns the current component size
+func (fb *FileBrowser) GetSize() (int, int) {
+	return fb.width, fb.height
+}
@@ -0,0 +1,187 @@
+package components
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"kubeoptic/internal/services"
+	"kubeoptic/internal/tui"
+)
+
+func TestFileBrowser(t *testing.T) {
+	pod := &services.Pod{Name: "api-7d9f", Namespace: "payments", Containers: []string{"api", "sidecar"}}
+	files := []services.RemoteFile{
+		{Name: "heap.hprof", Mode: "-rw-r--r--", Size: 3 << 20, ModTime: "Mar 10 14:02"},
+		{Name: "logs", Mode: "drwxr-xr-x", Size: 4096, ModTime: "Mar 10 13:55", IsDir: true},
+		{Name: "current", Mode: "lrwxrwxrwx", Size: 9, ModTime: "Mar 10 13:55", Link: "releases/42"},
+		{Name: "cache", Mode: "drwxr-xr-x", Size: 4096, ModTime: "Mar 9 08:00", IsDir: true},
+	}
+
+	newBrowser := func() *FileBrowser {
+		browser := NewFileBrowser(120, 30)
+		browser.Update(tui.FilesRequestedMsg{Pod: pod, Container: "api", Path: "/tmp"})
+		browser.Update(tui.FilesLoadedMsg{Path: "/tmp", Files: files})
+		return browser
+	}
+	key := func(browser *FileBrowser, k string) tea.Cmd {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		switch k {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		case "down":
+			msg = tea.KeyMsg{Type: tea.KeyDown}
+		case "backspace":
+			msg = tea.KeyMsg{Type: tea.KeyBackspace}
+		}
+		_, cmd := browser.Update(msg)
+		return cmd
+	}
+	requested := func(t *testing.T, cmd tea.Cmd) tui.FilesRequestedMsg {
+		t.Helper()
+		if cmd == nil {
+			t.Fatal("Expected a command")
+		}
+		msg, ok := cmd().(tui.FilesRequestedMsg)
+		if !ok {
+			t.Fatalf("Expected a FilesRequestedMsg, got %T", cmd())
+		}
+		return msg
+	}
+
+	t.Run("directories_first", func(t *testing.T) {
+		browser := newBrowser()
+		view := browser.View()
+		for _, want := range []string{"Files: payments/api-7d9f (api) /tmp", "NAME", "cache/", "logs/", "current -> releases/42", "3Mi", "-rw-r--r--"} {
+			if !strings.Contains(view, want) {
+				t.Errorf("Expected %q in the view:\n%s", want, view)
+			}
+		}
+		if strings.Index(view, "logs/") > strings.Index(view, "heap.hprof") {
+			t.Error("Expected directories before files")
+		}
+		if got := browser.SelectedFile(); got == nil || got.Name != "cache" {
+			t.Errorf("Expected the first directory highlighted, got %+v", got)
+		}
+	})
+
+	t.Run("navigate", func(t *testing.T) {
+		browser := newBrowser()
+		if msg := requested(t, key(browser, "enter")); msg.Path != "/tmp/cache" || msg.Container != "api" {
+			t.Errorf("Expected /tmp/cache to be listed, got %+v", msg)
+		}
+
+		// A link is followed, as it may point to a directory
+		key(browser, "down")
+		key(browser, "down")
+		if msg := requested(t, key(browser, "enter")); msg.Path != "/tmp/current" {
+			t.Errorf("Expected the link to be opened, got %+v", msg)
+		}
+
+		// Going up highlights the directory left
+		browser.Update(tui.FilesRequestedMsg{Pod: pod, Container: "api", Path: "/tmp/logs"})
+		browser.Update(tui.FilesLoadedMsg{Path: "/tmp/logs"})
+		msg := requested(t, key(browser, "backspace"))
+		if msg.Path != "/tmp" {
+			t.Errorf("Expected the parent to be listed, got %+v", msg)
+		}
+		browser.Update(msg)
+		browser.Update(tui.FilesLoadedMsg{Path: "/tmp", Files: files})
+		if got := browser.SelectedFile(); got == nil || got.Name != "logs" {
+			t.Errorf("Expected logs highlighted, got %+v", got)
+		}
+	})
+
+	t.Run("root_has_no_parent", func(t *testing.T) {
+		browser := newBrowser()
+		browser.Update(tui.FilesRequestedMsg{Pod: pod, Container: "api", Path: "/"})
+		if cmd := key(browser, "backspace"); cmd != nil {
+			t.Error("Expected nothing above the root")
+		}
+	})
+
+	t.Run("next_container", func(t *testing.T) {
+		browser := newBrowser()
+		if msg := requested(t, key(browser, "c")); msg.Container != "sidecar" || msg.Path != "/" {
+			t.Errorf("Expected the sidecar's root, got %+v", msg)
+		}
+	})
+
+	t.Run("download_request", func(t *testing.T) {
+		browser := newBrowser()
+		for range 3 {
+			key(browser, "down")
+		}
+		key(browser, "d")
+		if !browser.CapturingInput() || !strings.Contains(browser.View(), "Save to: heap.hprof") {
+			t.Fatalf("Expected to be asked where to save:\n%s", browser.View())
+		}
+		for _, r := range "-1" {
+			browser.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+		_, cmd := browser.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		if cmd == nil || browser.CapturingInput() {
+			t.Fatal("Expected enter to start the download")
+		}
+		msg, ok := cmd().(tui.DownloadRequestedMsg)
+		want := services.DownloadRequest{Namespace: "payments", Pod: "api-7d9f", Container: "api", Path: "/tmp/heap.hprof", Local: "heap.hprof-1", Size: 3 << 20}
+		if !ok || msg.Request != want {
+			t.Errorf("Expected %+v, got %+v", want, msg.Request)
+		}
+	})
+
+	t.Run("download_directory", func(t *testing.T) {
+		browser := newBrowser()
+		key(browser, "D")
+		if !strings.Contains(browser.View(), "Save to: tmp") {
+			t.Fatalf("Expected the directory's name suggested:\n%s", browser.View())
+		}
+		_, cmd := browser.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		msg, ok := cmd().(tui.DownloadRequestedMsg)
+		if !ok || msg.Request.Path != "/tmp" || msg.Request.Size != 0 {
+			t.Errorf("Expected /tmp with no known size, got %+v", msg.Request)
+		}
+	})
+
+	t.Run("download_cancelled", func(t *testing.T) {
+		browser := newBrowser()
+		key(browser, "d")
+		if cmd := key(browser, "esc"); cmd != nil || browser.CapturingInput() {
+			t.Error("Expected esc to close the prompt")
+		}
+	})
+
+	t.Run("progress", func(t *testing.T) {
+		browser := newBrowser()
+		// A download that hasn't ended
+		download := &services.Download{DownloadRequest: services.DownloadRequest{Path: "/tmp/heap.hprof", Local: "heap.hprof", Size: 3 << 20}}
+		browser.Update(tui.DownloadsChangedMsg{Downloads: []*services.Download{download}})
+		view := browser.View()
+		for _, want := range []string{"/tmp/heap.hprof → heap.hprof", "0%", "0B/3Mi", "x cancel download"} {
+			if !strings.Contains(view, want) {
+				t.Errorf("Expected %q in the view:\n%s", want, view)
+			}
+		}
+
+		cmd := key(browser, "x")
+		if cmd == nil {
+			t.Fatal("Expected x to cancel the download")
+		}
+		if msg, ok := cmd().(tui.DownloadCancelMsg); !ok || msg.Download != download {
+			t.Errorf("Expected the download cancelled, got %+v", cmd())
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		browser := NewFileBrowser(120, 30)
+		browser.Update(tui.FilesRequestedMsg{Pod: pod, Container: "api", Path: "/root"})
+		browser.Update(tui.FilesLoadedMsg{Path: "/root", Error: errors.New("ls: /root/: Permission denied")})
+		if !strings.Contains(browser.View(), "Permission denied") {
+			t.Error("Expected the error in the view")
+		}
+	})
+}
@@ -0,0 +1,40 @@
+package components
+
+import (
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// replaceItems swaps a list's items for a fresh set, as happens on every live
+// update. An active filter is re-applied immediately rather than through a
+// command, and the cursor stays on the item with the same key; if that item
+// is gone the cursor keeps its position.
+func replaceItems(l *list.Model, items []list.Item, key func(list.Item) string) {
+	var selectedKey string
+	if selected := l.SelectedItem(); selected != nil {
+		selectedKey = key(selected)
+	}
+	index := l.Index()
+
+	if cmd := l.SetItems(items); cmd != nil {
+		if matches, ok := cmd().(list.FilterMatchesMsg); ok {
+			*l, _ = l.Update(matches)
+		}
+	}
+
+	visible := l.VisibleItems()
+	if selectedKey != "" {
+		for i, item := range visible {
+			if key(item) == selectedKey {
+				l.Select(i)
+				return
+			}
+		}
+	}
+
+	if index >= len(visible) {
+		index = len(visible) - 1
+	}
+	if index >= 0 {
+		l.Select(index)
+	}
+}
@@ -39,6 +39,7 @@ Key Bindings:
 - f : Toggle follow mode
 - w : Toggle line wrapping
 - t : Toggle timestamps
+- e : Toggle pod events between log lines
 - g : Go to top
 - G : Go to bottom
 - ↑/k : Scroll up
@@ -70,13 +71,14 @@ package components
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/key"
-	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -97,9 +99,15 @@ const (
 	maxSearchResults = 1000                   // Limit search results for performance
 	debounceDelay    = 100 * time.Millisecond // Debounce search updates
 
+	// Consecutive read errors tolerated before a stream is given up
+	maxStreamRetries = 3
+
 	// Search
 	maxSearchHistory = 50
 	searchPrompt     = "Search: "
+
+	// eventLinePrefix marks log lines that are Kubernetes events
+	eventLinePrefix = "── event: "
 )
 
 // LogDataProvider defines the interface for accessing log data and pod information
@@ -115,8 +123,7 @@ type LogDataProvider interface {
 // LogViewer represents the log viewing component
 type LogViewer struct {
 	// Core components
-	viewport    viewport.Model
-	searchInput textinput.Model
+	viewport viewport.Model
 
 	// State
 	dataProvider LogDataProvider
@@ -128,6 +135,11 @@ type LogViewer struct {
 	logLines      []string
 	filteredLines []string
 	logStream     io.ReadCloser
+	logReader     *bufio.Reader
+	streamID      int
+	streamErrors  int
+	connecting    bool
+	spinner       spinner.Model
 	streamCtx     context.Context
 	streamCancel  context.CancelFunc
 
@@ -136,12 +148,16 @@ type LogViewer struct {
 	showTimestamps bool
 	wrapLines      bool
 
+	// Pod events are added to the log lines as they arrive and shown when
+	// showEvents is on; shownEvents holds the count last added per event.
+	// Only events seen since streamStarted are added.
+	showEvents    bool
+	shownEvents   map[string]int32
+	eventLines    int
+	streamStarted time.Time
+
 	// Search functionality
-	searchMode    bool
-	searchQuery   string
-	searchResults []int // line numbers with matches
-	currentResult int
-	searchHistory []string
+	textSearch
 
 	// Error handling
 	lastError error
@@ -170,6 +186,7 @@ type LogViewerKeyMap struct {
 	ClearSearch  key.Binding
 	ToggleWrap   key.Binding
 	ToggleTime   key.Binding
+	ToggleEvents key.Binding
 	Quit         key.Binding
 }
 
@@ -228,6 +245,10 @@ func DefaultLogViewerKeyMap() LogViewerKeyMap {
 			key.WithKeys("t"),
 			key.WithHelp("t", "toggle timestamps"),
 		),
+		ToggleEvents: key.NewBinding(
+			key.WithKeys("e"),
+			key.WithHelp("e", "toggle pod events"),
+		),
 		Quit: key.NewBinding(
 			key.WithKeys("q", "ctrl+c"),
 			key.WithHelp("q", "quit"),
@@ -241,11 +262,6 @@ func NewLogViewer(dataProvider LogDataProvider, width, height int) *LogViewer {
 	vp := viewport.New(width-2, height-4) // Account for borders and search
 	vp.SetContent("No logs available")
 
-	// Initialize search input
-	searchInput := textinput.New()
-	searchInput.Placeholder = "Search logs..."
-	searchInput.CharLimit = 256
-
 	// Create context for stream management
 	ctx, cancel := context.WithCancel(context.Background())
 
@@ -253,7 +269,7 @@ func NewLogViewer(dataProvider LogDataProvider, width, height int) *LogViewer {
 
 	return &LogViewer{
 		viewport:       vp,
-		searchInput:    searchInput,
+		textSearch:     newTextSearch("Search logs..."),
 		dataProvider:   dataProvider,
 		width:          width,
 		height:         height,
@@ -262,12 +278,13 @@ func NewLogViewer(dataProvider LogDataProvider, width, height int) *LogViewer {
 		wrapLines:      true,
 		streamCtx:      ctx,
 		streamCancel:   cancel,
+		spinner:        spinner.New(spinner.WithSpinner(spinner.Dot)),
 		styles:         styles.NewLogViewerStyles(theme, width, height, false),
 		theme:          theme,
 		keyMap:         DefaultLogViewerKeyMap(),
 		logLines:       make([]string, 0, maxLogLines),
 		filteredLines:  make([]string, 0, maxLogLines),
-		searchHistory:  make([]string, 0, maxSearchHistory),
+		shownEvents:    make(map[string]int32),
 	}
 }
 
@@ -294,8 +311,7 @@ func (lv *LogViewer) Focus() tea.Cmd {
 func (lv *LogViewer) Blur() tea.Cmd {
 	lv.focused = false
 	lv.styles = styles.NewLogViewerStyles(lv.theme, lv.width, lv.height, false)
-	lv.searchMode = false
-	lv.searchInput.Blur()
+	lv.exitSearchMode()
 	return nil
 }
 
@@ -304,6 +320,11 @@ func (lv *LogViewer) IsFocused() bool {
 	return lv.focused
 }
 
+// CapturingInput reports whether keys are being typed into the search bar
+func (lv *LogViewer) CapturingInput() bool {
+	return lv.searchMode
+}
+
 // Init initializes the log viewer
 func (lv *LogViewer) Init() tea.Cmd {
 	// Don't automatically start streaming - wait for a pod to be selected
@@ -328,11 +349,48 @@ func (lv *LogViewer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return lv.handleLogChunk(msg)
 
 	case tui.LogStreamStartedMsg:
+		if msg.Error != nil {
+			lv.setError(msg.Error)
+			return lv, nil
+		}
 		lv.clearError()
+		if msg.Stream != nil {
+			lv.attachStream(msg.Stream, msg.RequestID)
+			if lv.showEvents {
+				// The new pod's events may be in another namespace
+				return lv, tea.Batch(lv.streamLogs(), lv.eventsToggled())
+			}
+			return lv, lv.streamLogs()
+		}
+
+	case tui.EventsLoadedMsg:
+		if msg.Error == nil {
+			lv.appendEvents(msg.Events)
+		}
+		return lv, nil
 
 	case tui.LogStreamStoppedMsg:
 		lv.stopLogStream()
 
+	case tui.LoadingStartedMsg:
+		if msg.Component == tui.LoadingLogs {
+			lv.connecting = true
+			return lv, lv.spinner.Tick
+		}
+
+	case tui.LoadingCompletedMsg:
+		if msg.Component == tui.LoadingLogs {
+			lv.connecting = false
+		}
+
+	case spinner.TickMsg:
+		if lv.connecting {
+			var cmd tea.Cmd
+			lv.spinner, cmd = lv.spinner.Update(msg)
+			return lv, cmd
+		}
+		return lv, nil
+
 	case tui.ErrorMsg:
 		lv.setError(msg.Error)
 
@@ -411,6 +469,11 @@ func (lv *LogViewer) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		lv.showTimestamps = !lv.showTimestamps
 		return lv, nil
 
+	case key.Matches(msg, lv.keyMap.ToggleEvents):
+		lv.showEvents = !lv.showEvents
+		lv.updateFilteredLines()
+		return lv, lv.eventsToggled()
+
 	case key.Matches(msg, lv.keyMap.NextSearch) && len(lv.searchResults) > 0:
 		lv.nextSearchResult()
 		return lv, nil
@@ -436,34 +499,32 @@ func (lv *LogViewer) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (lv *LogViewer) handleSearchMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.Type {
-	case tea.KeyEnter:
-		lv.executeSearch()
-		return lv, nil
-
-	case tea.KeyEsc:
-		lv.exitSearchMode()
-		return lv, nil
-	}
-
-	// Let search input handle the key
-	var cmd tea.Cmd
-	lv.searchInput, cmd = lv.searchInput.Update(msg)
-
-	// Update search results as user types
-	if lv.searchInput.Value() != lv.searchQuery {
-		lv.searchQuery = lv.searchInput.Value()
+	// Update search results as user types, and on enter
+	changed, cmd := lv.handleSearchKey(msg)
+	if changed {
 		lv.updateSearchResults()
 	}
-
 	return lv, cmd
 }
 
 func (lv *LogViewer) handleLogChunk(msg tui.LogChunkMsg) (tea.Model, tea.Cmd) {
+	// Ignore chunks still in flight from a replaced or stopped stream
+	if msg.RequestID != lv.streamID {
+		return lv, nil
+	}
+
 	if msg.Error != nil {
+		if errors.Is(msg.Error, context.Canceled) {
+			return lv, nil // Stream was cancelled on purpose
+		}
 		lv.setError(msg.Error)
+		lv.streamErrors++
+		if lv.streamErrors > maxStreamRetries {
+			return lv, nil // Give up on a persistently failing stream
+		}
 		return lv, lv.streamLogs() // Continue trying to read
 	}
+	lv.streamErrors = 0
 
 	if msg.EOF {
 		return lv, nil // Stream ended
@@ -496,10 +557,81 @@ func (lv *LogViewer) startLogStream() tea.Cmd {
 	}
 }
 
+// attachStream starts showing a newly opened log stream
+func (lv *LogViewer) attachStream(stream io.ReadCloser, requestID int) {
+	lv.logStream = stream
+	lv.logReader = bufio.NewReader(stream)
+	lv.streamID = requestID
+	lv.streamErrors = 0
+	lv.logLines = make([]string, 0, maxLogLines)
+	lv.shownEvents = make(map[string]int32)
+	lv.eventLines = 0
+	lv.streamStarted = time.Now()
+	lv.updateFilteredLines()
+}
+
+// eventsToggled tells the app whether pod events are wanted, so it can keep
+// them coming
+func (lv *LogViewer) eventsToggled() tea.Cmd {
+	enabled := lv.showEvents
+	return func() tea.Msg {
+		return tui.LogEventsToggledMsg{Enabled: enabled}
+	}
+}
+
+// appendEvents adds the streamed pod's events that are new, or have happened
+// again, as event lines after the logs received so far. Log lines carry no
+// time to place older events among, so only events seen since the stream
+// started are added; the events panel lists the earlier ones.
+func (lv *LogViewer) appendEvents(events []services.Event) {
+	pod := lv.dataProvider.GetSelectedPod()
+	if pod == nil || lv.logStream == nil {
+		return
+	}
+
+	// Event times are whole seconds
+	since := lv.streamStarted.Truncate(time.Second)
+	added := false
+	for _, event := range events {
+		if event.Namespace != pod.Namespace || !event.IsAbout("Pod", pod.Name) {
+			continue
+		}
+		if event.LastSeen.Before(since) {
+			continue
+		}
+		if shown, ok := lv.shownEvents[event.UID]; ok && shown >= event.Count {
+			continue
+		}
+		lv.shownEvents[event.UID] = event.Count
+
+		line := fmt.Sprintf("%s%s %s: %s", eventLinePrefix, event.Type, event.Reason, strings.ReplaceAll(event.Message, "\n", " "))
+		if event.Count > 1 {
+			line += fmt.Sprintf(" (x%d)", event.Count)
+		}
+		lv.logLines = append(lv.logLines, line)
+		lv.eventLines++
+		added = true
+	}
+	if !added {
+		return
+	}
+
+	if len(lv.logLines) > maxLogLines {
+		lv.logLines = lv.logLines[len(lv.logLines)-maxLogLines:]
+	}
+	lv.updateFilteredLines()
+	if lv.followMode {
+		lv.scrollToBottom()
+	}
+}
+
 // streamLogs reads from the log stream asynchronously
 func (lv *LogViewer) streamLogs() tea.Cmd {
+	// Capture the stream now; the command runs outside the event loop
+	stream, reader, requestID := lv.logStream, lv.logReader, lv.streamID
+
 	return func() tea.Msg {
-		if lv.logStream == nil {
+		if stream == nil {
 			// Try to get the stream from dataProvider
 			buffer := lv.dataProvider.GetLogBuffer()
 			if len(buffer) > 0 {
@@ -510,23 +642,24 @@ func (lv *LogViewer) streamLogs() tea.Cmd {
 			return nil
 		}
 
-		// Read from stream with timeout
-		reader := bufio.NewReader(lv.logStream)
-
 		// Set read deadline
-		if closer, ok := lv.logStream.(interface{ SetReadDeadline(time.Time) error }); ok {
+		if closer, ok := stream.(interface{ SetReadDeadline(time.Time) error }); ok {
 			closer.SetReadDeadline(time.Now().Add(streamTimeout))
 		}
 
+		// The reader is reused between reads so buffered data is not lost
 		line, err := reader.ReadString('\n')
 		if err != nil {
 			if err == io.EOF {
-				return tui.LogChunkMsg{EOF: true}
+				if line != "" {
+					return tui.LogChunkMsg{Data: line, RequestID: requestID}
+				}
+				return tui.LogChunkMsg{EOF: true, RequestID: requestID}
 			}
-			return tui.LogChunkMsg{Error: err}
+			return tui.LogChunkMsg{Error: err, RequestID: requestID}
 		}
 
-		return tui.LogChunkMsg{Data: strings.TrimSuffix(line, "\n")}
+		return tui.LogChunkMsg{Data: strings.TrimSuffix(line, "\n"), RequestID: requestID}
 	}
 }
 
@@ -553,7 +686,8 @@ func (lv *LogViewer) appendLogData(data string) {
 
 // updateFilteredLines updates the filtered lines based on search query with performance optimizations
 func (lv *LogViewer) updateFilteredLines() {
-	if lv.searchQuery == "" {
+	hideEvents := !lv.showEvents && lv.eventLines > 0
+	if lv.searchQuery == "" && !hideEvents {
 		lv.filteredLines = lv.logLines
 		lv.searchResults = make([]int, 0)
 		return
@@ -563,10 +697,12 @@ func (lv *LogViewer) updateFilteredLines() {
 	lv.filteredLines = make([]string, 0, len(lv.logLines))
 	lv.searchResults = make([]int, 0, maxSearchResults)
 
-	searchLower := strings.ToLower(lv.searchQuery)
-
+	filter := services.LogFilter{Query: lv.searchQuery}
 	for i, line := range lv.logLines {
-		if strings.Contains(strings.ToLower(line), searchLower) {
+		if hideEvents && strings.HasPrefix(line, eventLinePrefix) {
+			continue
+		}
+		if filter.Matches(line) {
 			lv.filteredLines = append(lv.filteredLines, line)
 
 			// Limit search results for performance
@@ -584,92 +720,33 @@ func (lv *LogViewer) updateFilteredLines() {
 
 // Search functionality
 
-// Search functionality
-func (lv *LogViewer) enterSearchMode() {
-	lv.searchMode = true
-	lv.searchInput.Focus()
-}
-
-func (lv *LogViewer) exitSearchMode() {
-	lv.searchMode = false
-	lv.searchInput.Blur()
-}
-
 func (lv *LogViewer) executeSearch() {
-	query := lv.searchInput.Value()
-	if query != "" {
-		lv.searchQuery = query
-		lv.addToSearchHistory(query)
+	if lv.commitSearch() {
 		lv.updateSearchResults()
 	}
-	lv.exitSearchMode()
 }
 
 func (lv *LogViewer) updateSearchResults() {
 	lv.updateFilteredLines()
 	if len(lv.searchResults) > 0 {
 		lv.currentResult = 0
-		lv.jumpToSearchResult(lv.currentResult)
+		lv.showMatch(&lv.viewport)
 	}
 }
 
-// Search functionality
-
 func (lv *LogViewer) nextSearchResult() {
-	if len(lv.searchResults) == 0 {
-		return
-	}
-	lv.currentResult = (lv.currentResult + 1) % len(lv.searchResults)
-	lv.jumpToSearchResult(lv.currentResult)
+	lv.nextMatch(&lv.viewport)
 }
 
 func (lv *LogViewer) prevSearchResult() {
-	if len(lv.searchResults) == 0 {
-		return
-	}
-	lv.currentResult = (lv.currentResult - 1 + len(lv.searchResults)) % len(lv.searchResults)
-	lv.jumpToSearchResult(lv.currentResult)
-}
-
-func (lv *LogViewer) jumpToSearchResult(index int) {
-	if index < 0 || index >= len(lv.searchResults) {
-		return
-	}
-
-	lineNum := lv.searchResults[index]
-	// Calculate viewport position to center the line
-	viewportHeight := lv.viewport.Height
-	targetLine := lineNum - viewportHeight/2
-	if targetLine < 0 {
-		targetLine = 0
-	}
-
-	// Set viewport to show the target line
-	lv.viewport.SetYOffset(targetLine)
+	lv.prevMatch(&lv.viewport)
 }
 
 func (lv *LogViewer) clearSearch() {
-	lv.searchQuery = ""
-	lv.searchResults = make([]int, 0)
-	lv.currentResult = 0
-	lv.searchInput.SetValue("")
+	lv.resetSearch()
 	lv.updateFilteredLines()
 }
 
-func (lv *LogViewer) addToSearchHistory(query string) {
-	// Add to history if not already present
-	for _, existing := range lv.searchHistory {
-		if existing == query {
-			return
-		}
-	}
-
-	lv.searchHistory = append(lv.searchHistory, query)
-	if len(lv.searchHistory) > maxSearchHistory {
-		lv.searchHistory = lv.searchHistory[1:]
-	}
-}
-
 // Utility methods
 func (lv *LogViewer) scrollToBottom() {
 	lv.viewport.GotoBottom()
@@ -689,7 +766,10 @@ func (lv *LogViewer) stopLogStream() {
 	if lv.logStream != nil {
 		lv.logStream.Close()
 		lv.logStream = nil
+		lv.logReader = nil
 	}
+	lv.streamID = 0
+	lv.connecting = false
 	if lv.streamCancel != nil {
 		lv.streamCancel()
 	}
@@ -711,26 +791,30 @@ func (lv *LogViewer) renderLogContent() string {
 }
 
 func (lv *LogViewer) renderLogLine(line string, index int) string {
+	if event, ok := strings.CutPrefix(line, eventLinePrefix); ok {
+		if strings.HasPrefix(event, services.EventWarning+" ") {
+			return lv.styles.WarningEvent.Render(line)
+		}
+		return lv.styles.NormalEvent.Render(line)
+	}
+
 	// Apply syntax highlighting based on log level
 	style := lv.styles.LogLine
 
-	lowerLine := strings.ToLower(line)
-	switch {
-	case strings.Contains(lowerLine, "error"):
+	switch services.LogLevelOf(line) {
+	case services.LogLevelError:
 		style = lv.styles.ErrorLog
-	case strings.Contains(lowerLine, "warn"):
+	case services.LogLevelWarn:
 		style = lv.styles.WarningLog
-	case strings.Contains(lowerLine, "info"):
+	case services.LogLevelInfo:
 		style = lv.styles.InfoLog
-	case strings.Contains(lowerLine, "debug"):
+	case services.LogLevelDebug:
 		style = lv.styles.DebugLog
 	}
 
 	// Highlight search matches
-	if lv.searchQuery != "" && strings.Contains(strings.ToLower(line), strings.ToLower(lv.searchQuery)) {
-		// Simple highlighting - in a full implementation, you'd want proper regex highlighting
-		line = strings.ReplaceAll(line, lv.searchQuery,
-			lipgloss.NewStyle().Background(lv.theme.Highlight).Render(lv.searchQuery))
+	if lv.matches(line) {
+		line = lv.highlightMatches(line, lipgloss.NewStyle().Background(lv.theme.Highlight))
 	}
 
 	return style.Render(line)
@@ -743,23 +827,21 @@ func (lv *LogViewer) renderHeader() string {
 	}
 
 	title := fmt.Sprintf("Logs: %s/%s", pod.Namespace, pod.Name)
+	if lv.connecting {
+		title += " " + lv.spinner.View() + " connecting"
+	}
 	if lv.followMode {
 		title += " [FOLLOW]"
 	}
+	if lv.showEvents {
+		title += " [EVENTS]"
+	}
 
 	return lv.styles.Title.Render(title)
 }
 
 func (lv *LogViewer) renderSearchBar() string {
-	prompt := lv.styles.Title.Render(searchPrompt)
-	input := lv.searchInput.View()
-
-	if len(lv.searchResults) > 0 {
-		status := fmt.Sprintf(" (%d/%d)", lv.currentResult+1, len(lv.searchResults))
-		input += lv.styles.Title.Render(status)
-	}
-
-	return prompt + input
+	return lv.searchBar(lv.styles.Title)
 }
 
 func (lv *LogViewer) renderStatusBar() string {
@@ -780,7 +862,7 @@ func (lv *LogViewer) renderStatusBar() string {
 
 	// Key hints
 	if !lv.searchMode {
-		status = append(status, "/ search • f follow • q quit")
+		status = append(status, "/ search • f follow • e events • q quit")
 	}
 
 	return lv.styles.Title.Render(strings.Join(status, " | "))
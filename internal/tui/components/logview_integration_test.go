@@ -467,3 +467,73 @@ func TestLogViewerIntegration_RealTimeStreaming(t *testing.T) {
 		}
 	})
 }
+
+func TestLogViewerIntegration_StreamLifecycle(t *testing.T) {
+	dataProvider := newStreamingMockKubeoptic()
+	lv := NewLogViewer(dataProvider, 120, 40)
+
+	t.Run("attach_and_read", func(t *testing.T) {
+		startMsg := tui.LogStreamStartedMsg{
+			Pod:       dataProvider.selectedPod,
+			Stream:    dataProvider.logStream,
+			RequestID: 7,
+		}
+		_, cmd := lv.Update(startMsg)
+		if cmd == nil {
+			t.Fatal("Expected command to start reading the stream")
+		}
+
+		// Drain the stream through the read loop
+		for i := 0; i < 10 && cmd != nil; i++ {
+			msg := cmd()
+			chunk, ok := msg.(tui.LogChunkMsg)
+			if !ok {
+				t.Fatalf("Expected LogChunkMsg, got %T", msg)
+			}
+			if chunk.RequestID != 7 {
+				t.Errorf("Expected chunk for request 7, got %d", chunk.RequestID)
+			}
+			_, cmd = lv.Update(chunk)
+		}
+
+		if len(lv.logLines) != 6 {
+			t.Errorf("Expected 6 log lines from stream, got %d", len(lv.logLines))
+		}
+	})
+
+	t.Run("stale_chunks_ignored", func(t *testing.T) {
+		before := len(lv.logLines)
+		_, cmd := lv.Update(tui.LogChunkMsg{Data: "from an old stream", RequestID: 3})
+		if cmd != nil {
+			t.Error("Stale chunk should not continue streaming")
+		}
+		if len(lv.logLines) != before {
+			t.Error("Stale chunk should not be appended")
+		}
+	})
+
+	t.Run("connect_error", func(t *testing.T) {
+		_, cmd := lv.Update(tui.LogStreamStartedMsg{Error: io.ErrUnexpectedEOF, RequestID: 8})
+		if cmd != nil {
+			t.Error("Failed connection should not start reading")
+		}
+		if lv.lastError == nil {
+			t.Error("Expected connection error to be shown")
+		}
+	})
+
+	t.Run("connecting_indicator", func(t *testing.T) {
+		_, cmd := lv.Update(tui.LoadingStartedMsg{Component: tui.LoadingLogs})
+		if cmd == nil {
+			t.Error("Expected spinner tick while connecting")
+		}
+		if !strings.Contains(lv.View(), "connecting") {
+			t.Error("Expected connecting indicator in header")
+		}
+
+		lv.Update(tui.LoadingCompletedMsg{Component: tui.LoadingLogs})
+		if strings.Contains(lv.View(), "connecting") {
+			t.Error("Connecting indicator should clear once connected")
+		}
+	})
+}
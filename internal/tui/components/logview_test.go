@@ -2,8 +2,10 @@ package components
 
 import (
 	"errors"
+	"io"
 	"strings"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"kubeoptic/internal/models"
@@ -476,3 +478,42 @@ func TestLogViewerView(t *testing.T) {
 		t.Error("Expected view to contain error message")
 	}
 }
+
+func TestLogViewerPodEvents(t *testing.T) {
+	dataProvider := newMockKubeoptic()
+	lv := NewLogViewer(dataProvider, 80, 24)
+	lv.Update(tui.LogStreamStartedMsg{Stream: io.NopCloser(strings.NewReader("")), RequestID: 1})
+	lv.appendLogData("INFO starting")
+
+	now := time.Now()
+	backOff := services.Event{UID: "e1", Namespace: "test-namespace", Type: services.EventWarning, Reason: "BackOff",
+		Message: "Back-off restarting failed container", Object: services.OwnerRef{Kind: "Pod", Name: "test-pod"}, Count: 1, LastSeen: now}
+	other := services.Event{UID: "e2", Namespace: "test-namespace", Type: services.EventNormal, Reason: "Pulled",
+		Object: services.OwnerRef{Kind: "Pod", Name: "other-pod"}, Count: 1, LastSeen: now}
+	earlier := services.Event{UID: "e3", Namespace: "test-namespace", Type: services.EventNormal, Reason: "Scheduled",
+		Object: services.OwnerRef{Kind: "Pod", Name: "test-pod"}, Count: 1, LastSeen: now.Add(-time.Hour)}
+
+	lv.Update(tui.EventsLoadedMsg{Namespace: "test-namespace", Events: []services.Event{earlier, backOff, other}})
+	if len(lv.logLines) != 2 {
+		t.Fatalf("Expected only the streamed pod's event since the stream started to be added, got %v", lv.logLines)
+	}
+	if len(lv.filteredLines) != 1 {
+		t.Errorf("Expected events hidden until toggled on, got %v", lv.filteredLines)
+	}
+
+	_, cmd := lv.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	if msg, ok := cmd().(tui.LogEventsToggledMsg); !ok || !msg.Enabled {
+		t.Errorf("Expected events to be requested, got %+v", cmd())
+	}
+	if len(lv.filteredLines) != 2 || !strings.Contains(lv.renderLogContent(), "Warning BackOff") {
+		t.Errorf("Expected the event between the log lines, got %v", lv.filteredLines)
+	}
+
+	// Repeats are added again with their count; unchanged events are not
+	lv.Update(tui.EventsLoadedMsg{Namespace: "test-namespace", Events: []services.Event{backOff}})
+	backOff.Count = 3
+	lv.Update(tui.EventsLoadedMsg{Namespace: "test-namespace", Events: []services.Event{backOff}})
+	if len(lv.logLines) != 3 || !strings.HasSuffix(lv.logLines[2], "(x3)") {
+		t.Errorf("Expected the repeated event once more, got %v", lv.logLines)
+	}
+}
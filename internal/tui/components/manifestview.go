@@ -0,0 +1,384 @@
+package components
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"kubeoptic/internal/services"
+	"kubeoptic/internal/tui"
+	"kubeoptic/internal/tui/styles"
+)
+
+// ManifestViewer shows the YAML of a pod or workload with syntax
+// highlighting, scrolled and searched like the log viewer. Managed fields
+// are left out unless asked for.
+type ManifestViewer struct {
+	viewport viewport.Model
+	keyMap   LogViewerKeyMap
+
+	// Search functionality
+	textSearch
+
+	request       tui.ManifestRequestedMsg
+	manifest      *services.Manifest
+	lines         []string
+	managedFields bool
+	loading       bool
+	err           error
+
+	focused bool
+	width   int
+	height  int
+
+	theme  styles.Theme
+	styles manifestViewerStyles
+}
+
+type manifestViewerStyles struct {
+	title    lipgloss.Style
+	muted    lipgloss.Style
+	key      lipgloss.Style
+	str      lipgloss.Style
+	number   lipgloss.Style
+	keyword  lipgloss.Style
+	text     lipgloss.Style
+	match    lipgloss.Style
+	selected lipgloss.Style
+}
+
+// NewManifestViewer creates a new YAML view
+func NewManifestViewer(width, height int) *ManifestViewer {
+	theme := styles.DefaultTheme()
+	return &ManifestViewer{
+		viewport:   viewport.New(width, max(height-2, 0)),
+		keyMap:     DefaultLogViewerKeyMap(),
+		textSearch: newTextSearch("Search YAML..."),
+		width:      width,
+		height:     height,
+		theme:      theme,
+		styles: manifestViewerStyles{
+			title:    lipgloss.NewStyle().Bold(true).Foreground(theme.Primary).Padding(0, 1),
+			muted:    lipgloss.NewStyle().Foreground(lipgloss.Color("240")),
+			key:      lipgloss.NewStyle().Foreground(lipgloss.Color("39")),
+			str:      lipgloss.NewStyle().Foreground(lipgloss.Color("114")),
+			number:   lipgloss.NewStyle().Foreground(lipgloss.Color("214")),
+			keyword:  lipgloss.NewStyle().Foreground(lipgloss.Color("170")),
+			text:     lipgloss.NewStyle().Foreground(lipgloss.Color("252")),
+			match:    lipgloss.NewStyle().Background(theme.Highlight).Foreground(lipgloss.Color("0")),
+			selected: lipgloss.NewStyle().Background(theme.Warning).Foreground(lipgloss.Color("0")),
+		},
+	}
+}
+
+// Init implements tea.Model interface
+func (mv *ManifestViewer) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model interface
+func (mv *ManifestViewer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		mv.SetSize(msg.Width, msg.Height)
+		return mv, nil
+
+	case tea.KeyMsg:
+		if mv.searchMode {
+			changed, cmd := mv.handleSearchKey(msg)
+			if changed {
+				mv.search()
+			}
+			return mv, cmd
+		}
+		return mv, mv.handleKeyPress(msg)
+
+	case tui.ManifestRequestedMsg:
+		// Another resource starts from the top with nothing shown
+		if msg != mv.request {
+			mv.request = msg
+			mv.manifest = nil
+			mv.lines = nil
+			mv.err = nil
+			mv.resetSearch()
+			mv.viewport.SetContent("")
+			mv.viewport.GotoTop()
+		}
+		return mv, nil
+
+	case tui.ManifestLoadedMsg:
+		mv.err = msg.Error
+		if msg.Error == nil && msg.Manifest != nil {
+			mv.manifest = msg.Manifest
+			mv.refresh()
+		}
+		return mv, nil
+
+	case tui.LoadingStartedMsg:
+		if msg.Component == tui.LoadingManifest {
+			mv.loading = true
+		}
+		return mv, nil
+
+	case tui.LoadingCompletedMsg:
+		if msg.Component == tui.LoadingManifest {
+			mv.loading = false
+		}
+		return mv, nil
+	}
+
+	return mv, nil
+}
+
+func (mv *ManifestViewer) handleKeyPress(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, mv.keyMap.Search):
+		mv.enterSearchMode()
+	case key.Matches(msg, mv.keyMap.NextSearch):
+		mv.nextMatch(&mv.viewport)
+		mv.render()
+	case key.Matches(msg, mv.keyMap.PrevSearch):
+		mv.prevMatch(&mv.viewport)
+		mv.render()
+	case key.Matches(msg, mv.keyMap.Up):
+		mv.viewport.ScrollUp(1)
+	case key.Matches(msg, mv.keyMap.Down):
+		mv.viewport.ScrollDown(1)
+	case key.Matches(msg, mv.keyMap.PageUp):
+		mv.viewport.PageUp()
+	case key.Matches(msg, mv.keyMap.PageDown):
+		mv.viewport.PageDown()
+	case key.Matches(msg, mv.keyMap.Home):
+		mv.viewport.GotoTop()
+	case key.Matches(msg, mv.keyMap.End):
+		mv.viewport.GotoBottom()
+
+	case msg.String() == "m":
+		mv.managedFields = !mv.managedFields
+		mv.refresh()
+
+	case msg.String() == "r":
+		// Fetch the resource again
+		request := mv.request
+		if request.Name == "" {
+			return nil
+		}
+		return func() tea.Msg { return request }
+
+	case msg.String() == "e":
+		// Edit the resource in the user's editor
+		request := mv.request
+		if request.Name == "" {
+			return nil
+		}
+		return func() tea.Msg {
+			return tui.EditRequestedMsg{Kind: request.Kind, Namespace: request.Namespace, Name: request.Name}
+		}
+	}
+	return nil
+}
+
+// refresh shows the manifest, with or without its managed fields, keeping
+// the scroll position and the search
+func (mv *ManifestViewer) refresh() {
+	if mv.manifest == nil {
+		return
+	}
+	yaml := mv.manifest.YAML
+	if mv.managedFields {
+		yaml = mv.manifest.FullYAML
+	}
+	mv.lines = strings.Split(strings.TrimSuffix(yaml, "\n"), "\n")
+
+	offset := mv.viewport.YOffset
+	current := mv.currentResult
+	mv.findMatches(mv.lines)
+	if current < len(mv.searchResults) {
+		mv.currentResult = current
+	}
+	mv.render()
+	mv.viewport.SetYOffset(offset)
+}
+
+// search finds the query as it is typed, showing the first match
+func (mv *ManifestViewer) search() {
+	mv.findMatches(mv.lines)
+	mv.render()
+	mv.showMatch(&mv.viewport)
+}
+
+// render highlights the manifest into the viewport
+func (mv *ManifestViewer) render() {
+	current := -1
+	if len(mv.searchResults) > 0 {
+		current = mv.searchResults[mv.currentResult]
+	}
+
+	// Lines of a block scalar are text, however they look
+	text := blockScalarLines(mv.lines)
+
+	rendered := make([]string, len(mv.lines))
+	for i, line := range mv.lines {
+		switch {
+		case i == current:
+			rendered[i] = mv.highlightMatches(line, mv.styles.selected)
+		case mv.matches(line):
+			rendered[i] = mv.highlightMatches(line, mv.styles.match)
+		case text[i]:
+			rendered[i] = mv.styles.text.Render(line)
+		default:
+			rendered[i] = mv.highlightYAML(line)
+		}
+	}
+	mv.viewport.SetContent(strings.Join(rendered, "\n"))
+}
+
+// highlightYAML colours a line of YAML: keys, strings, numbers, booleans
+// and nulls, comments and the dashes of list items
+func (mv *ManifestViewer) highlightYAML(line string) string {
+	rest := strings.TrimLeft(line, " ")
+	var b strings.Builder
+	b.WriteString(line[:len(line)-len(rest)])
+
+	for strings.HasPrefix(rest, "- ") || rest == "-" {
+		b.WriteString(mv.styles.muted.Render("-"))
+		rest = strings.TrimPrefix(strings.TrimPrefix(rest, "-"), " ")
+		if rest != "" {
+			b.WriteString(" ")
+		}
+	}
+
+	if strings.HasPrefix(rest, "#") {
+		b.WriteString(mv.styles.muted.Render(rest))
+		return b.String()
+	}
+
+	if name, value, ok := strings.Cut(rest, ": "); ok && !strings.HasPrefix(rest, `"`) && !strings.HasPrefix(rest, "'") {
+		b.WriteString(mv.styles.key.Render(name) + mv.styles.muted.Render(":") + " " + mv.valueStyle(value).Render(value))
+		return b.String()
+	}
+	if name, ok := strings.CutSuffix(rest, ":"); ok {
+		b.WriteString(mv.styles.key.Render(name) + mv.styles.muted.Render(":"))
+		return b.String()
+	}
+
+	b.WriteString(mv.valueStyle(rest).Render(rest))
+	return b.String()
+}
+
+// valueStyle picks the style of a scalar by what it holds
+func (mv *ManifestViewer) valueStyle(value string) lipgloss.Style {
+	switch value {
+	case "true", "false", "null", "~":
+		return mv.styles.keyword
+	case "{}", "[]", "|", "|-", "|+", ">", ">-", ">+":
+		return mv.styles.muted
+	}
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		return mv.styles.number
+	}
+	return mv.styles.str
+}
+
+// blockScalarLines reports which lines are the text of a multi-line string:
+// the lines after one such as "script: |" that are indented further
+func blockScalarLines(lines []string) []bool {
+	text := make([]bool, len(lines))
+	blockIndent := -1
+	for i, line := range lines {
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		text[i] = blockIndent >= 0 && (indent > blockIndent || strings.TrimSpace(line) == "")
+		if text[i] {
+			continue
+		}
+		blockIndent = -1
+		if startsBlockScalar(line) {
+			blockIndent = indent
+		}
+	}
+	return text
+}
+
+// startsBlockScalar reports whether a line ends with the indicator of a
+// multi-line string, such as "script: |"
+func startsBlockScalar(line string) bool {
+	for _, indicator := range []string{"|", "|-", "|+", ">", ">-", ">+"} {
+		if strings.HasSuffix(line, ": "+indicator) || strings.HasSuffix(line, "- "+indicator) {
+			return true
+		}
+	}
+	return false
+}
+
+// View implements tea.Model interface
+func (mv *ManifestViewer) View() string {
+	title := "YAML: " + strings.ToLower(mv.request.Kind) + " " + mv.request.Namespace + "/" + mv.request.Name
+	if mv.managedFields {
+		title += " [managed fields]"
+	}
+	if mv.loading {
+		title += " (loading...)"
+	}
+
+	body := mv.viewport.View()
+	switch {
+	case mv.err != nil:
+		body = styles.ErrorMessageStyles(mv.theme, mv.width).Render(fmt.Sprintf("Error: %v", mv.err))
+	case mv.manifest == nil:
+		body = mv.styles.muted.Render("Loading manifest...")
+	}
+
+	footer := mv.searchBar(mv.styles.title)
+	if !mv.searchMode {
+		status := []string{fmt.Sprintf("%3.0f%%", mv.viewport.ScrollPercent()*100)}
+		if mv.searchQuery != "" {
+			status = append(status, fmt.Sprintf("Search: %s (%d matches)", mv.searchQuery, len(mv.searchResults)))
+		}
+		status = append(status, "/ search • n/N next/prev • m managed fields • e edit • r reload • esc back")
+		footer = mv.styles.muted.Render(strings.Join(status, " • "))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, mv.styles.title.Render(title), body, footer)
+}
+
+// Focus sets the component as focused
+func (mv *ManifestViewer) Focus() tea.Cmd {
+	mv.focused = true
+	return nil
+}
+
+// Blur removes focus from the component
+func (mv *ManifestViewer) Blur() tea.Cmd {
+	mv.focused = false
+	mv.exitSearchMode()
+	return nil
+}
+
+// IsFocused returns whether the component is focused
+func (mv *ManifestViewer) IsFocused() bool {
+	return mv.focused
+}
+
+// CapturingInput reports whether keys are being typed into the search bar
+func (mv *ManifestViewer) CapturingInput() bool {
+	return mv.searchMode
+}
+
+// SetSize updates the component size
+func (mv *ManifestViewer) SetSize(width, height int) {
+	mv.width = width
+	mv.height = height
+	mv.viewport.Width = width
+	mv.viewport.Height = max(height-2, 0)
+	mv.searchInput.Width = max(width-len(searchPrompt)-4, 1)
+}
+
+// GetSize returns the current component size
+func (mv *ManifestViewer) GetSize() (int, int) {
+	return mv.width, mv.height
+}
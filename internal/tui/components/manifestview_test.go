@@ -0,0 +1,189 @@
+package components
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"kubeoptic/internal/services"
+	"kubeoptic/internal/tui"
+)
+
+func TestManifestViewer(t *testing.T) {
+	request := tui.ManifestRequestedMsg{Kind: services.KindPod, Namespace: "payments", Name: "api-7d9f"}
+	yaml := `apiVersion: v1
+kind: Pod
+metadata:
+  labels:
+    app: api
+  name: api-7d9f
+  namespace: payments
+spec:
+  containers:
+  - args:
+    - --port=8080
+    command:
+    - /bin/sh
+    - -c
+    - |
+      echo image: not a key
+      exec /api
+    image: registry.example.com/api:1.4.2
+    name: api
+    ports:
+    - containerPort: 8080
+      protocol: TCP
+status:
+  phase: Running
+`
+	manifest := &services.Manifest{
+		Kind:      services.KindPod,
+		Namespace: "payments",
+		Name:      "api-7d9f",
+		YAML:      yaml,
+		FullYAML:  strings.Replace(yaml, "metadata:\n", "metadata:\n  managedFields:\n  - manager: kubelet\n", 1),
+	}
+
+	newViewer := func(height int) *ManifestViewer {
+		viewer := NewManifestViewer(100, height)
+		viewer.Update(request)
+		viewer.Update(tui.ManifestLoadedMsg{Manifest: manifest})
+		return viewer
+	}
+	typeKeys := func(viewer *ManifestViewer, keys ...string) {
+		for _, k := range keys {
+			msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+			switch k {
+			case "enter":
+				msg = tea.KeyMsg{Type: tea.KeyEnter}
+			case "esc":
+				msg = tea.KeyMsg{Type: tea.KeyEsc}
+			}
+			viewer.Update(msg)
+		}
+	}
+
+	t.Run("shows_manifest", func(t *testing.T) {
+		view := newViewer(40).View()
+		for _, want := range []string{"YAML: pod payments/api-7d9f", "apiVersion", "registry.example.com/api:1.4.2", "containerPort"} {
+			if !strings.Contains(view, want) {
+				t.Errorf("Expected %q in the view", want)
+			}
+		}
+	})
+
+	t.Run("managed_fields_hidden_by_default", func(t *testing.T) {
+		viewer := newViewer(40)
+		if strings.Contains(viewer.View(), "managedFields") {
+			t.Error("Expected managed fields to be left out")
+		}
+		typeKeys(viewer, "m")
+		if view := viewer.View(); !strings.Contains(view, "managedFields") || !strings.Contains(view, "[managed fields]") {
+			t.Error("Expected m to show managed fields")
+		}
+	})
+
+	t.Run("highlights_yaml", func(t *testing.T) {
+		viewer := newViewer(40)
+		if line := viewer.highlightYAML("    - containerPort: 8080"); !strings.Contains(line, "containerPort") || !strings.Contains(line, "8080") {
+			t.Errorf("Expected highlighting to keep the text, got %q", line)
+		}
+
+		// Text of a block scalar is not taken for keys
+		text := blockScalarLines(viewer.lines)
+		for i, line := range viewer.lines {
+			wantText := strings.Contains(line, "echo image") || strings.Contains(line, "exec /api")
+			if text[i] != wantText {
+				t.Errorf("Line %q: expected block scalar text %v", line, wantText)
+			}
+		}
+	})
+
+	t.Run("search", func(t *testing.T) {
+		viewer := newViewer(6)
+		typeKeys(viewer, "/", "P", "O", "R", "T")
+		if !viewer.CapturingInput() {
+			t.Fatal("Expected the search bar to take keys")
+		}
+		if viewer.searchQuery != "PORT" || len(viewer.searchResults) != 3 {
+			t.Fatalf("Expected 3 case-insensitive matches for PORT, got %v", viewer.searchResults)
+		}
+		if viewer.viewport.YOffset == 0 {
+			t.Error("Expected the first match to be scrolled into view")
+		}
+
+		typeKeys(viewer, "enter")
+		if viewer.CapturingInput() {
+			t.Error("Expected enter to close the search bar")
+		}
+
+		typeKeys(viewer, "n")
+		if viewer.currentResult != 1 {
+			t.Errorf("Expected n to go to the next match, at %d", viewer.currentResult)
+		}
+		typeKeys(viewer, "N", "N")
+		if viewer.currentResult != 2 {
+			t.Errorf("Expected N to wrap around to the last match, at %d", viewer.currentResult)
+		}
+
+		// Showing managed fields keeps the search
+		typeKeys(viewer, "m")
+		if len(viewer.searchResults) != 3 || viewer.currentResult != 2 {
+			t.Errorf("Expected the search to survive a refresh, got %v at %d", viewer.searchResults, viewer.currentResult)
+		}
+	})
+
+	t.Run("reload_and_reset", func(t *testing.T) {
+		viewer := newViewer(6)
+		typeKeys(viewer, "G")
+
+		_, cmd := viewer.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+		if cmd == nil || cmd() != request {
+			t.Fatal("Expected r to request the manifest again")
+		}
+		viewer.Update(request)
+		if viewer.manifest == nil || !viewer.viewport.AtBottom() {
+			t.Error("Expected a reload to keep the manifest and the scroll position")
+		}
+
+		viewer.Update(tui.ManifestRequestedMsg{Kind: services.KindDeployment, Namespace: "payments", Name: "api"})
+		if viewer.manifest != nil || viewer.viewport.YOffset != 0 {
+			t.Error("Expected another resource to start empty at the top")
+		}
+	})
+
+	t.Run("edit", func(t *testing.T) {
+		viewer := newViewer(20)
+		_, cmd := viewer.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+		if cmd == nil {
+			t.Fatal("Expected e to ask to edit the resource")
+		}
+		want := tui.EditRequestedMsg{Kind: services.KindPod, Namespace: "payments", Name: "api-7d9f"}
+		if got := cmd(); got != want {
+			t.Errorf("Expected %+v, got %+v", want, got)
+		}
+	})
+
+	t.Run("shows_error", func(t *testing.T) {
+		viewer := newViewer(20)
+		viewer.Update(tui.ManifestLoadedMsg{Error: errors.New(`pods "api-7d9f" not found`)})
+		if view := viewer.View(); !strings.Contains(view, "not found") {
+			t.Errorf("Expected the error in the view, got:\n%s", view)
+		}
+	})
+}
+
+func TestTextSearchHighlightMatches(t *testing.T) {
+	search := newTextSearch("")
+	search.searchQuery = "port"
+	style := lipgloss.NewStyle().Bold(true)
+
+	got := search.highlightMatches("containerPort: 8080 # port", style)
+	want := "container" + style.Render("Port") + ": 8080 # " + style.Render("port")
+	if got != want {
+		t.Errorf("Expected every match highlighted as written, got %q", got)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"kubeoptic/internal/models"
+	"kubeoptic/internal/services"
 	"kubeoptic/internal/tui"
 	"kubeoptic/internal/tui/styles"
 )
@@ -17,6 +18,12 @@ import (
 type namespaceItem struct {
 	name   string
 	status string
+
+	// Resource usage of the namespace's pods and of every namespace, known
+	// while the cluster serves metrics
+	usage    services.ResourceAmounts
+	total    services.ResourceAmounts
+	hasUsage bool
 }
 
 // FilterValue implements list.Item
@@ -87,6 +94,10 @@ func (d namespaceDelegate) Render(w io.Writer, m list.Model, index int, listItem
 
 	// Render with proper spacing
 	content := nameText + statusText
+	if item.hasUsage {
+		content += statusStyle.Render(fmt.Sprintf("  %s %s ", formatCPU(item.usage.CPU), formatMemory(item.usage.Memory))) +
+			namespaceUsageBar(item, d.theme)
+	}
 	if index == m.Index() {
 		content = lipgloss.NewStyle().
 			Background(styles.Selection).
@@ -97,6 +108,12 @@ func (d namespaceDelegate) Render(w io.Writer, m list.Model, index int, listItem
 	fmt.Fprint(w, content)
 }
 
+// namespaceUsageBar shows the namespace's share of the memory used in the
+// cluster
+func namespaceUsageBar(item namespaceItem, theme styles.Theme) string {
+	return styles.CreateProgressBar(int(item.usage.Memory), int(item.total.Memory), podUsageBarWidth, theme)
+}
+
 // NamespaceList represents the namespace list component
 type NamespaceList struct {
 	list      list.Model
@@ -133,20 +150,39 @@ func NewNamespaceList(kubeoptic *models.Kubeoptic) *NamespaceList {
 	}
 }
 
-// LoadNamespaces loads namespaces and updates the list
+// LoadNamespaces loads namespaces and updates the list, keeping the
+// selected namespace and any active filter
 func (nl *NamespaceList) LoadNamespaces() tea.Cmd {
 	namespaces := nl.kubeoptic.GetNamespaces()
 	items := make([]list.Item, len(namespaces))
 
+	usage := nl.kubeoptic.GetNamespaceUsage()
+	var total services.ResourceAmounts
+	for _, u := range usage {
+		total = total.Add(u)
+	}
+
 	for i, ns := range namespaces {
+		_, hasUsage := usage[ns.Name]
 		items[i] = namespaceItem{
-			name:   ns.Name,
-			status: string(ns.Status),
+			name:     ns.Name,
+			status:   string(ns.Status),
+			usage:    usage[ns.Name],
+			total:    total,
+			hasUsage: hasUsage,
 		}
 	}
 
-	cmd := nl.list.SetItems(items)
-	return cmd
+	replaceItems(&nl.list, items, namespaceItemKey)
+	return nil
+}
+
+// namespaceItemKey identifies a namespace across updates, whatever its status
+func namespaceItemKey(item list.Item) string {
+	if ns, ok := item.(namespaceItem); ok {
+		return ns.name
+	}
+	return ""
 }
 
 // Init implements tea.Model
@@ -173,23 +209,10 @@ func (nl *NamespaceList) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		switch msg.String() {
 		case "enter":
 			// Handle namespace selection
+			// Pods load in the background so the UI stays responsive
 			if selectedItem := nl.list.SelectedItem(); selectedItem != nil {
 				item := selectedItem.(namespaceItem)
-				return nl, tea.Batch(
-					func() tea.Msg {
-						err := nl.kubeoptic.SelectNamespace(item.name)
-						if err != nil {
-							return tui.ErrorMsg{
-								Error:   err,
-								Context: "selecting namespace",
-							}
-						}
-						return tui.NamespacesLoadedMsg{
-							Namespaces: []string{item.name},
-							Error:      nil,
-						}
-					},
-				)
+				return nl, nl.kubeoptic.SelectNamespaceCmd(item.name)
 			}
 
 		case "r", "ctrl+r":
@@ -224,6 +247,21 @@ func (nl *NamespaceList) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return nl, nl.LoadNamespaces()
 
+	case tui.MetricsLoadedMsg:
+		return nl, nl.LoadNamespaces()
+
+	case tui.LoadingStartedMsg:
+		if msg.Component == tui.LoadingNamespaces {
+			return nl, nl.list.StartSpinner()
+		}
+		return nl, nil
+
+	case tui.LoadingCompletedMsg:
+		if msg.Component == tui.LoadingNamespaces {
+			nl.list.StopSpinner()
+		}
+		return nl, nil
+
 	case tui.FocusChangedMsg:
 		nl.focused = msg.Focused && msg.Component == "namespacelist"
 		if nl.focused {
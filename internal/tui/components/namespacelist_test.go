@@ -8,6 +8,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 
 	"kubeoptic/internal/models"
 	"kubeoptic/internal/services"
@@ -53,6 +54,10 @@ func (m *namespaceListMockPodService) GetPodLogs(ctx context.Context, podName, n
 	return nil, nil
 }
 
+func (m *namespaceListMockPodService) StreamPodLogs(ctx context.Context, podName, namespace string, options services.LogOptions) (io.ReadCloser, error) {
+	return nil, nil
+}
+
 // Mock config service for testing namespace list
 type namespaceListMockConfigService struct{}
 
@@ -64,6 +69,22 @@ func (m *namespaceListMockConfigService) LoadContexts(configPath string) ([]serv
 	return []services.Context{{Name: "test-context"}}, "test-context", nil, nil
 }
 
+func (m *namespaceListMockConfigService) ClientForContext(configPath, contextName string) (*kubernetes.Clientset, error) {
+	return nil, nil
+}
+
+func (m *namespaceListMockConfigService) CredentialFingerprint(configPath, contextName string) (string, error) {
+	return "", nil
+}
+
+func (m *namespaceListMockConfigService) RESTConfigForContext(configPath, contextName string) (*rest.Config, error) {
+	return nil, nil
+}
+
+func (m *namespaceListMockConfigService) NamespaceForContext(configPath, contextName string) (string, error) {
+	return "default", nil
+}
+
 func createTestKubeopticForNamespaceList(namespaces []services.Namespace) *models.Kubeoptic {
 	namespaceSvc := &namespaceListMockNamespaceService{namespaces: namespaces}
 	podSvc := &namespaceListMockPodService{}
@@ -173,6 +194,39 @@ func TestNamespaceListFiltering(t *testing.T) {
 	}
 }
 
+func TestNamespaceListLiveUpdates(t *testing.T) {
+	namespaces := []services.Namespace{
+		{Name: "default", Status: services.NamespaceActive},
+		{Name: "kube-system", Status: services.NamespaceActive},
+		{Name: "my-app", Status: services.NamespaceActive},
+	}
+
+	kubeoptic := createTestKubeopticForNamespaceList(namespaces)
+	kubeoptic.SetNamespaces(namespaces)
+
+	nl := NewNamespaceList(kubeoptic)
+	nl.SetSize(80, 24)
+	nl.LoadNamespaces()
+	nl.list.Select(2) // my-app
+
+	// A namespace is created before the selection and another starts terminating
+	kubeoptic.SetNamespaces([]services.Namespace{
+		{Name: "batch", Status: services.NamespaceActive},
+		{Name: "default", Status: services.NamespaceActive},
+		{Name: "kube-system", Status: services.NamespaceActive},
+		{Name: "my-app", Status: services.NamespaceTerminating},
+	})
+	nl.Update(tui.NamespacesLoadedMsg{Detailed: kubeoptic.GetNamespaces()})
+
+	selected, ok := nl.list.SelectedItem().(namespaceItem)
+	if !ok || selected.name != "my-app" {
+		t.Fatalf("Expected selection to stay on my-app, got %v", nl.list.SelectedItem())
+	}
+	if selected.status != string(services.NamespaceTerminating) {
+		t.Errorf("Expected updated status Terminating, got %s", selected.status)
+	}
+}
+
 func TestNamespaceListKeyHandling(t *testing.T) {
 	namespaces := []services.Namespace{
 		{Name: "default", Status: services.NamespaceActive},
@@ -0,0 +1,490 @@
+package components
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"k8s.io/apimachinery/pkg/util/duration"
+
+	"kubeoptic/internal/services"
+	"kubeoptic/internal/tui"
+	"kubeoptic/internal/tui/styles"
+)
+
+// describeRecentEvents is how many of the newest events the describe view shows
+const describeRecentEvents = 20
+
+const (
+	timelineTimeWidth    = 19
+	timelineAgeWidth     = 8
+	timelineSubjectWidth = 24
+)
+
+// PodDescribe shows what kubectl describe pod shows, or the pod's timeline,
+// in a scrollable viewport using the log viewer's key bindings
+type PodDescribe struct {
+	viewport    viewport.Model
+	keyMap      LogViewerKeyMap
+	description *services.PodDescription
+	pod         string
+	timeline    bool
+	loading     bool
+	err         error
+
+	focused bool
+	width   int
+	height  int
+
+	theme  styles.Theme
+	styles podDescribeStyles
+}
+
+type podDescribeStyles struct {
+	title   lipgloss.Style
+	section lipgloss.Style
+	label   lipgloss.Style
+	value   lipgloss.Style
+	muted   lipgloss.Style
+	good    lipgloss.Style
+	pending lipgloss.Style
+	bad     lipgloss.Style
+}
+
+// NewPodDescribe creates a new pod describe view
+func NewPodDescribe(width, height int) *PodDescribe {
+	theme := styles.DefaultTheme()
+	pd := &PodDescribe{
+		viewport: viewport.New(width, max(height-2, 0)),
+		keyMap:   DefaultLogViewerKeyMap(),
+		width:    width,
+		height:   height,
+		theme:    theme,
+		styles: podDescribeStyles{
+			title:   lipgloss.NewStyle().Bold(true).Foreground(theme.Primary).Padding(0, 1),
+			section: lipgloss.NewStyle().Bold(true).Foreground(theme.Secondary),
+			label:   lipgloss.NewStyle().Foreground(lipgloss.Color("245")),
+			value:   lipgloss.NewStyle().Foreground(lipgloss.Color("252")),
+			muted:   lipgloss.NewStyle().Foreground(lipgloss.Color("240")),
+			good:    lipgloss.NewStyle().Foreground(lipgloss.Color("46")),
+			pending: lipgloss.NewStyle().Foreground(lipgloss.Color("214")),
+			bad:     lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true),
+		},
+	}
+	return pd
+}
+
+// Init implements tea.Model interface
+func (pd *PodDescribe) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model interface
+func (pd *PodDescribe) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		pd.SetSize(msg.Width, msg.Height)
+		return pd, nil
+
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, pd.keyMap.Up):
+			pd.viewport.ScrollUp(1)
+		case key.Matches(msg, pd.keyMap.Down):
+			pd.viewport.ScrollDown(1)
+		case key.Matches(msg, pd.keyMap.PageUp):
+			pd.viewport.PageUp()
+		case key.Matches(msg, pd.keyMap.PageDown):
+			pd.viewport.PageDown()
+		case key.Matches(msg, pd.keyMap.Home):
+			pd.viewport.GotoTop()
+		case key.Matches(msg, pd.keyMap.End):
+			pd.viewport.GotoBottom()
+		case msg.String() == "t":
+			pd.showTimeline(!pd.timeline)
+		}
+		return pd, nil
+
+	case tui.DescribeRequestedMsg:
+		// A different pod starts from the top with nothing shown
+		if msg.Pod != nil && msg.Pod.Namespace+"/"+msg.Pod.Name != pd.pod {
+			pd.pod = msg.Pod.Namespace + "/" + msg.Pod.Name
+			pd.description = nil
+			pd.err = nil
+			pd.viewport.SetContent("")
+			pd.viewport.GotoTop()
+		}
+		if msg.Timeline != pd.timeline {
+			pd.showTimeline(msg.Timeline)
+		}
+		return pd, nil
+
+	case tui.PodDescribedMsg:
+		pd.err = msg.Error
+		if msg.Error == nil && msg.Description != nil {
+			pd.description = msg.Description
+			pd.refresh()
+		}
+		return pd, nil
+
+	case tui.LoadingStartedMsg:
+		if msg.Component == tui.LoadingDescribe {
+			pd.loading = true
+		}
+		return pd, nil
+
+	case tui.LoadingCompletedMsg:
+		if msg.Component == tui.LoadingDescribe {
+			pd.loading = false
+		}
+		return pd, nil
+	}
+
+	return pd, nil
+}
+
+// showTimeline switches between the details and the timeline, starting
+// from the top
+func (pd *PodDescribe) showTimeline(timeline bool) {
+	pd.timeline = timeline
+	pd.viewport.GotoTop()
+	pd.refresh()
+}
+
+// refresh renders the description into the viewport, keeping the scroll
+// position across live updates
+func (pd *PodDescribe) refresh() {
+	if pd.description == nil {
+		return
+	}
+	offset := pd.viewport.YOffset
+	if pd.timeline {
+		pd.viewport.SetContent(pd.renderTimeline(*pd.description, time.Now()))
+	} else {
+		pd.viewport.SetContent(pd.render(*pd.description, time.Now()))
+	}
+	pd.viewport.SetYOffset(offset)
+}
+
+// View implements tea.Model interface
+func (pd *PodDescribe) View() string {
+	title := "Describe: " + pd.pod
+	if pd.timeline {
+		title = "Timeline: " + pd.pod
+	}
+	if pd.loading {
+		title += " (loading...)"
+	}
+
+	body := pd.viewport.View()
+	switch {
+	case pd.err != nil:
+		body = styles.ErrorMessageStyles(pd.theme, pd.width).Render(fmt.Sprintf("Error: %v", pd.err))
+	case pd.description == nil:
+		body = pd.styles.muted.Render("Loading pod details...")
+	}
+
+	mode := "t timeline"
+	if pd.timeline {
+		mode = "t details"
+	}
+	help := pd.styles.muted.Render(fmt.Sprintf("%3.0f%% • ↑/↓ scroll • pgup/pgdn page • g/G top/bottom • %s • esc back",
+		pd.viewport.ScrollPercent()*100, mode))
+
+	return lipgloss.JoinVertical(lipgloss.Left, pd.styles.title.Render(title), body, help)
+}
+
+// render formats a description section by section like kubectl describe
+func (pd *PodDescribe) render(d services.PodDescription, now time.Time) string {
+	var b describeBuilder
+	b.styles = pd.styles
+
+	pod := d.Pod
+	b.field(0, "Name", pod.Name)
+	b.field(0, "Namespace", pod.Namespace)
+	if d.PriorityClass != "" {
+		b.field(0, "Priority Class", d.PriorityClass)
+	}
+	b.field(0, "Service Account", d.ServiceAccount)
+	node := valueOrNone(pod.NodeName)
+	if d.HostIP != "" {
+		node += "/" + d.HostIP
+	}
+	b.field(0, "Node", node)
+	if !d.StartTime.IsZero() {
+		b.field(0, "Start Time", fmt.Sprintf("%s (%s ago)", d.StartTime.Format(time.RFC1123Z), duration.HumanDuration(now.Sub(d.StartTime))))
+	}
+	b.mapField(0, "Labels", pod.Labels)
+	b.mapField(0, "Annotations", d.Annotations)
+	b.styledField(0, "Status", podStatusText(pod), pd.statusStyle(podStatusText(pod)))
+	b.field(0, "IP", valueOrNone(pod.PodIP))
+	if !pod.Owner.IsZero() {
+		b.field(0, "Controlled By", pod.Owner.Kind+"/"+pod.Owner.Name)
+	}
+
+	if len(d.InitContainers) > 0 {
+		b.section("Init Containers")
+		for _, container := range d.InitContainers {
+			pd.renderContainer(&b, container, now)
+		}
+	}
+	b.section("Containers")
+	for _, container := range d.Containers {
+		pd.renderContainer(&b, container, now)
+	}
+	if len(d.EphemeralContainers) > 0 {
+		b.section("Ephemeral Containers")
+		for _, container := range d.EphemeralContainers {
+			pd.renderContainer(&b, container, now)
+		}
+	}
+
+	b.section("Conditions")
+	if len(d.Conditions) == 0 {
+		b.line(1, b.styles.muted.Render("<none>"))
+	}
+	for _, condition := range d.Conditions {
+		style := pd.styles.good
+		if condition.Status != "True" {
+			style = pd.styles.pending
+		}
+		text := fitCell(condition.Type, 28) + " " + style.Render(condition.Status)
+		if condition.Reason != "" {
+			text += " " + pd.styles.muted.Render(condition.Reason)
+		}
+		b.line(1, text)
+	}
+
+	b.field(0, "QoS Class", valueOrNone(pod.QOSClass))
+	b.mapField(0, "Node-Selectors", d.NodeSelector)
+	b.listField(0, "Tolerations", d.Tolerations)
+
+	b.section("Events")
+	events := d.Events
+	if len(events) > describeRecentEvents {
+		events = events[len(events)-describeRecentEvents:]
+	}
+	if len(events) == 0 {
+		b.line(1, b.styles.muted.Render("<none>"))
+	} else {
+		b.line(1, pd.styles.label.Render(fitCell("Type", 8)+" "+fitCell("Reason", 20)+" "+fitCell("Age", 14)+" Message"))
+	}
+	for _, event := range events {
+		style := pd.styles.value
+		if event.IsWarning() {
+			style = pd.styles.bad
+		}
+		b.line(1, style.Render(fitCell(event.Type, 8)+" "+fitCell(event.Reason, 20))+" "+
+			pd.styles.muted.Render(fitCell(eventAge(event, now), 14))+" "+pd.styles.value.Render(event.Message))
+	}
+
+	return b.String()
+}
+
+// renderTimeline lists what happened to the pod oldest first, with the time
+// elapsed since the previous entry
+func (pd *PodDescribe) renderTimeline(d services.PodDescription, now time.Time) string {
+	entries := d.Timeline()
+	if len(entries) == 0 {
+		return pd.styles.muted.Render("Nothing is known about the pod's history")
+	}
+
+	var b describeBuilder
+	b.styles = pd.styles
+	b.line(0, pd.styles.label.Render(fitCell("TIME", timelineTimeWidth)+" "+fitCell("AGE", timelineAgeWidth)+" "+
+		fitCell("+GAP", timelineAgeWidth)+" "+fitCell("SUBJECT", timelineSubjectWidth)+" WHAT"))
+
+	for i, entry := range entries {
+		gap := ""
+		if i > 0 {
+			gap = "+" + duration.HumanDuration(entry.Time.Sub(entries[i-1].Time))
+		}
+		style := pd.styles.value
+		if entry.Warning {
+			style = pd.styles.bad
+		}
+
+		b.line(0, pd.styles.muted.Render(fitCell(entry.Time.Local().Format(time.DateTime), timelineTimeWidth)+" "+
+			fitCell(duration.HumanDuration(now.Sub(entry.Time)), timelineAgeWidth)+" ")+
+			pd.styles.label.Render(fitCell(gap, timelineAgeWidth))+" "+
+			pd.styles.value.Render(fitCell(entry.Subject, timelineSubjectWidth))+" "+
+			style.Render(entry.What))
+		if entry.Detail != "" {
+			b.line(0, strings.Repeat(" ", timelineTimeWidth+2*timelineAgeWidth+timelineSubjectWidth+4)+pd.styles.muted.Render(entry.Detail))
+		}
+	}
+	return b.String()
+}
+
+func (pd *PodDescribe) renderContainer(b *describeBuilder, c services.ContainerDescription, now time.Time) {
+	b.line(1, pd.styles.section.Render(c.Name+":"))
+	b.field(2, "Image", c.Image)
+	if c.Target != "" {
+		b.field(2, "Target Container", c.Target)
+	}
+	b.listField(2, "Ports", c.Ports)
+	pd.renderState(b, "State", c.State, now)
+	if c.LastState.State != "" {
+		pd.renderState(b, "Last State", c.LastState, now)
+	}
+	ready := "False"
+	if c.Ready {
+		ready = "True"
+	}
+	b.field(2, "Ready", ready)
+	restarts := fmt.Sprintf("%d", c.RestartCount)
+	if c.RestartCount > 0 {
+		b.styledField(2, "Restart Count", restarts, pd.styles.pending)
+	} else {
+		b.field(2, "Restart Count", restarts)
+	}
+	b.mapField(2, "Requests", c.Requests)
+	b.mapField(2, "Limits", c.Limits)
+
+	b.line(2, pd.styles.label.Render("Environment:"))
+	if len(c.Env) == 0 {
+		b.line(3, pd.styles.muted.Render("<none>"))
+	}
+	for _, env := range c.Env {
+		value := env.Value
+		if env.Ref != "" {
+			value = "<set to the " + env.Ref + ">"
+		}
+		b.line(3, pd.styles.label.Render(env.Name+":")+" "+pd.styles.value.Render(value))
+	}
+	if len(c.EnvFrom) > 0 {
+		b.listField(2, "Environment From", c.EnvFrom)
+	}
+	b.listField(2, "Mounts", c.Mounts)
+}
+
+func (pd *PodDescribe) renderState(b *describeBuilder, label string, state services.ContainerState, now time.Time) {
+	if state.State == "" {
+		b.field(2, label, "<none>")
+		return
+	}
+
+	style := pd.styles.good
+	switch {
+	case state.State == "Waiting":
+		style = pd.styles.pending
+	case state.State == "Terminated" && state.ExitCode != 0:
+		style = pd.styles.bad
+	}
+	b.styledField(2, label, state.State, style)
+	if state.Reason != "" {
+		b.styledField(3, "Reason", state.Reason, style)
+	}
+	if state.Message != "" {
+		b.field(3, "Message", state.Message)
+	}
+	if state.State == "Terminated" {
+		b.field(3, "Exit Code", fmt.Sprintf("%d", state.ExitCode))
+	}
+	if !state.StartedAt.IsZero() {
+		b.field(3, "Started", fmt.Sprintf("%s (%s ago)", state.StartedAt.Format(time.RFC1123Z), duration.HumanDuration(now.Sub(state.StartedAt))))
+	}
+	if !state.FinishedAt.IsZero() {
+		b.field(3, "Finished", state.FinishedAt.Format(time.RFC1123Z))
+	}
+}
+
+// statusStyle colors a pod status like the pod list does
+func (pd *PodDescribe) statusStyle(status string) lipgloss.Style {
+	switch status {
+	case "Running", "Completed", "Succeeded":
+		return pd.styles.good
+	case "Pending", "ContainerCreating", "PodInitializing", "Terminating":
+		return pd.styles.pending
+	}
+	if strings.HasPrefix(status, "Init:") && !strings.Contains(status, "Error") && !strings.Contains(status, "BackOff") {
+		return pd.styles.pending
+	}
+	return pd.styles.bad
+}
+
+// Focus sets the component as focused
+func (pd *PodDescribe) Focus() tea.Cmd {
+	pd.focused = true
+	return nil
+}
+
+// Blur removes focus from the component
+func (pd *PodDescribe) Blur() tea.Cmd {
+	pd.focused = false
+	return nil
+}
+
+// IsFocused returns whether the component is focused
+func (pd *PodDescribe) IsFocused() bool {
+	return pd.focused
+}
+
+// SetSize updates the component size
+func (pd *PodDescribe) SetSize(width, height int) {
+	pd.width = width
+	pd.height = height
+	pd.viewport.Width = width
+	pd.viewport.Height = max(height-2, 0)
+	pd.refresh()
+}
+
+// GetSize returns the current component size
+func (pd *PodDescribe) GetSize() (int, int) {
+	return pd.width, pd.height
+}
+
+// describeBuilder writes indented "Label: value" lines
+type describeBuilder struct {
+	strings.Builder
+	styles podDescribeStyles
+}
+
+func (b *describeBuilder) line(indent int, text string) {
+	b.WriteString(strings.Repeat("  ", indent) + text + "\n")
+}
+
+func (b *describeBuilder) section(title string) {
+	b.line(0, b.styles.section.Render(title+":"))
+}
+
+func (b *describeBuilder) field(indent int, label, value string) {
+	b.styledField(indent, label, value, b.styles.value)
+}
+
+func (b *describeBuilder) styledField(indent int, label, value string, style lipgloss.Style) {
+	b.line(indent, b.styles.label.Render(fitCell(label+":", max(18-2*indent, len(label)+1)))+" "+style.Render(value))
+}
+
+// mapField writes a map one key=value per line, in key order
+func (b *describeBuilder) mapField(indent int, label string, values map[string]string) {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	lines := make([]string, len(keys))
+	for i, key := range keys {
+		lines[i] = key + "=" + values[key]
+	}
+	b.listField(indent, label, lines)
+}
+
+// listField writes the first value after the label and the rest aligned below it
+func (b *describeBuilder) listField(indent int, label string, values []string) {
+	if len(values) == 0 {
+		b.field(indent, label, "<none>")
+		return
+	}
+	b.field(indent, label, values[0])
+	pad := strings.Repeat(" ", max(18-2*indent, len(label)+1)+1)
+	for _, value := range values[1:] {
+		b.line(indent, pad+b.styles.value.Render(value))
+	}
+}
@@ -0,0 +1,240 @@
+package components
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"kubeoptic/internal/services"
+	"kubeoptic/internal/tui"
+)
+
+func TestPodDescribe(t *testing.T) {
+	now := time.Now()
+	pod := services.Pod{Name: "api-7d9f", Namespace: "payments", DisplayStatus: "CrashLoopBackOff", NodeName: "node-a", QOSClass: "Burstable"}
+	description := &services.PodDescription{
+		Pod:       pod,
+		StartTime: now.Add(-time.Hour),
+		Containers: []services.ContainerDescription{{
+			Name:         "api",
+			Image:        "registry.example.com/api:1.4.2",
+			RestartCount: 7,
+			State:        services.ContainerState{State: "Waiting", Reason: "CrashLoopBackOff"},
+			LastState:    services.ContainerState{State: "Terminated", Reason: "Error", ExitCode: 137},
+			Ports:        []string{"8080/TCP (http)"},
+			Requests:     map[string]string{"cpu": "250m", "memory": "128Mi"},
+			Limits:       map[string]string{"memory": "256Mi"},
+			Env: []services.EnvVar{
+				{Name: "LOG_LEVEL", Value: "debug"},
+				{Name: "DB_PASSWORD", Ref: "key 'password' in secret 'db'"},
+			},
+			Mounts: []string{"/etc/api from config (ro)"},
+		}},
+		Conditions:  []services.PodCondition{{Type: "Ready", Status: "False", Reason: "ContainersNotReady"}},
+		Tolerations: []string{"node.kubernetes.io/not-ready:NoExecute op=Exists for 300s"},
+		Events: []services.Event{
+			{UID: "1", Type: "Normal", Reason: "Pulled", Message: "Container image already present", LastSeen: now.Add(-5 * time.Minute)},
+			{UID: "2", Type: "Warning", Reason: "BackOff", Message: "Back-off restarting failed container", Count: 12, LastSeen: now.Add(-3 * time.Minute)},
+		},
+	}
+
+	newDescribe := func(height int) *PodDescribe {
+		podDescribe := NewPodDescribe(120, height)
+		podDescribe.Update(tui.DescribeRequestedMsg{Pod: &pod})
+		podDescribe.Update(tui.PodDescribedMsg{Description: description})
+		return podDescribe
+	}
+
+	t.Run("renders_sections", func(t *testing.T) {
+		content := newDescribe(200).View()
+		for _, want := range []string{
+			"payments/api-7d9f", "node-a", "registry.example.com/api:1.4.2", "8080/TCP (http)",
+			"CrashLoopBackOff", "Exit Code", "137", "250m", "256Mi", "LOG_LEVEL", "debug",
+			"key 'password' in secret 'db'", "/etc/api from config (ro)", "ContainersNotReady",
+			"op=Exists for 300s", "Burstable", "BackOff", "Back-off restarting failed container",
+		} {
+			if !strings.Contains(content, want) {
+				t.Errorf("Expected %q in the description", want)
+			}
+		}
+	})
+
+	t.Run("events_oldest_first", func(t *testing.T) {
+		content := newDescribe(200).View()
+		if strings.Index(content, "Pulled") > strings.Index(content, "Back-off restarting") {
+			t.Error("Expected events in the order they happened, like kubectl describe")
+		}
+	})
+
+	t.Run("ephemeral_containers", func(t *testing.T) {
+		if strings.Contains(newDescribe(200).View(), "Ephemeral Containers") {
+			t.Error("Expected no ephemeral containers section for a pod without any")
+		}
+
+		debugged := *description
+		debugged.EphemeralContainers = []services.ContainerDescription{{
+			Name:   "debugger-x7k2p",
+			Image:  "busybox:1.36",
+			Target: "api",
+			State:  services.ContainerState{State: "Running", StartedAt: now.Add(-time.Minute)},
+		}}
+		podDescribe := NewPodDescribe(120, 200)
+		podDescribe.Update(tui.DescribeRequestedMsg{Pod: &pod})
+		podDescribe.Update(tui.PodDescribedMsg{Description: &debugged})
+		content := podDescribe.View()
+		for _, want := range []string{"Ephemeral Containers", "debugger-x7k2p:", "busybox:1.36", "Target Container"} {
+			if !strings.Contains(content, want) {
+				t.Errorf("Expected %q in the description", want)
+			}
+		}
+	})
+
+	t.Run("scrolls", func(t *testing.T) {
+		podDescribe := newDescribe(10)
+		top := podDescribe.View()
+
+		podDescribe.Update(tea.KeyMsg{Type: tea.KeyDown})
+		if podDescribe.viewport.YOffset != 1 {
+			t.Errorf("Expected down to scroll one line, offset is %d", podDescribe.viewport.YOffset)
+		}
+		podDescribe.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("G")})
+		if !podDescribe.viewport.AtBottom() {
+			t.Error("Expected G to scroll to the bottom")
+		}
+
+		// A live refresh keeps the scroll position
+		podDescribe.Update(tui.PodDescribedMsg{Description: description})
+		if !podDescribe.viewport.AtBottom() {
+			t.Error("Expected a refresh to keep the scroll position")
+		}
+
+		podDescribe.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("g")})
+		if podDescribe.View() != top {
+			t.Error("Expected g to scroll back to the top")
+		}
+	})
+
+	t.Run("new_pod_resets", func(t *testing.T) {
+		podDescribe := newDescribe(10)
+		podDescribe.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("G")})
+
+		other := services.Pod{Name: "worker-1", Namespace: "payments"}
+		podDescribe.Update(tui.DescribeRequestedMsg{Pod: &other})
+		view := podDescribe.View()
+		if strings.Contains(view, "registry.example.com/api") {
+			t.Error("Expected the previous pod's details to be cleared")
+		}
+		if !strings.Contains(view, "payments/worker-1") {
+			t.Error("Expected the title to name the new pod")
+		}
+		if podDescribe.viewport.YOffset != 0 {
+			t.Errorf("Expected a new pod to start at the top, offset is %d", podDescribe.viewport.YOffset)
+		}
+	})
+
+	t.Run("shows_error", func(t *testing.T) {
+		podDescribe := newDescribe(20)
+		podDescribe.Update(tui.PodDescribedMsg{Error: errors.New("pods \"api-7d9f\" not found")})
+		if view := podDescribe.View(); !strings.Contains(view, "not found") {
+			t.Errorf("Expected the error in the view, got:\n%s", view)
+		}
+	})
+
+	t.Run("caps_events", func(t *testing.T) {
+		many := *description
+		many.Events = nil
+		for i := range 30 {
+			many.Events = append(many.Events, services.Event{
+				UID: fmt.Sprint(i), Type: "Normal", Reason: fmt.Sprintf("Reason%02d", i), LastSeen: now.Add(time.Duration(i-30) * time.Minute),
+			})
+		}
+		podDescribe := NewPodDescribe(120, 300)
+		podDescribe.Update(tui.DescribeRequestedMsg{Pod: &pod})
+		podDescribe.Update(tui.PodDescribedMsg{Description: &many})
+
+		view := podDescribe.View()
+		if !strings.Contains(view, "Reason29") || strings.Contains(view, "Reason05") {
+			t.Error("Expected only the newest events")
+		}
+	})
+}
+
+func TestPodDescribeTimeline(t *testing.T) {
+	created := time.Now().Add(-time.Hour)
+	pod := services.Pod{Name: "api-7d9f", Namespace: "payments", NodeName: "node-a", CreatedAt: created}
+	description := &services.PodDescription{
+		Pod:       pod,
+		StartTime: created.Add(2 * time.Second),
+		Conditions: []services.PodCondition{
+			{Type: "PodScheduled", Status: "True", LastTransition: created.Add(time.Second)},
+			{Type: "Ready", Status: "False", Reason: "ContainersNotReady", LastTransition: created.Add(10 * time.Minute)},
+		},
+		Containers: []services.ContainerDescription{{
+			Name:         "api",
+			RestartCount: 3,
+			State:        services.ContainerState{State: "Waiting", Reason: "CrashLoopBackOff", Message: "back-off 40s restarting failed container"},
+			LastState: services.ContainerState{State: "Terminated", Reason: "OOMKilled", ExitCode: 137,
+				StartedAt: created.Add(9 * time.Minute), FinishedAt: created.Add(10 * time.Minute)},
+		}},
+		Events: []services.Event{
+			{UID: "1", Type: "Warning", Reason: "BackOff", Message: "Back-off restarting failed container", Count: 5,
+				FirstSeen: created.Add(10 * time.Minute), LastSeen: created.Add(20 * time.Minute)},
+		},
+	}
+
+	t.Run("chronological", func(t *testing.T) {
+		entries := description.Timeline()
+		want := []string{"Created", "PodScheduled=True", "Started on node-a", "Running",
+			"Ready=False (ContainersNotReady)", "Terminated: OOMKilled (exit code 137)", "Waiting: CrashLoopBackOff (restart 3)", "BackOff"}
+		if len(entries) != len(want) {
+			t.Fatalf("Expected %d entries, got %+v", len(want), entries)
+		}
+		for i, entry := range entries {
+			if !strings.HasPrefix(entry.What, want[i]) {
+				t.Errorf("Entry %d: expected %q, got %q", i, want[i], entry.What)
+			}
+			if i > 0 && entry.Time.Before(entries[i-1].Time) {
+				t.Errorf("Entry %d is out of order", i)
+			}
+		}
+		if !entries[5].Warning || entries[0].Warning {
+			t.Error("Expected failed runs and only those flagged as warnings")
+		}
+	})
+
+	t.Run("opened_from_pod_row", func(t *testing.T) {
+		podDescribe := NewPodDescribe(140, 40)
+		podDescribe.Update(tui.DescribeRequestedMsg{Pod: &pod, Timeline: true})
+		podDescribe.Update(tui.PodDescribedMsg{Description: description})
+
+		view := podDescribe.View()
+		for _, want := range []string{"Timeline: payments/api-7d9f", "+GAP", "+8m58s", "+10m", "container api", "OOMKilled",
+			"back-off 40s restarting failed container", "BackOff (x5 since"} {
+			if !strings.Contains(view, want) {
+				t.Errorf("Expected %q in the timeline", want)
+			}
+		}
+	})
+
+	t.Run("t_switches", func(t *testing.T) {
+		podDescribe := NewPodDescribe(140, 40)
+		podDescribe.Update(tui.DescribeRequestedMsg{Pod: &pod})
+		podDescribe.Update(tui.PodDescribedMsg{Description: description})
+		if strings.Contains(podDescribe.View(), "+GAP") {
+			t.Fatal("Expected the details first")
+		}
+
+		podDescribe.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+		if !strings.Contains(podDescribe.View(), "+GAP") {
+			t.Error("Expected t to show the timeline")
+		}
+		podDescribe.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+		if !strings.Contains(podDescribe.View(), "Describe: payments/api-7d9f") {
+			t.Error("Expected t to switch back to the details")
+		}
+	})
+}
@@ -0,0 +1,116 @@
+package components
+
+import (
+	"fmt"
+	"sort"
+
+	"kubeoptic/internal/services"
+)
+
+// WorkloadItem is the header of a group of pods owned by one workload
+type WorkloadItem struct {
+	Workload  services.Workload
+	Pods      []services.Pod
+	Collapsed bool
+}
+
+// FilterValue implements list.Item interface for filtering
+func (w WorkloadItem) FilterValue() string {
+	return w.Workload.Kind + " " + w.Workload.Name
+}
+
+// Title names the workload the way kubectl does, e.g. Deployment/api
+func (w WorkloadItem) Title() string {
+	if w.Workload.Kind == "" {
+		return "Standalone pods"
+	}
+	return w.Workload.Kind + "/" + w.Workload.Name
+}
+
+// Counts summarises the workload's pods. Replicated workloads show ready pods
+// against desired replicas; Jobs show completions and CronJobs active jobs.
+func (w WorkloadItem) Counts() string {
+	switch w.Workload.Kind {
+	case services.KindJob:
+		return fmt.Sprintf("%d/%d completed", w.Workload.Ready, w.Workload.Desired)
+	case services.KindCronJob:
+		return fmt.Sprintf("%d active", w.Workload.Ready)
+	}
+
+	ready := 0
+	for _, pod := range w.Pods {
+		if podReady(pod) {
+			ready++
+		}
+	}
+	desired := w.Workload.Desired
+	if w.Workload.Controllers == nil {
+		// Owners that weren't resolved have no known replica count
+		desired = len(w.Pods)
+	}
+	return fmt.Sprintf("%d/%d ready", ready, desired)
+}
+
+// key identifies the group across updates
+func (w WorkloadItem) key() string {
+	return "workload:" + w.Workload.Kind + "/" + w.Workload.Name
+}
+
+// podReady reports whether a pod is running with every container ready
+func podReady(pod services.Pod) bool {
+	return pod.Status == services.PodRunning && pod.TotalContainers > 0 &&
+		pod.ReadyContainers == pod.TotalContainers
+}
+
+// podGroup is a workload with the pods it owns
+type podGroup struct {
+	workload services.Workload
+	pods     []services.Pod
+}
+
+// groupPods assigns pods to the workloads that own them, keeping pod order.
+// Pods whose owner isn't among the workloads are grouped by that owner, and
+// pods without an owner come last as standalone pods. Workloads without pods
+// are left out.
+func groupPods(pods []services.Pod, workloads []services.Workload) []podGroup {
+	byController := make(map[services.OwnerRef]int)
+	for i, workload := range workloads {
+		for _, controller := range workload.Controllers {
+			byController[controller] = i
+		}
+	}
+
+	var groups []podGroup
+	index := make(map[string]int)
+	var standalone []services.Pod
+	for _, pod := range pods {
+		if pod.Owner.IsZero() {
+			standalone = append(standalone, pod)
+			continue
+		}
+
+		workload := services.Workload{Kind: pod.Owner.Kind, Name: pod.Owner.Name, Namespace: pod.Namespace}
+		if i, ok := byController[pod.Owner]; ok {
+			workload = workloads[i]
+		}
+		key := workload.Kind + "/" + workload.Name
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, podGroup{workload: workload})
+		}
+		groups[i].pods = append(groups[i].pods, pod)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].workload.Kind != groups[j].workload.Kind {
+			return groups[i].workload.Kind < groups[j].workload.Kind
+		}
+		return groups[i].workload.Name < groups[j].workload.Name
+	})
+	if len(standalone) > 0 {
+		groups = append(groups, podGroup{pods: standalone})
+	}
+	return groups
+}
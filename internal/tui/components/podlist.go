@@ -6,11 +6,14 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
 	"kubeoptic/internal/services"
 	"kubeoptic/internal/tui"
+	"kubeoptic/internal/tui/styles"
+	"kubeoptic/pkg/config"
 )
 
 // PodItem represents a pod in the list with required interfaces
@@ -20,7 +23,7 @@ type PodItem struct {
 
 // FilterValue implements list.Item interface for filtering
 func (p PodItem) FilterValue() string {
-	return fmt.Sprintf("%s %s %s", p.Pod.Name, p.Pod.Namespace, p.Pod.Status)
+	return fmt.Sprintf("%s %s %s", p.Pod.Name, p.Pod.Namespace, podStatusText(p.Pod))
 }
 
 // Title implements list.DefaultItem interface
@@ -36,11 +39,46 @@ func (p PodItem) Description() string {
 // PodList manages the pod list component
 type PodList struct {
 	list      list.Model
+	delegate  *podDelegate
 	pods      []services.Pod
+	namespace string
 	focused   bool
 	width     int
 	height    int
 	searching bool
+
+	// Table configuration; an empty sortBy keeps API order
+	columns        []podColumn
+	sortBy         string
+	sortDescending bool
+
+	// Grouping by owning workload; collapsed holds the keys of folded groups
+	groupByWorkload bool
+	workloads       []services.Workload
+	collapsed       map[string]bool
+
+	// Resource usage by pod key, nil while metrics are unavailable
+	usage map[string]services.ResourceAmounts
+
+	// Column picker state
+	choosingColumns bool
+	columnCursor    int
+
+	// Container and shell picker, shown while choosing where to open a shell
+	execPicker *execPicker
+
+	// Port picker, shown while choosing a port of the pod to forward
+	portPicker *portForwardPicker
+
+	// Debug container picker, and the image it suggests
+	debugPicker *debugPicker
+	debugImage  string
+
+	// Pod query box; activeQuery is the query the pods are filtered by
+	queryInput   textinput.Model
+	editingQuery bool
+	queryErr     error
+	activeQuery  string
 }
 
 // NewPodList creates a new pod list component
@@ -52,18 +90,29 @@ func NewPodList(pods []services.Pod, width, height int) *PodList {
 
 	// Create custom delegate for pod-specific styling
 	delegate := newPodDelegate()
+	delegate.columns = podColumns
 
-	l := list.New(items, delegate, width, height)
+	l := list.New(items, delegate, width, height-podTableHeaderHeight)
 	l.Title = "Pods"
 	l.SetShowStatusBar(true)
 	l.SetFilteringEnabled(true)
 	l.SetShowHelp(true)
 
+	queryInput := textinput.New()
+	queryInput.Prompt = "/ "
+	queryInput.Placeholder = "app=api status.phase=Running name"
+	queryInput.CharLimit = 256
+	queryInput.Width = max(width-len(queryInput.Prompt)-1, 1)
+
 	return &PodList{
-		list:   l,
-		pods:   pods,
-		width:  width,
-		height: height,
+		list:       l,
+		delegate:   delegate,
+		pods:       pods,
+		width:      width,
+		height:     height,
+		columns:    podColumns,
+		queryInput: queryInput,
+		collapsed:  make(map[string]bool),
 	}
 }
 
@@ -78,12 +127,131 @@ func (p *PodList) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
-		p.width = msg.Width
-		p.height = msg.Height
-		p.list.SetSize(p.width, p.height)
+		p.SetSize(msg.Width, msg.Height)
 		return p, nil
 
 	case tea.KeyMsg:
+		if p.execPicker != nil {
+			done, cmd := p.execPicker.handleKey(msg)
+			if done {
+				p.execPicker = nil
+			}
+			return p, cmd
+		}
+		if p.portPicker != nil {
+			done, cmd := p.portPicker.handleKey(msg)
+			if done {
+				p.portPicker = nil
+			}
+			return p, cmd
+		}
+		if p.debugPicker != nil {
+			done, cmd := p.debugPicker.handleKey(msg)
+			if done {
+				p.debugPicker = nil
+			}
+			return p, cmd
+		}
+		if p.choosingColumns {
+			return p, p.handleColumnPickerKey(msg)
+		}
+		if p.editingQuery {
+			return p, p.handleQueryKey(msg)
+		}
+
+		// Sorting and column keys would otherwise be typed into the filter
+		if p.list.FilterState() != list.Filtering {
+			if sortBy, ok := podSortKeys[msg.String()]; ok {
+				return p, p.toggleSort(sortBy)
+			}
+			if msg.String() == "c" {
+				p.choosingColumns = true
+				p.columnCursor = 0
+				return p, nil
+			}
+			if msg.String() == "w" {
+				p.groupByWorkload = !p.groupByWorkload
+				p.UpdatePods(p.pods)
+				return p, p.tableChanged()
+			}
+			if msg.String() == "y" {
+				if podItem, ok := p.list.SelectedItem().(PodItem); ok {
+					pod := podItem.Pod
+					return p, func() tea.Msg {
+						return tui.ManifestRequestedMsg{Kind: services.KindPod, Namespace: pod.Namespace, Name: pod.Name}
+					}
+				}
+				return p, nil
+			}
+			if msg.String() == "i" || msg.String() == "t" {
+				if podItem, ok := p.list.SelectedItem().(PodItem); ok {
+					pod := podItem.Pod
+					timeline := msg.String() == "t"
+					return p, func() tea.Msg {
+						return tui.DescribeRequestedMsg{Pod: &pod, Timeline: timeline}
+					}
+				}
+				return p, nil
+			}
+			if msg.String() == "s" {
+				if podItem, ok := p.list.SelectedItem().(PodItem); ok {
+					p.execPicker = newExecPicker(podItem.Pod)
+				}
+				return p, nil
+			}
+			if action, ok := podActionKeys[msg.String()]; ok {
+				if podItem, ok := p.list.SelectedItem().(PodItem); ok {
+					return p, requestPodAction(action, podItem.Pod)
+				}
+				return p, nil
+			}
+			if msg.String() == "p" {
+				if podItem, ok := p.list.SelectedItem().(PodItem); ok {
+					p.portPicker = newPortForwardPicker(podItem.Pod)
+				}
+				return p, nil
+			}
+			if msg.String() == "D" {
+				if podItem, ok := p.list.SelectedItem().(PodItem); ok {
+					image := p.debugImage
+					if image == "" {
+						image = config.DefaultDebugImage
+					}
+					p.debugPicker = newDebugPicker(podItem.Pod, image)
+				}
+				return p, nil
+			}
+			if msg.String() == "F" {
+				if podItem, ok := p.list.SelectedItem().(PodItem); ok {
+					pod := podItem.Pod
+					container := ""
+					if len(pod.Containers) > 0 {
+						container = pod.Containers[0]
+					}
+					return p, func() tea.Msg {
+						return tui.FilesRequestedMsg{Pod: &pod, Container: container, Path: "/"}
+					}
+				}
+				return p, nil
+			}
+			if msg.String() == "e" {
+				if podItem, ok := p.list.SelectedItem().(PodItem); ok {
+					pod := podItem.Pod
+					return p, func() tea.Msg {
+						return tui.EventsRequestedMsg{Pod: &pod}
+					}
+				}
+				return p, nil
+			}
+			if msg.String() == " " {
+				if group, ok := p.list.SelectedItem().(WorkloadItem); ok {
+					p.collapsed[group.key()] = !group.Collapsed
+					p.UpdatePods(p.pods)
+					return p, nil
+				}
+			}
+		}
+
 		// Handle special keys first
 		switch msg.String() {
 		case "enter":
@@ -94,16 +262,28 @@ func (p *PodList) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						return tui.PodSelectedMsg{Pod: &podItem.Pod}
 					}
 				}
+				// A workload header tails the logs of all its pods
+				if group, ok := selectedItem.(WorkloadItem); ok && len(group.Pods) > 0 {
+					return p, func() tea.Msg {
+						return tui.WorkloadSelectedMsg{Workload: &group.Workload, Pods: group.Pods}
+					}
+				}
 			}
 			return p, nil
 
 		case "/":
 			p.searching = true
-			p.list.SetFilteringEnabled(true)
-			// The list will automatically enter filtering mode when the user types
+			p.editingQuery = true
+			p.queryInput.SetValue(p.activeQuery)
+			p.queryInput.CursorEnd()
+			p.queryInput.Focus()
+			p.resizeList()
 			return p, nil
 
 		case "esc":
+			if p.activeQuery != "" {
+				return p, p.applyQuery("")
+			}
 			if p.list.FilterState() == list.Filtering {
 				p.searching = false
 				p.list.ResetFilter()
@@ -111,17 +291,55 @@ func (p *PodList) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return p, nil
 		}
 
+	case tui.LoadingStartedMsg:
+		if msg.Component == tui.LoadingPods {
+			return p, p.list.StartSpinner()
+		}
+		return p, nil
+
+	case tui.LoadingCompletedMsg:
+		if msg.Component == tui.LoadingPods {
+			p.list.StopSpinner()
+		}
+		return p, nil
+
 	case tui.PodsLoadedMsg:
 		if msg.Error != nil {
 			// Handle error - could add error state to component
 			return p, nil
 		}
+		// A different namespace starts from the top with no filter
+		if msg.Namespace != p.namespace {
+			p.namespace = msg.Namespace
+			p.workloads = nil
+			p.collapsed = make(map[string]bool)
+			p.list.ResetFilter()
+			p.list.ResetSelected()
+		}
+		// The query is cleared elsewhere, for instance by switching namespace
+		if msg.Query != p.activeQuery && !p.editingQuery {
+			p.SetSearchQuery(msg.Query)
+		}
 		p.UpdatePods(msg.Pods)
 		return p, nil
 
+	case tui.WorkloadsLoadedMsg:
+		if msg.Error == nil && msg.Namespace == p.namespace {
+			p.workloads = msg.Workloads
+			p.UpdatePods(p.pods)
+		}
+		return p, nil
+
+	case tui.MetricsLoadedMsg:
+		p.SetMetrics(msg.Metrics, msg.Error == nil)
+		return p, nil
+
 	case tui.SearchQueryChangedMsg:
-		// Update search query - let the list handle filtering automatically
-		p.list.SetFilteringEnabled(true)
+		p.SetSearchQuery(msg.Query)
+		return p, nil
+
+	case tui.ClearSearchMsg:
+		p.ClearSearch()
 		return p, nil
 	}
 
@@ -132,29 +350,344 @@ func (p *PodList) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // View implements tea.Model interface
 func (p *PodList) View() string {
+	if p.execPicker != nil {
+		return p.execPicker.view(p.width, p.delegate.styles)
+	}
+	if p.portPicker != nil {
+		return p.portPicker.view(p.width, p.delegate.styles)
+	}
+	if p.debugPicker != nil {
+		return p.debugPicker.view(p.width, p.delegate.styles)
+	}
+	if p.choosingColumns {
+		return p.columnPickerView()
+	}
+
 	if !p.focused {
 		// Add a subtle style for unfocused state
 		return lipgloss.NewStyle().
 			BorderStyle(lipgloss.NormalBorder()).
 			BorderForeground(lipgloss.Color("240")).
-			Render(p.list.View())
+			Render(p.tableView())
 	}
 
 	// Focused state with highlighted border
 	return lipgloss.NewStyle().
 		BorderStyle(lipgloss.RoundedBorder()).
 		BorderForeground(lipgloss.Color("86")).
-		Render(p.list.View())
+		Render(p.tableView())
+}
+
+// tableView renders the query and column headings above the pod rows
+func (p *PodList) tableView() string {
+	columns, nameWidth := podTableLayout(styles.NewLayoutConfig(p.list.Width(), p.list.Height()), p.delegate.columns)
+	header := fitCell("  NAME"+p.sortIndicator(sortByName), nameWidth)
+	for _, column := range columns {
+		header += " " + fitCell(column.title+p.sortIndicator(column.id), column.width)
+	}
+
+	headerStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Bold(true)
+	lines := p.queryLines()
+	lines = append(lines, headerStyle.Render(header), p.list.View())
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// queryLines renders the query box while editing, with any parse error
+// below it, or the query the pods are filtered by
+func (p *PodList) queryLines() []string {
+	if p.editingQuery {
+		lines := []string{p.queryInput.View()}
+		if p.queryErr != nil {
+			errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+			lines = append(lines, errorStyle.Render(fitCell(p.queryErr.Error(), max(p.width, 1))))
+		}
+		return lines
+	}
+	if p.activeQuery != "" {
+		queryStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("86"))
+		return []string{queryStyle.Render(fitCell("Query: "+p.activeQuery, max(p.width, 1)))}
+	}
+	return nil
+}
+
+// handleQueryKey edits the pod query, checking it as it is typed. Enter
+// applies a valid query and esc abandons the edit.
+func (p *PodList) handleQueryKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "enter":
+		if p.queryErr != nil {
+			return nil
+		}
+		return p.applyQuery(strings.TrimSpace(p.queryInput.Value()))
+
+	case "esc":
+		p.stopEditingQuery()
+		p.searching = p.activeQuery != ""
+		return nil
+	}
+
+	var cmd tea.Cmd
+	p.queryInput, cmd = p.queryInput.Update(msg)
+	_, p.queryErr = services.ParsePodQuery(p.queryInput.Value())
+	p.resizeList()
+	return cmd
+}
+
+// applyQuery filters the pods by a query, or lists them all for an empty one
+func (p *PodList) applyQuery(query string) tea.Cmd {
+	p.stopEditingQuery()
+	p.SetSearchQuery(query)
+	if query == "" {
+		return func() tea.Msg {
+			return tui.ClearSearchMsg{}
+		}
+	}
+	return func() tea.Msg {
+		return tui.SearchQueryChangedMsg{Query: query}
+	}
 }
 
-// UpdatePods updates the pod list with new data
+func (p *PodList) stopEditingQuery() {
+	p.editingQuery = false
+	p.queryErr = nil
+	p.queryInput.Blur()
+	p.resizeList()
+}
+
+// CapturingInput reports whether keys are being typed into the query box,
+// or are choosing where to open a shell, which port to forward, how to
+// debug the pod or which columns to show
+func (p *PodList) CapturingInput() bool {
+	return p.editingQuery || p.execPicker != nil || p.portPicker != nil || p.debugPicker != nil || p.choosingColumns
+}
+
+// resizeList gives the list the height left by the query and column headings
+func (p *PodList) resizeList() {
+	p.list.SetSize(p.width, p.height-podTableHeaderHeight-len(p.queryLines()))
+}
+
+// UpdatePods updates the pod list with new data, keeping the selected pod
+// and any active filter
 func (p *PodList) UpdatePods(pods []services.Pod) {
 	p.pods = pods
-	items := make([]list.Item, len(pods))
-	for i, pod := range pods {
-		items[i] = PodItem{Pod: pod}
+	sorted := sortPods(pods, p.sortBy, p.sortDescending, p.usage)
+	if !p.groupByWorkload {
+		items := make([]list.Item, len(sorted))
+		for i, pod := range sorted {
+			items[i] = PodItem{Pod: pod}
+		}
+		replaceItems(&p.list, items, podItemKey)
+		return
+	}
+
+	var items []list.Item
+	for _, group := range groupPods(sorted, p.workloads) {
+		header := WorkloadItem{Workload: group.workload, Pods: group.pods}
+		header.Collapsed = p.collapsed[header.key()]
+		items = append(items, header)
+		if header.Collapsed {
+			continue
+		}
+		for _, pod := range group.pods {
+			items = append(items, PodItem{Pod: pod})
+		}
+	}
+	replaceItems(&p.list, items, podItemKey)
+}
+
+// SetDebugImage sets the image debug containers run unless another is typed
+func (p *PodList) SetDebugImage(image string) {
+	p.debugImage = image
+}
+
+// SetTableConfig applies saved column and sort preferences
+func (p *PodList) SetTableConfig(cfg config.PodTable) {
+	p.setColumns(podColumnsByID(cfg.Columns))
+	p.sortBy = ""
+	for _, sortBy := range podSortKeys {
+		if cfg.SortBy == sortBy {
+			p.sortBy = sortBy
+		}
+	}
+	p.sortDescending = cfg.SortDescending
+	p.groupByWorkload = cfg.GroupByWorkload
+	p.UpdatePods(p.pods)
+}
+
+// TableConfig returns the current column and sort preferences
+func (p *PodList) TableConfig() config.PodTable {
+	return config.PodTable{
+		Columns:         podColumnIDs(p.columns),
+		SortBy:          p.sortBy,
+		SortDescending:  p.sortDescending,
+		GroupByWorkload: p.groupByWorkload,
+	}
+}
+
+// toggleSort sorts by a column, reversing the order when it is already sorted by it
+func (p *PodList) toggleSort(sortBy string) tea.Cmd {
+	if p.sortBy == sortBy {
+		p.sortDescending = !p.sortDescending
+	} else {
+		p.sortBy = sortBy
+		p.sortDescending = false
+	}
+	p.UpdatePods(p.pods)
+	return p.tableChanged()
+}
+
+// sortIndicator marks the heading of the column the table is sorted by
+func (p *PodList) sortIndicator(columnID string) string {
+	if columnID != p.sortBy {
+		return ""
+	}
+	if p.sortDescending {
+		return " ▼"
+	}
+	return " ▲"
+}
+
+// setColumns changes the visible columns of the table. While metrics are
+// available the usage columns follow RESTARTS, or come last without it.
+func (p *PodList) setColumns(columns []podColumn) {
+	p.columns = columns
+	if p.usage == nil {
+		p.delegate.columns = columns
+		return
+	}
+
+	usageColumns := podUsageColumns(p.podUsage, p.delegate.theme)
+	shown := make([]podColumn, 0, len(columns)+len(usageColumns))
+	for _, column := range columns {
+		shown = append(shown, column)
+		if column.id == "restarts" {
+			shown = append(shown, usageColumns...)
+			usageColumns = nil
+		}
+	}
+	p.delegate.columns = append(shown, usageColumns...)
+}
+
+// SetMetrics shows what pods currently use, or hides usage when metrics
+// aren't available
+func (p *PodList) SetMetrics(metrics []services.PodMetrics, available bool) {
+	p.usage = nil
+	if available {
+		p.usage = make(map[string]services.ResourceAmounts, len(metrics))
+		for _, m := range metrics {
+			p.usage[m.Namespace+"/"+m.Name] = m.Usage
+		}
+	}
+	p.setColumns(p.columns)
+	p.UpdatePods(p.pods)
+}
+
+// podUsage returns what a pod currently uses, if known
+func (p *PodList) podUsage(pod services.Pod) (services.ResourceAmounts, bool) {
+	usage, ok := p.usage[podKey(pod)]
+	return usage, ok
+}
+
+// tableChanged reports new table preferences so they can be saved
+func (p *PodList) tableChanged() tea.Cmd {
+	cfg := p.TableConfig()
+	return func() tea.Msg {
+		return tui.PodTableChangedMsg{
+			Columns:         cfg.Columns,
+			SortBy:          cfg.SortBy,
+			SortDescending:  cfg.SortDescending,
+			GroupByWorkload: cfg.GroupByWorkload,
+		}
 	}
-	p.list.SetItems(items)
+}
+
+// handleColumnPickerKey moves through the column picker and toggles columns
+func (p *PodList) handleColumnPickerKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "up", "k":
+		if p.columnCursor > 0 {
+			p.columnCursor--
+		}
+	case "down", "j":
+		if p.columnCursor < len(podColumns)-1 {
+			p.columnCursor++
+		}
+	case " ", "x":
+		p.toggleColumn(podColumns[p.columnCursor].id)
+	case "enter", "esc", "c":
+		p.choosingColumns = false
+		return p.tableChanged()
+	}
+	return nil
+}
+
+// toggleColumn shows or hides a column. A shown column goes after the
+// visible columns that precede it in the default order.
+func (p *PodList) toggleColumn(id string) {
+	ids := podColumnIDs(p.columns)
+	for i, visible := range ids {
+		if visible == id {
+			p.setColumns(podColumnsByID(append(ids[:i:i], ids[i+1:]...)))
+			return
+		}
+	}
+
+	position := 0
+	for _, column := range podColumns {
+		if column.id == id {
+			break
+		}
+		for i, visible := range ids {
+			if visible == column.id && i+1 > position {
+				position = i + 1
+			}
+		}
+	}
+	ids = append(ids[:position:position], append([]string{id}, ids[position:]...)...)
+	p.setColumns(podColumnsByID(ids))
+}
+
+// columnPickerView renders the list of columns with their visibility
+func (p *PodList) columnPickerView() string {
+	visible := make(map[string]bool)
+	for _, column := range p.columns {
+		visible[column.id] = true
+	}
+
+	titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("86")).Bold(true)
+	lines := []string{titleStyle.Render("Pod columns"), ""}
+	for i, column := range podColumns {
+		check := "[ ]"
+		if visible[column.id] {
+			check = "[x]"
+		}
+		line := fmt.Sprintf("%s %s", check, column.title)
+		if i == p.columnCursor {
+			line = p.delegate.styles.selected.Render("> " + line)
+		} else {
+			line = p.delegate.styles.normal.Render("  " + line)
+		}
+		lines = append(lines, line)
+	}
+	lines = append(lines, "", lipgloss.NewStyle().Foreground(lipgloss.Color("240")).
+		Render("space: toggle • enter/esc: done"))
+
+	return lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("86")).
+		Width(max(p.width-2, 0)).
+		Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// podItemKey identifies a pod or workload header across updates, whatever its status
+func podItemKey(item list.Item) string {
+	switch item := item.(type) {
+	case PodItem:
+		return podKey(item.Pod)
+	case WorkloadItem:
+		return item.key()
+	}
+	return ""
 }
 
 // GetSelectedPod returns the currently selected pod
@@ -187,7 +720,8 @@ func (p *PodList) IsFocused() bool {
 func (p *PodList) SetSize(width, height int) {
 	p.width = width
 	p.height = height
-	p.list.SetSize(width, height)
+	p.queryInput.Width = max(width-len(p.queryInput.Prompt)-1, 1)
+	p.resizeList()
 }
 
 // GetSize returns the current component size
@@ -195,23 +729,26 @@ func (p *PodList) GetSize() (int, int) {
 	return p.width, p.height
 }
 
-// SetSearchQuery sets the search query for filtering
+// SetSearchQuery sets the query the pods are filtered by. Filtering itself
+// happens in the model, which sends the matching pods.
 func (p *PodList) SetSearchQuery(query string) {
-	// This would trigger filtering - Bubble Tea list handles this internally
+	p.activeQuery = query
 	p.searching = query != ""
+	p.resizeList()
 }
 
 // GetSearchQuery returns the current search query
 func (p *PodList) GetSearchQuery() string {
-	if p.list.FilterState() == list.Filtering {
-		return p.list.FilterValue()
+	if p.editingQuery {
+		return p.queryInput.Value()
 	}
-	return ""
+	return p.activeQuery
 }
 
 // ClearSearch clears the current search
 func (p *PodList) ClearSearch() {
-	p.searching = false
+	p.stopEditingQuery()
+	p.SetSearchQuery("")
 	p.list.ResetFilter()
 }
 
@@ -226,8 +763,9 @@ func (p *PodList) GetSearchResults() []interface{} {
 
 // podDelegate creates a custom delegate for pod list items with status color coding
 type podDelegate struct {
-	styles     podDelegateStyles
-	showLabels bool
+	styles  podDelegateStyles
+	theme   styles.Theme
+	columns []podColumn
 }
 
 type podDelegateStyles struct {
@@ -238,10 +776,12 @@ type podDelegateStyles struct {
 	failed    lipgloss.Style
 	succeeded lipgloss.Style
 	unknown   lipgloss.Style
+	workload  lipgloss.Style
 }
 
 func newPodDelegate() *podDelegate {
 	return &podDelegate{
+		theme: styles.DefaultTheme(),
 		styles: podDelegateStyles{
 			normal:    lipgloss.NewStyle().Foreground(lipgloss.Color("252")),
 			selected:  lipgloss.NewStyle().Foreground(lipgloss.Color("86")).Bold(true),
@@ -250,19 +790,24 @@ func newPodDelegate() *podDelegate {
 			failed:    lipgloss.NewStyle().Foreground(lipgloss.Color("196")), // Red
 			succeeded: lipgloss.NewStyle().Foreground(lipgloss.Color("82")),  // Light green
 			unknown:   lipgloss.NewStyle().Foreground(lipgloss.Color("240")), // Gray
+			workload:  lipgloss.NewStyle().Foreground(lipgloss.Color("111")).Bold(true),
 		},
-		showLabels: true,
 	}
 }
 
-func (d *podDelegate) Height() int  { return 2 }
-func (d *podDelegate) Spacing() int { return 1 }
+func (d *podDelegate) Height() int  { return 1 }
+func (d *podDelegate) Spacing() int { return 0 }
 
 func (d *podDelegate) Update(msg tea.Msg, m *list.Model) tea.Cmd {
 	return nil
 }
 
 func (d *podDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	if group, ok := listItem.(WorkloadItem); ok {
+		d.renderWorkload(w, m, index, group)
+		return
+	}
+
 	podItem, ok := listItem.(PodItem)
 	if !ok {
 		return
@@ -270,41 +815,48 @@ func (d *podDelegate) Render(w io.Writer, m list.Model, index int, listItem list
 
 	pod := podItem.Pod
 	isSelected := index == m.Index()
+	health := podHealth(pod)
 
-	// Get status-specific style
-	statusStyle := d.getStatusStyle(pod.Status)
-
-	var nameStyle, descStyle lipgloss.Style
+	// Color the name and status by health; the selected row is highlighted instead
+	statusStyle := d.getStatusStyle(health)
+	nameStyle := statusStyle
+	cellStyle := d.styles.normal
 	if isSelected {
 		nameStyle = d.styles.selected
-		descStyle = d.styles.selected
-	} else {
-		nameStyle = statusStyle
-		descStyle = d.styles.normal
+		statusStyle = d.styles.selected
+		cellStyle = d.styles.selected
 	}
 
-	// Format the pod name with status indicator
-	statusIndicator := d.getStatusIndicator(pod.Status)
-	name := fmt.Sprintf("%s %s", statusIndicator, pod.Name)
-
-	// Format description with namespace and labels if available
-	desc := fmt.Sprintf("Namespace: %s", pod.Namespace)
-	if len(pod.Labels) > 0 && d.showLabels {
-		labelStrs := make([]string, 0, len(pod.Labels))
-		for k, v := range pod.Labels {
-			labelStrs = append(labelStrs, fmt.Sprintf("%s=%s", k, v))
+	columns, nameWidth := podTableLayout(styles.NewLayoutConfig(m.Width(), m.Height()), d.columns)
+	row := nameStyle.Render(fitCell(d.getStatusIndicator(health)+" "+pod.Name, nameWidth))
+	for _, column := range columns {
+		if column.cell != nil {
+			row += " " + cellStyle.Render(column.cell(pod, column.width))
+			continue
 		}
-		if len(labelStrs) > 0 {
-			desc += fmt.Sprintf(" | Labels: %s", strings.Join(labelStrs[:min(2, len(labelStrs))], ", "))
-			if len(labelStrs) > 2 {
-				desc += "..."
-			}
+		style := cellStyle
+		if column.id == "status" {
+			style = statusStyle
 		}
+		row += " " + style.Render(fitCell(column.value(pod), column.width))
+	}
+
+	fmt.Fprint(w, row)
+}
+
+// renderWorkload renders a group header with its pod counts
+func (d *podDelegate) renderWorkload(w io.Writer, m list.Model, index int, group WorkloadItem) {
+	marker := "▾"
+	if group.Collapsed {
+		marker = "▸"
 	}
 
-	// Render with proper width handling
-	w.Write([]byte(nameStyle.Render(name) + "\n"))
-	w.Write([]byte(descStyle.Render(desc)))
+	style := d.styles.workload
+	if index == m.Index() {
+		style = d.styles.selected
+	}
+	row := fmt.Sprintf("%s %s  %s", marker, group.Title(), group.Counts())
+	fmt.Fprint(w, style.Render(fitCell(row, max(m.Width(), 1))))
 }
 
 func (d *podDelegate) getStatusStyle(status services.PodStatus) lipgloss.Style {
@@ -344,3 +896,23 @@ func min(a, b int) int {
 	}
 	return b
 }
+
+// podActionKeys maps keys pressed in the pod list to the changes they ask
+// for, each confirmed before it is carried out. They stay clear of the
+// global keys, such as r to refresh and ctrl+d to scroll, and of the sort
+// keys, R sorting by restarts; B restarts as in "bounce".
+var podActionKeys = map[string]string{
+	"ctrl+x": tui.ActionDeletePod,
+	"X":      tui.ActionForceDeletePod,
+	"B":      tui.ActionRestart,
+}
+
+// requestPodAction asks to delete a pod, or to restart the workload that
+// owns it
+func requestPodAction(action string, pod services.Pod) tea.Cmd {
+	msg := tui.ActionRequestedMsg{Action: action, Namespace: pod.Namespace, Kind: services.KindPod, Name: pod.Name}
+	if action == tui.ActionRestart {
+		msg.Kind, msg.Name = pod.Owner.Kind, pod.Owner.Name
+	}
+	return func() tea.Msg { return msg }
+}
@@ -9,6 +9,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 
 	"kubeoptic/internal/models"
 	"kubeoptic/internal/services"
@@ -48,6 +49,10 @@ func (m *mockPodServiceIntegration) GetPodLogs(ctx context.Context, podName, nam
 	return io.NopCloser(strings.NewReader("mock log data")), nil
 }
 
+func (m *mockPodServiceIntegration) StreamPodLogs(ctx context.Context, podName, namespace string, options services.LogOptions) (io.ReadCloser, error) {
+	return io.NopCloser(strings.NewReader("mock log data")), nil
+}
+
 type mockNamespaceServiceIntegration struct {
 	namespaces []string
 }
@@ -80,6 +85,22 @@ func (m *mockConfigServiceIntegration) LoadContexts(configPath string) ([]servic
 	return contexts, "test-context", nil, nil
 }
 
+func (m *mockConfigServiceIntegration) ClientForContext(configPath, contextName string) (*kubernetes.Clientset, error) {
+	return nil, nil
+}
+
+func (m *mockConfigServiceIntegration) CredentialFingerprint(configPath, contextName string) (string, error) {
+	return "", nil
+}
+
+func (m *mockConfigServiceIntegration) RESTConfigForContext(configPath, contextName string) (*rest.Config, error) {
+	return nil, nil
+}
+
+func (m *mockConfigServiceIntegration) NamespaceForContext(configPath, contextName string) (string, error) {
+	return "default", nil
+}
+
 // Integration test data
 var integrationTestPods = []services.Pod{
 	{
@@ -108,6 +129,17 @@ var integrationTestPods = []services.Pod{
 	},
 }
 
+// applyPodsLoaded fetches the pods of the model's namespace and search
+// query and hands them to the model, as the app does with a pod loader's result
+func applyPodsLoaded(kubeoptic *models.Kubeoptic) error {
+	msg, ok := kubeoptic.FetchPodsCmd()().(tui.PodsLoadedMsg)
+	if !ok {
+		return fmt.Errorf("no pods loaded")
+	}
+	kubeoptic.ApplyPods(msg)
+	return msg.Error
+}
+
 func TestPodListIntegration(t *testing.T) {
 	// Setup mock services
 	podSvc := &mockPodServiceIntegration{pods: integrationTestPods}
@@ -123,8 +155,8 @@ func TestPodListIntegration(t *testing.T) {
 		podList.Focus()
 
 		// Set namespace so kubeoptic can search for pods
-		err := kubeoptic.SelectNamespace("production")
-		if err != nil {
+		kubeoptic.SelectNamespaceCmd("production")
+		if err := applyPodsLoaded(kubeoptic); err != nil {
 			t.Errorf("Failed to select namespace: %v", err)
 		}
 
@@ -200,8 +232,8 @@ func TestPodListIntegration(t *testing.T) {
 		}
 
 		// Simulate kubeoptic search
-		err := kubeoptic.SearchPods("web")
-		if err != nil {
+		kubeoptic.SearchPodsCmd("web")
+		if err := applyPodsLoaded(kubeoptic); err != nil {
 			t.Errorf("Kubeoptic search failed: %v", err)
 		}
 
@@ -237,8 +269,8 @@ func TestPodListIntegration(t *testing.T) {
 		}
 
 		// Verify component can handle status-based filtering
-		err := kubeoptic.SearchPods("Running")
-		if err != nil {
+		kubeoptic.SearchPodsCmd("Running")
+		if err := applyPodsLoaded(kubeoptic); err != nil {
 			t.Errorf("Status-based search failed: %v", err)
 		}
 
@@ -429,3 +461,68 @@ func BenchmarkPodListIntegration(b *testing.B) {
 		podList.Update(msg)
 	}
 }
+
+// TestPodListColumnPickerInApp checks the app leaves esc and q to an open
+// column picker instead of navigating back or quitting
+func TestPodListColumnPickerInApp(t *testing.T) {
+	kubeoptic := models.NewKubeoptic(&mockConfigServiceIntegration{}, &mockPodServiceIntegration{pods: integrationTestPods}, &mockNamespaceServiceIntegration{namespaces: []string{"production"}})
+	kubeoptic.SelectNamespaceCmd("production")
+	if err := applyPodsLoaded(kubeoptic); err != nil {
+		t.Fatal(err)
+	}
+
+	podList := NewPodList(kubeoptic.GetPods(), 100, 30)
+	app := tui.NewApp(kubeoptic)
+	app.SetComponents(nil, nil, podList, nil, nil)
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	key := func(k string) tea.Cmd {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		switch k {
+		case "tab":
+			msg = tea.KeyMsg{Type: tea.KeyTab}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		}
+		_, cmd := app.Update(msg)
+		return cmd
+	}
+	quits := func(cmd tea.Cmd) bool {
+		if cmd == nil {
+			return false
+		}
+		_, ok := cmd().(tea.QuitMsg)
+		return ok
+	}
+
+	// Context, then namespace, then the loaded pods
+	key("tab")
+	key("tab")
+	key("c")
+	if !podList.choosingColumns {
+		t.Fatal("Expected the column picker to open")
+	}
+
+	if quits(key("q")) {
+		t.Error("Expected q to stay in the column picker")
+	}
+	if !podList.choosingColumns {
+		t.Error("Expected the column picker to stay open after q")
+	}
+
+	key("esc")
+	if podList.choosingColumns {
+		t.Error("Expected esc to close the column picker")
+	}
+	if kubeoptic.GetFocusedView() != models.PodView {
+		t.Errorf("Expected esc to stay on the pods, got view %v", kubeoptic.GetFocusedView())
+	}
+
+	// With the picker closed, esc navigates back again
+	key("c")
+	key("esc")
+	key("esc")
+	if !quits(key("q")) {
+		t.Error("Expected q to quit once the picker is closed")
+	}
+}
@@ -2,12 +2,16 @@ package components
 
 import (
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 
 	"kubeoptic/internal/services"
 	"kubeoptic/internal/tui"
+	"kubeoptic/internal/tui/styles"
+	"kubeoptic/pkg/config"
 )
 
 // Test data
@@ -237,6 +241,23 @@ func TestPodListUpdate(t *testing.T) {
 			t.Error("Error handling should not return a command")
 		}
 	})
+
+	t.Run("loading_spinner", func(t *testing.T) {
+		_, cmd := podList.Update(tui.LoadingStartedMsg{Component: tui.LoadingPods})
+		if cmd == nil {
+			t.Error("Loading pods should start the spinner")
+		}
+
+		_, cmd = podList.Update(tui.LoadingStartedMsg{Component: tui.LoadingNamespaces})
+		if cmd != nil {
+			t.Error("Loading other components should not start the pod spinner")
+		}
+
+		_, cmd = podList.Update(tui.LoadingCompletedMsg{Component: tui.LoadingPods})
+		if cmd != nil {
+			t.Error("Stopping the spinner should not return a command")
+		}
+	})
 }
 
 func TestPodListView(t *testing.T) {
@@ -389,6 +410,68 @@ func TestUpdatePods(t *testing.T) {
 	})
 }
 
+func TestPodListLiveUpdates(t *testing.T) {
+	t.Run("keeps_selected_pod", func(t *testing.T) {
+		podList := NewPodList(testPods, 80, 40)
+		podList.list.Select(1) // redis-cache-def456
+
+		// A new pod appears above the selection and the selected pod changes phase
+		updated := append([]services.Pod{
+			{Name: "api-server-xyz999", Namespace: "default", Status: services.PodPending},
+		}, testPods...)
+		updated[2].Status = services.PodRunning
+		podList.UpdatePods(updated)
+
+		selected := podList.GetSelectedPod()
+		if selected == nil || selected.Name != "redis-cache-def456" {
+			t.Fatalf("Expected selection to stay on redis-cache-def456, got %v", selected)
+		}
+		if selected.Status != services.PodRunning {
+			t.Errorf("Expected updated status Running, got %s", selected.Status)
+		}
+	})
+
+	t.Run("selected_pod_removed", func(t *testing.T) {
+		podList := NewPodList(testPods, 80, 40)
+		podList.list.Select(3)
+
+		podList.UpdatePods(testPods[:2])
+
+		selected := podList.GetSelectedPod()
+		if selected == nil || selected.Name != testPods[1].Name {
+			t.Errorf("Expected selection to move to the last remaining pod, got %v", selected)
+		}
+	})
+
+	t.Run("keeps_filter", func(t *testing.T) {
+		podList := NewPodList(testPods, 80, 40)
+		podList.list.SetFilterText("jobs")
+
+		podList.UpdatePods(append(testPods, services.Pod{
+			Name: "cron-jobs-mno345", Namespace: "default", Status: services.PodRunning,
+		}))
+
+		if podList.list.FilterValue() != "jobs" {
+			t.Errorf("Expected filter to be kept, got %q", podList.list.FilterValue())
+		}
+		if got := len(podList.list.VisibleItems()); got != 3 {
+			t.Errorf("Expected 3 pods matching the filter, got %d", got)
+		}
+	})
+
+	t.Run("namespace_change_resets", func(t *testing.T) {
+		podList := NewPodList(nil, 80, 40)
+		podList.Update(tui.PodsLoadedMsg{Pods: testPods, Namespace: "default"})
+		podList.list.Select(2)
+
+		podList.Update(tui.PodsLoadedMsg{Pods: testPods, Namespace: "jobs"})
+
+		if podList.list.Index() != 0 {
+			t.Errorf("Expected selection to reset for a new namespace, got index %d", podList.list.Index())
+		}
+	})
+}
+
 func TestGetSelectedPod(t *testing.T) {
 	podList := NewPodList(testPods, 80, 20)
 
@@ -474,6 +557,742 @@ func TestPodDelegate(t *testing.T) {
 	})
 }
 
+func TestPodTable(t *testing.T) {
+	t.Run("health_from_display_status", func(t *testing.T) {
+		cases := map[string]services.PodStatus{
+			"":                 services.PodRunning,
+			"Running":          services.PodRunning,
+			"CrashLoopBackOff": services.PodFailed,
+			"ImagePullBackOff": services.PodFailed,
+			"Init:0/2":         services.PodPending,
+			"Init:Error":       services.PodFailed,
+			"Terminating":      services.PodPending,
+			"Completed":        services.PodSucceeded,
+		}
+		for status, expected := range cases {
+			pod := services.Pod{Status: services.PodRunning, DisplayStatus: status}
+			if got := podHealth(pod); got != expected {
+				t.Errorf("podHealth(%q) = %s, want %s", status, got, expected)
+			}
+		}
+	})
+
+	t.Run("columns_fit_width", func(t *testing.T) {
+		layout := func(width int) styles.LayoutConfig { return styles.NewLayoutConfig(width, 30) }
+
+		narrow, nameWidth := podTableLayout(layout(20), podColumns)
+		if len(narrow) != 0 || nameWidth != 20 {
+			t.Errorf("Expected only NAME in a narrow table, got %d columns", len(narrow))
+		}
+
+		wide, _ := podTableLayout(layout(200), podColumns)
+		if len(wide) != len(podColumns) {
+			t.Errorf("Expected all %d columns in a wide table, got %d", len(podColumns), len(wide))
+		}
+
+		for _, width := range []int{60, 100} {
+			medium, nameWidth := podTableLayout(layout(width), podColumns)
+			if len(medium) == 0 || medium[0].title != "STATUS" {
+				t.Error("Expected STATUS to be the first column kept")
+			}
+			if minWidth := layout(width).PodNameMinWidth; nameWidth < minWidth {
+				t.Errorf("NAME column narrower than %d at width %d: %d", minWidth, width, nameWidth)
+			}
+		}
+
+		// A small screen keeps less of NAME, fitting more columns
+		small, _ := podTableLayout(layout(60), podColumns)
+		medium, _ := podTableLayout(styles.LayoutConfig{Width: 60, PodNameMinWidth: styles.PodNameMinWidth}, podColumns)
+		if len(small) <= len(medium) {
+			t.Errorf("Expected more columns with a small screen's NAME width, got %d and %d", len(small), len(medium))
+		}
+
+		// Spare width grows columns up to their maximum before NAME
+		grown, _ := podTableLayout(layout(300), podColumns)
+		for _, column := range grown {
+			if column.width != column.maxWidth {
+				t.Errorf("Expected %s to grow to %d, got %d", column.id, column.maxWidth, column.width)
+			}
+		}
+	})
+
+	t.Run("renders_columns", func(t *testing.T) {
+		pods := []services.Pod{{
+			Name:            "api-7d4b9",
+			Namespace:       "default",
+			Status:          services.PodRunning,
+			DisplayStatus:   "CrashLoopBackOff",
+			ReadyContainers: 1,
+			TotalContainers: 2,
+			Restarts:        12,
+			CreatedAt:       time.Now().Add(-3 * time.Hour),
+			NodeName:        "node-a",
+			PodIP:           "10.0.0.7",
+		}}
+		podList := NewPodList(pods, 160, 20)
+		view := podList.View()
+
+		for _, expected := range []string{"NAME", "STATUS", "RESTARTS", "CrashLoopBackOff", "1/2", "12", "3h", "10.0.0.7", "node-a"} {
+			if !strings.Contains(view, expected) {
+				t.Errorf("Expected view to contain %q", expected)
+			}
+		}
+	})
+
+	t.Run("fit_cell", func(t *testing.T) {
+		if got := fitCell("abc", 5); got != "abc  " {
+			t.Errorf("fitCell pad = %q", got)
+		}
+		if got := fitCell("abcdef", 4); got != "abc…" {
+			t.Errorf("fitCell truncate = %q", got)
+		}
+	})
+}
+
+func TestPodListSorting(t *testing.T) {
+	now := time.Now()
+	pods := []services.Pod{
+		{Name: "b", Restarts: 3, NodeName: "node-2", CreatedAt: now.Add(-time.Hour)},
+		{Name: "c", Restarts: 0, NodeName: "node-1", CreatedAt: now.Add(-time.Minute)},
+		{Name: "a", Restarts: 7, NodeName: "node-1", CreatedAt: now.Add(-24 * time.Hour)},
+	}
+
+	order := func(p *PodList) string {
+		names := ""
+		for _, item := range p.list.Items() {
+			names += item.(PodItem).Pod.Name
+		}
+		return names
+	}
+
+	podList := NewPodList(pods, 120, 20)
+	if got := order(podList); got != "bca" {
+		t.Errorf("Expected pods in API order until sorted, got %s", got)
+	}
+
+	tests := []struct {
+		key      string
+		expected string
+	}{
+		{"R", "cba"}, // restarts ascending
+		{"R", "abc"}, // pressing again reverses
+		{"A", "cba"}, // youngest first
+		{"O", "acb"}, // node, then name
+		{"N", "abc"},
+		{"N", "cba"},
+	}
+	for _, test := range tests {
+		_, cmd := podList.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(test.key)})
+		if got := order(podList); got != test.expected {
+			t.Errorf("After %s expected order %s, got %s", test.key, test.expected, got)
+		}
+		if cmd == nil {
+			t.Fatalf("Expected sorting by %s to report the table change", test.key)
+		}
+		if _, ok := cmd().(tui.PodTableChangedMsg); !ok {
+			t.Error("Expected a PodTableChangedMsg")
+		}
+	}
+
+	// Live updates keep the sort order
+	podList.UpdatePods(append(pods, services.Pod{Name: "d"}))
+	if got := order(podList); got != "dcba" {
+		t.Errorf("Expected updated pods to stay sorted, got %s", got)
+	}
+}
+
+func TestPodListColumns(t *testing.T) {
+	t.Run("table_config", func(t *testing.T) {
+		podList := NewPodList(testPods, 120, 20)
+		podList.SetTableConfig(config.PodTable{
+			Columns:        []string{"age", "bogus", "status"},
+			SortBy:         "restarts",
+			SortDescending: true,
+		})
+
+		cfg := podList.TableConfig()
+		if strings.Join(cfg.Columns, ",") != "age,status" {
+			t.Errorf("Expected unknown columns to be dropped, got %v", cfg.Columns)
+		}
+		if cfg.SortBy != "restarts" || !cfg.SortDescending {
+			t.Errorf("Expected sort to be applied, got %+v", cfg)
+		}
+
+		header := podList.tableView()
+		if strings.Index(header, "AGE") > strings.Index(header, "STATUS") {
+			t.Error("Expected columns in configured order")
+		}
+		if strings.Contains(header, "NODE") {
+			t.Error("Hidden columns should not be shown")
+		}
+	})
+
+	t.Run("column_picker", func(t *testing.T) {
+		podList := NewPodList(testPods, 120, 20)
+		key := func(k string) tea.Cmd {
+			msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+			if k == " " {
+				msg = tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(k)}
+			}
+			_, cmd := podList.Update(msg)
+			return cmd
+		}
+
+		key("c")
+		if !strings.Contains(podList.View(), "Pod columns") {
+			t.Fatal("Expected the column picker to open")
+		}
+
+		key(" ") // hide STATUS
+		key("j")
+		key(" ") // hide READY
+		key(" ") // show READY again
+		cmd := key("c")
+
+		if podList.choosingColumns {
+			t.Error("Expected the column picker to close")
+		}
+		if cmd == nil {
+			t.Fatal("Expected closing the picker to report the table change")
+		}
+		msg, ok := cmd().(tui.PodTableChangedMsg)
+		if !ok {
+			t.Fatal("Expected a PodTableChangedMsg")
+		}
+		if strings.Join(msg.Columns, ",") != "ready,restarts,age,ip,node,qos" {
+			t.Errorf("Unexpected columns %v", msg.Columns)
+		}
+	})
+}
+
+func TestPodListQuery(t *testing.T) {
+	typeQuery := func(podList *PodList, query string) {
+		podList.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+		for _, r := range query {
+			podList.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+	}
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+
+	t.Run("invalid_query_shows_error", func(t *testing.T) {
+		podList := NewPodList(testPods, 120, 20)
+		typeQuery(podList, "app in (api")
+
+		if !podList.CapturingInput() {
+			t.Error("Expected the query box to capture input")
+		}
+		if !strings.Contains(podList.View(), "invalid label selector") {
+			t.Error("Expected the parse error to be shown inline")
+		}
+
+		_, cmd := podList.Update(enter)
+		if cmd != nil {
+			t.Error("An invalid query should not be applied")
+		}
+		if !podList.editingQuery {
+			t.Error("Expected the query box to stay open")
+		}
+	})
+
+	t.Run("apply_and_clear", func(t *testing.T) {
+		podList := NewPodList(testPods, 120, 20)
+		typeQuery(podList, "app=api status.phase=Running web")
+
+		_, cmd := podList.Update(enter)
+		if cmd == nil {
+			t.Fatal("Expected applying the query to return a command")
+		}
+		msg, ok := cmd().(tui.SearchQueryChangedMsg)
+		if !ok || msg.Query != "app=api status.phase=Running web" {
+			t.Fatalf("Expected a SearchQueryChangedMsg with the query, got %#v", cmd())
+		}
+		if podList.CapturingInput() {
+			t.Error("Expected the query box to close")
+		}
+		if !strings.Contains(podList.View(), "Query: app=api") {
+			t.Error("Expected the active query to be shown")
+		}
+
+		_, cmd = podList.Update(tea.KeyMsg{Type: tea.KeyEsc})
+		if cmd == nil {
+			t.Fatal("Expected esc to clear the query")
+		}
+		if _, ok := cmd().(tui.ClearSearchMsg); !ok {
+			t.Errorf("Expected a ClearSearchMsg, got %T", cmd())
+		}
+		if podList.GetSearchQuery() != "" {
+			t.Errorf("Expected no query, got %q", podList.GetSearchQuery())
+		}
+	})
+
+	t.Run("namespace_switch_clears_query", func(t *testing.T) {
+		podList := NewPodList(testPods, 120, 20)
+		podList.SetSearchQuery("app=api")
+
+		podList.Update(tui.PodsLoadedMsg{Pods: testPods, Namespace: "other"})
+		if podList.GetSearchQuery() != "" {
+			t.Errorf("Expected the query to follow the loaded pods, got %q", podList.GetSearchQuery())
+		}
+	})
+}
+
+func TestPodListGrouping(t *testing.T) {
+	pods := []services.Pod{
+		{Name: "api-7d9f8-aaaaa", Namespace: "shop", Status: services.PodRunning, ReadyContainers: 1, TotalContainers: 1,
+			Owner: services.OwnerRef{Kind: "ReplicaSet", Name: "api-7d9f8"}},
+		{Name: "debug", Namespace: "shop", Status: services.PodRunning},
+		{Name: "api-7d9f8-bbbbb", Namespace: "shop", Status: services.PodPending, TotalContainers: 1,
+			Owner: services.OwnerRef{Kind: "ReplicaSet", Name: "api-7d9f8"}},
+		{Name: "db-0", Namespace: "shop", Status: services.PodRunning, ReadyContainers: 1, TotalContainers: 1,
+			Owner: services.OwnerRef{Kind: "StatefulSet", Name: "db"}},
+	}
+	workloads := []services.Workload{
+		{Kind: "Deployment", Name: "api", Namespace: "shop", Desired: 3,
+			Controllers: []services.OwnerRef{{Kind: "ReplicaSet", Name: "api-7d9f8"}}},
+	}
+
+	newGroupedList := func() *PodList {
+		podList := NewPodList(nil, 120, 30)
+		podList.Update(tui.PodsLoadedMsg{Pods: pods, Namespace: "shop"})
+		podList.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'w'}})
+		podList.Update(tui.WorkloadsLoadedMsg{Workloads: workloads, Namespace: "shop"})
+		podList.list.Select(0)
+		return podList
+	}
+	titles := func(podList *PodList) []string {
+		var titles []string
+		for _, item := range podList.list.Items() {
+			switch item := item.(type) {
+			case WorkloadItem:
+				titles = append(titles, item.Title()+" "+item.Counts())
+			case PodItem:
+				titles = append(titles, item.Pod.Name)
+			}
+		}
+		return titles
+	}
+
+	t.Run("groups_by_resolved_owner", func(t *testing.T) {
+		podList := newGroupedList()
+		got := strings.Join(titles(podList), ",")
+		want := "Deployment/api 1/3 ready,api-7d9f8-aaaaa,api-7d9f8-bbbbb,StatefulSet/db 1/1 ready,db-0,Standalone pods 0/1 ready,debug"
+		if got != want {
+			t.Errorf("Unexpected rows\n got: %s\nwant: %s", got, want)
+		}
+	})
+
+	t.Run("toggle_reports_table_change", func(t *testing.T) {
+		podList := NewPodList(pods, 120, 30)
+		_, cmd := podList.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'w'}})
+		if cmd == nil {
+			t.Fatal("Expected grouping to report the table change")
+		}
+		if msg, ok := cmd().(tui.PodTableChangedMsg); !ok || !msg.GroupByWorkload {
+			t.Errorf("Expected GroupByWorkload in %#v", cmd())
+		}
+	})
+
+	t.Run("collapse_group", func(t *testing.T) {
+		podList := newGroupedList()
+		podList.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+
+		got := titles(podList)
+		if len(got) != 5 || got[1] != "StatefulSet/db 1/1 ready" {
+			t.Errorf("Expected the api pods to be folded away, got %v", got)
+		}
+		if !strings.Contains(podList.View(), "▸ Deployment/api") {
+			t.Error("Expected a folded marker on the header")
+		}
+	})
+
+	t.Run("enter_on_header_tails_workload", func(t *testing.T) {
+		podList := newGroupedList()
+		_, cmd := podList.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		if cmd == nil {
+			t.Fatal("Expected enter on a header to return a command")
+		}
+		msg, ok := cmd().(tui.WorkloadSelectedMsg)
+		if !ok {
+			t.Fatalf("Expected a WorkloadSelectedMsg, got %T", cmd())
+		}
+		if msg.Workload.Name != "api" || len(msg.Pods) != 2 {
+			t.Errorf("Expected the api deployment with 2 pods, got %s with %d", msg.Workload.Name, len(msg.Pods))
+		}
+	})
+}
+
+func TestPodListMetrics(t *testing.T) {
+	pods := []services.Pod{
+		{
+			Name: "api", Namespace: "default", Status: services.PodRunning, Restarts: 2,
+			Requests: services.ResourceAmounts{CPU: 200, Memory: 256 << 20},
+			Limits:   services.ResourceAmounts{CPU: 1000, Memory: 512 << 20},
+		},
+		{Name: "worker", Namespace: "default", Status: services.PodRunning},
+		{Name: "pending", Namespace: "default", Status: services.PodPending},
+	}
+	metrics := []services.PodMetrics{
+		{Namespace: "default", Name: "api", Usage: services.ResourceAmounts{CPU: 150, Memory: 128 << 20}},
+		{Namespace: "default", Name: "worker", Usage: services.ResourceAmounts{CPU: 400, Memory: 3 << 29}},
+	}
+
+	t.Run("hidden_without_metrics", func(t *testing.T) {
+		podList := NewPodList(pods, 200, 20)
+		if header := podList.tableView(); strings.Contains(header, "CPU") || strings.Contains(header, "MEM") {
+			t.Error("Expected no usage columns before metrics load")
+		}
+	})
+
+	t.Run("shows_usage", func(t *testing.T) {
+		podList := NewPodList(pods, 200, 20)
+		podList.Update(tui.MetricsLoadedMsg{Metrics: metrics})
+
+		view := podList.View()
+		for _, want := range []string{"CPU", "MEM", "150m   75%/15%", "128Mi  50%/25%", "400m   -/-", "1.5Gi"} {
+			if !strings.Contains(view, want) {
+				t.Errorf("Expected %q in the view:\n%s", want, view)
+			}
+		}
+		if strings.Index(view, "RESTARTS") > strings.Index(view, "CPU") || strings.Index(view, "CPU") > strings.Index(view, "AGE") {
+			t.Error("Expected usage columns right after RESTARTS")
+		}
+
+		// Usage isn't a column that can be picked or saved
+		if cfg := podList.TableConfig(); strings.Contains(strings.Join(cfg.Columns, ","), "cpu") {
+			t.Errorf("Expected usage columns left out of the saved columns, got %v", cfg.Columns)
+		}
+	})
+
+	t.Run("sorts_by_usage", func(t *testing.T) {
+		podList := NewPodList(pods, 200, 20)
+		podList.Update(tui.MetricsLoadedMsg{Metrics: metrics})
+		order := func() string {
+			var names []string
+			for _, item := range podList.list.Items() {
+				names = append(names, item.(PodItem).Pod.Name)
+			}
+			return strings.Join(names, ",")
+		}
+
+		podList.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("M")})
+		podList.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("M")})
+		if got := order(); got != "worker,api,pending" {
+			t.Errorf("Expected the biggest memory user first, got %s", got)
+		}
+		podList.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("C")})
+		if got := order(); got != "pending,api,worker" {
+			t.Errorf("Expected pods by CPU ascending, got %s", got)
+		}
+	})
+
+	t.Run("hidden_when_unavailable", func(t *testing.T) {
+		podList := NewPodList(pods, 200, 20)
+		podList.Update(tui.MetricsLoadedMsg{Metrics: metrics})
+		podList.Update(tui.MetricsLoadedMsg{Error: services.ErrMetricsUnavailable})
+		if header := podList.tableView(); strings.Contains(header, "CPU") {
+			t.Error("Expected usage columns to be hidden once metrics are unavailable")
+		}
+	})
+
+	t.Run("narrow_cells_drop_percentages", func(t *testing.T) {
+		theme := NewPodList(nil, 80, 20).delegate.theme
+		cell := usageCell(150, 200, 1000, formatCPU, 11, theme)
+		if !strings.HasPrefix(cell, "150m ") || strings.Contains(cell, "%") {
+			t.Errorf("Expected only usage in a narrow cell, got %q", cell)
+		}
+		if got := formatMemory(512 << 10); got != "512Ki" {
+			t.Errorf("formatMemory = %q", got)
+		}
+	})
+}
+
+func TestPodListExecPicker(t *testing.T) {
+	pod := services.Pod{Name: "api-7d9f", Namespace: "payments", Status: services.PodRunning, Containers: []string{"api", "envoy"}}
+	key := func(p *PodList, k string) tea.Cmd {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		switch k {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		case "tab":
+			msg = tea.KeyMsg{Type: tea.KeyTab}
+		}
+		_, cmd := p.Update(msg)
+		return cmd
+	}
+
+	t.Run("chooses_container_and_shell", func(t *testing.T) {
+		podList := NewPodList([]services.Pod{pod}, 120, 20)
+		key(podList, "s")
+		if !podList.CapturingInput() {
+			t.Fatal("Expected the picker to take keys")
+		}
+		view := podList.View()
+		for _, want := range []string{"Shell in api-7d9f", "> api", "envoy", "bash, or sh without bash"} {
+			if !strings.Contains(view, want) {
+				t.Errorf("Expected %q in the picker:\n%s", want, view)
+			}
+		}
+
+		key(podList, "j")
+		key(podList, "tab")
+		key(podList, "tab")
+		cmd := key(podList, "enter")
+		if cmd == nil {
+			t.Fatal("Expected enter to open a shell")
+		}
+		msg, ok := cmd().(tui.ExecRequestedMsg)
+		if !ok || msg.Pod.Name != "api-7d9f" || msg.Container != "envoy" || msg.Shell != services.ShellSh {
+			t.Errorf("Expected a shell request for envoy with sh, got %+v", msg)
+		}
+		if podList.CapturingInput() {
+			t.Error("Expected the picker to close")
+		}
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		podList := NewPodList([]services.Pod{pod}, 120, 20)
+		key(podList, "s")
+		if cmd := key(podList, "esc"); cmd != nil {
+			t.Error("Expected esc to open nothing")
+		}
+		if podList.CapturingInput() || strings.Contains(podList.View(), "Shell in") {
+			t.Error("Expected esc to close the picker")
+		}
+	})
+}
+
+func TestPodListPortForwardPicker(t *testing.T) {
+	pod := services.Pod{
+		Name:      "api-7d9f",
+		Namespace: "payments",
+		Status:    services.PodRunning,
+		Ports: []services.ContainerPort{
+			{Container: "api", Name: "http", Port: 8080, Protocol: "TCP"},
+			{Container: "api", Name: "dns", Port: 53, Protocol: "UDP"},
+			{Container: "envoy", Name: "admin", Port: 9901, Protocol: "TCP"},
+		},
+	}
+	key := func(p *PodList, k string) tea.Cmd {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		switch k {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		case "down":
+			msg = tea.KeyMsg{Type: tea.KeyDown}
+		case "backspace":
+			msg = tea.KeyMsg{Type: tea.KeyBackspace}
+		}
+		_, cmd := p.Update(msg)
+		return cmd
+	}
+
+	t.Run("forwards_declared_port", func(t *testing.T) {
+		podList := NewPodList([]services.Pod{pod}, 120, 20)
+		key(podList, "p")
+		if !podList.CapturingInput() {
+			t.Fatal("Expected the picker to take keys")
+		}
+		view := podList.View()
+		for _, want := range []string{"Port-forward to api-7d9f", "> 8080 (http)", "9901 (admin)", "8080:8080"} {
+			if !strings.Contains(view, want) {
+				t.Errorf("Expected %q in the picker:\n%s", want, view)
+			}
+		}
+		if strings.Contains(view, "dns") {
+			t.Error("Expected UDP ports to be left out")
+		}
+
+		key(podList, "down")
+		cmd := key(podList, "enter")
+		if cmd == nil {
+			t.Fatal("Expected enter to start a forward")
+		}
+		want := tui.PortForwardRequestedMsg{Namespace: "payments", Pod: "api-7d9f", LocalPort: 9901, RemotePort: 9901}
+		if msg := cmd(); msg != want {
+			t.Errorf("Expected %+v, got %+v", want, msg)
+		}
+		if podList.CapturingInput() {
+			t.Error("Expected the picker to close")
+		}
+	})
+
+	t.Run("typed_ports", func(t *testing.T) {
+		podList := NewPodList([]services.Pod{pod}, 120, 20)
+		key(podList, "p")
+		for range "8080:8080" {
+			key(podList, "backspace")
+		}
+		for _, k := range []string{"1", "8", "0", "8", "0", ":", "8", "0", "8", "0"} {
+			key(podList, k)
+		}
+		cmd := key(podList, "enter")
+		if cmd == nil {
+			t.Fatal("Expected enter to start a forward")
+		}
+		if msg := cmd().(tui.PortForwardRequestedMsg); msg.LocalPort != 18080 || msg.RemotePort != 8080 {
+			t.Errorf("Expected 18080:8080, got %+v", msg)
+		}
+	})
+
+	t.Run("invalid_ports", func(t *testing.T) {
+		podList := NewPodList([]services.Pod{pod}, 120, 20)
+		key(podList, "p")
+		key(podList, "x")
+		if cmd := key(podList, "enter"); cmd != nil {
+			t.Error("Expected invalid ports to start nothing")
+		}
+		if !podList.CapturingInput() || !strings.Contains(podList.View(), "invalid") {
+			t.Error("Expected the picker to stay open with the error")
+		}
+		key(podList, "esc")
+		if podList.CapturingInput() {
+			t.Error("Expected esc to close the picker")
+		}
+	})
+}
+
+func TestPodListActions(t *testing.T) {
+	pod := services.Pod{
+		Name:      "api-7d9f-x2k4",
+		Namespace: "payments",
+		Status:    services.PodRunning,
+		Owner:     services.OwnerRef{Kind: services.KindReplicaSet, Name: "api-7d9f"},
+	}
+	podList := NewPodList([]services.Pod{pod}, 120, 20)
+
+	tests := []struct {
+		key  tea.KeyMsg
+		want tui.ActionRequestedMsg
+	}{
+		{
+			key:  tea.KeyMsg{Type: tea.KeyCtrlX},
+			want: tui.ActionRequestedMsg{Action: tui.ActionDeletePod, Namespace: "payments", Kind: services.KindPod, Name: "api-7d9f-x2k4"},
+		},
+		{
+			key:  tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("X")},
+			want: tui.ActionRequestedMsg{Action: tui.ActionForceDeletePod, Namespace: "payments", Kind: services.KindPod, Name: "api-7d9f-x2k4"},
+		},
+		{
+			// The owner is resolved to its workload before asking
+			key:  tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("B")},
+			want: tui.ActionRequestedMsg{Action: tui.ActionRestart, Namespace: "payments", Kind: services.KindReplicaSet, Name: "api-7d9f"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key.String(), func(t *testing.T) {
+			_, cmd := podList.Update(tt.key)
+			if cmd == nil {
+				t.Fatal("Expected the key to ask for the action")
+			}
+			if msg := cmd(); msg != tt.want {
+				t.Errorf("Expected %+v, got %+v", tt.want, msg)
+			}
+		})
+	}
+
+	// Refreshing, scrolling and sorting don't ask for changes
+	for _, k := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune("r")},
+		{Type: tea.KeyCtrlR},
+		{Type: tea.KeyCtrlD},
+		{Type: tea.KeyRunes, Runes: []rune("R")},
+	} {
+		if _, cmd := podList.Update(k); cmd != nil {
+			if msg, ok := cmd().(tui.ActionRequestedMsg); ok {
+				t.Errorf("Expected %s not to ask for an action, got %+v", k, msg)
+			}
+		}
+	}
+	for k := range podActionKeys {
+		if _, ok := podSortKeys[k]; ok {
+			t.Errorf("Action key %s also sorts the pods", k)
+		}
+	}
+}
+
+func TestPodListDebug(t *testing.T) {
+	pod := services.Pod{Name: "api-7d9f-x2k4", Namespace: "payments", Status: services.PodRunning, Containers: []string{"api", "sidecar"}}
+	newPodList := func() *PodList {
+		podList := NewPodList([]services.Pod{pod}, 120, 20)
+		podList.SetDebugImage("busybox:1.36")
+		podList.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("D")})
+		return podList
+	}
+	request := func(t *testing.T, podList *PodList) tui.ActionRequestedMsg {
+		t.Helper()
+		_, cmd := podList.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		if cmd == nil || podList.CapturingInput() {
+			t.Fatal("Expected enter to ask to debug the pod")
+		}
+		msg, ok := cmd().(tui.ActionRequestedMsg)
+		if !ok {
+			t.Fatalf("Expected an ActionRequestedMsg, got %T", cmd())
+		}
+		return msg
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		podList := newPodList()
+		if !podList.CapturingInput() {
+			t.Fatal("Expected D to open the debug picker")
+		}
+		view := podList.View()
+		for _, want := range []string{"Debug container in api-7d9f-x2k4", "> api", "Image: busybox:1.36", "network only"} {
+			if !strings.Contains(view, want) {
+				t.Errorf("Expected %q in the picker:\n%s", want, view)
+			}
+		}
+
+		want := tui.ActionRequestedMsg{Action: tui.ActionDebug, Namespace: "payments", Kind: services.KindPod, Name: "api-7d9f-x2k4", Container: "api", Image: "busybox:1.36"}
+		if msg := request(t, podList); msg != want {
+			t.Errorf("Expected %+v, got %+v", want, msg)
+		}
+	})
+
+	t.Run("target_and_image", func(t *testing.T) {
+		podList := newPodList()
+		podList.Update(tea.KeyMsg{Type: tea.KeyDown})
+		podList.Update(tea.KeyMsg{Type: tea.KeyDown})
+		podList.Update(tea.KeyMsg{Type: tea.KeyDown})
+		for range "busybox:1.36" {
+			podList.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+		}
+		for _, r := range "nicolaka/netshoot" {
+			podList.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+
+		msg := request(t, podList)
+		if msg.Container != "" || msg.Image != "nicolaka/netshoot" {
+			t.Errorf("Expected no target and the typed image, got %+v", msg)
+		}
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		podList := newPodList()
+		if _, cmd := podList.Update(tea.KeyMsg{Type: tea.KeyEsc}); cmd != nil || podList.CapturingInput() {
+			t.Error("Expected esc to close the picker")
+		}
+	})
+}
+
+func TestPodListFiles(t *testing.T) {
+	pod := services.Pod{Name: "api-7d9f-x2k4", Namespace: "payments", Status: services.PodRunning, Containers: []string{"api", "sidecar"}}
+	podList := NewPodList([]services.Pod{pod}, 120, 20)
+
+	_, cmd := podList.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("F")})
+	if cmd == nil {
+		t.Fatal("Expected F to open the file browser")
+	}
+	msg, ok := cmd().(tui.FilesRequestedMsg)
+	if !ok || msg.Pod == nil || msg.Pod.Name != "api-7d9f-x2k4" || msg.Container != "api" || msg.Path != "/" {
+		t.Errorf("Expected the first container's root, got %+v", msg)
+	}
+}
+
 func TestMinFunction(t *testing.T) {
 	t.Run("min_function", func(t *testing.T) {
 		testCases := []struct {
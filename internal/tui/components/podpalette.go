@@ -0,0 +1,259 @@
+package components
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sahilm/fuzzy"
+
+	"kubeoptic/internal/services"
+	"kubeoptic/internal/tui"
+)
+
+// podPaletteChrome is the number of lines around the results: border, input,
+// a blank line and the key hints
+const podPaletteChrome = 6
+
+// podSource exposes pods to fuzzy matching as "namespace/name"
+type podSource []services.Pod
+
+func (s podSource) String(i int) string {
+	return s[i].Namespace + "/" + s[i].Name
+}
+
+func (s podSource) Len() int {
+	return len(s)
+}
+
+// podMatch is a pod in the palette results with the matched characters of
+// its "namespace/name"
+type podMatch struct {
+	pod     services.Pod
+	indexes []int
+}
+
+// PodPalette fuzzy-finds a pod across every namespace and jumps to its logs
+type PodPalette struct {
+	input   textinput.Model
+	pods    []services.Pod
+	matches []podMatch
+	cursor  int
+	loading bool
+	err     error
+	width   int
+	height  int
+}
+
+// NewPodPalette creates a new pod jump palette
+func NewPodPalette(width, height int) *PodPalette {
+	input := textinput.New()
+	input.Prompt = "> "
+	input.Placeholder = "pod name in any namespace"
+	input.CharLimit = 256
+
+	palette := &PodPalette{input: input}
+	palette.SetSize(width, height)
+	return palette
+}
+
+// Init implements tea.Model interface
+func (pp *PodPalette) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model interface
+func (pp *PodPalette) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		pp.SetSize(msg.Width, msg.Height)
+		return pp, nil
+
+	case tui.PaletteOpenedMsg:
+		pp.input.SetValue("")
+		pp.pods = nil
+		pp.matches = nil
+		pp.cursor = 0
+		pp.loading = true
+		pp.err = nil
+		return pp, pp.input.Focus()
+
+	case tui.AllPodsLoadedMsg:
+		pp.loading = false
+		pp.err = msg.Error
+		pp.SetPods(msg.Pods)
+		return pp, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			pp.input.Blur()
+			return pp, func() tea.Msg {
+				return tui.PaletteClosedMsg{}
+			}
+
+		case "enter":
+			pod := pp.SelectedPod()
+			if pod == nil {
+				return pp, nil
+			}
+			pp.input.Blur()
+			return pp, func() tea.Msg {
+				return tui.PodJumpMsg{Pod: pod}
+			}
+
+		case "up", "ctrl+p", "ctrl+k":
+			if pp.cursor > 0 {
+				pp.cursor--
+			}
+			return pp, nil
+
+		case "down", "ctrl+n", "ctrl+j":
+			if pp.cursor < len(pp.matches)-1 {
+				pp.cursor++
+			}
+			return pp, nil
+		}
+
+		var cmd tea.Cmd
+		previous := pp.input.Value()
+		pp.input, cmd = pp.input.Update(msg)
+		if pp.input.Value() != previous {
+			pp.match()
+		}
+		return pp, cmd
+	}
+
+	return pp, nil
+}
+
+// SetPods sets the pods to search, ordered by namespace and name
+func (pp *PodPalette) SetPods(pods []services.Pod) {
+	pp.pods = make([]services.Pod, len(pods))
+	copy(pp.pods, pods)
+	sort.SliceStable(pp.pods, func(i, j int) bool {
+		return podSource(pp.pods).String(i) < podSource(pp.pods).String(j)
+	})
+	pp.match()
+}
+
+// match ranks the pods against the query, best match first. An empty query
+// lists every pod.
+func (pp *PodPalette) match() {
+	pp.cursor = 0
+	query := strings.TrimSpace(pp.input.Value())
+	if query == "" {
+		pp.matches = make([]podMatch, len(pp.pods))
+		for i, pod := range pp.pods {
+			pp.matches[i] = podMatch{pod: pod}
+		}
+		return
+	}
+
+	results := fuzzy.FindFrom(query, podSource(pp.pods))
+	pp.matches = make([]podMatch, len(results))
+	for i, result := range results {
+		pp.matches[i] = podMatch{pod: pp.pods[result.Index], indexes: result.MatchedIndexes}
+	}
+}
+
+// SelectedPod returns the highlighted result
+func (pp *PodPalette) SelectedPod() *services.Pod {
+	if pp.cursor < 0 || pp.cursor >= len(pp.matches) {
+		return nil
+	}
+	pod := pp.matches[pp.cursor].pod
+	return &pod
+}
+
+// CapturingInput reports that the palette takes every key while shown
+func (pp *PodPalette) CapturingInput() bool {
+	return true
+}
+
+// View implements tea.Model interface
+func (pp *PodPalette) View() string {
+	titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("86")).Bold(true)
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	lines := []string{titleStyle.Render("Jump to pod"), pp.input.View(), ""}
+
+	visible := max(pp.height-podPaletteChrome, 1)
+	switch {
+	case pp.err != nil:
+		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render(pp.err.Error()))
+	case pp.loading:
+		lines = append(lines, dimStyle.Render("Loading pods from all namespaces..."))
+	case len(pp.matches) == 0:
+		lines = append(lines, dimStyle.Render("No matching pods"))
+	default:
+		// Keep the cursor on screen
+		start := 0
+		if pp.cursor >= visible {
+			start = pp.cursor - visible + 1
+		}
+		end := min(start+visible, len(pp.matches))
+		for i := start; i < end; i++ {
+			lines = append(lines, pp.renderMatch(pp.matches[i], i == pp.cursor))
+		}
+	}
+
+	for len(lines) < visible+3 {
+		lines = append(lines, "")
+	}
+	hint := fmt.Sprintf("%d/%d pods • enter: open logs • esc: close", len(pp.matches), len(pp.pods))
+	lines = append(lines, dimStyle.Render(hint))
+
+	return lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("86")).
+		Width(max(pp.width-2, 0)).
+		Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// renderMatch renders a result with its matched characters highlighted
+func (pp *PodPalette) renderMatch(match podMatch, selected bool) string {
+	normal := lipgloss.NewStyle().Foreground(lipgloss.Color("252"))
+	matched := lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Bold(true)
+	prefix := "  "
+	if selected {
+		normal = lipgloss.NewStyle().Foreground(lipgloss.Color("86")).Bold(true)
+		matched = matched.Underline(true)
+		prefix = "> "
+	}
+
+	isMatched := make(map[int]bool, len(match.indexes))
+	for _, i := range match.indexes {
+		isMatched[i] = true
+	}
+
+	name := match.pod.Namespace + "/" + match.pod.Name
+	var b strings.Builder
+	b.WriteString(normal.Render(prefix))
+	for i, r := range name {
+		if isMatched[i] {
+			b.WriteString(matched.Render(string(r)))
+		} else {
+			b.WriteString(normal.Render(string(r)))
+		}
+	}
+
+	status := podStatusText(match.pod)
+	statusStyle := newPodDelegate().getStatusStyle(podHealth(match.pod))
+	return b.String() + "  " + statusStyle.Render(status)
+}
+
+// SetSize updates the component size
+func (pp *PodPalette) SetSize(width, height int) {
+	pp.width = width
+	pp.height = height
+	pp.input.Width = max(width-len(pp.input.Prompt)-4, 1)
+}
+
+// GetSize returns the current component size
+func (pp *PodPalette) GetSize() (int, int) {
+	return pp.width, pp.height
+}
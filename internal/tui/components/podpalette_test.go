@@ -0,0 +1,101 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"kubeoptic/internal/services"
+	"kubeoptic/internal/tui"
+)
+
+func TestPodPalette(t *testing.T) {
+	pods := []services.Pod{
+		{Name: "api-7d9f8-x2k4p", Namespace: "payments", Status: services.PodRunning},
+		{Name: "worker-5c6b7-qq8zt", Namespace: "payments", Status: services.PodRunning},
+		{Name: "api-server-0", Namespace: "platform", Status: services.PodPending},
+		{Name: "redis-0", Namespace: "cache", Status: services.PodRunning},
+	}
+
+	newPalette := func() *PodPalette {
+		palette := NewPodPalette(80, 20)
+		palette.Update(tui.PaletteOpenedMsg{})
+		palette.Update(tui.AllPodsLoadedMsg{Pods: pods})
+		return palette
+	}
+	typeText := func(palette *PodPalette, text string) {
+		for _, r := range text {
+			palette.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+	}
+
+	t.Run("lists_all_pods_sorted", func(t *testing.T) {
+		palette := newPalette()
+		if len(palette.matches) != len(pods) {
+			t.Fatalf("Expected all %d pods with an empty query, got %d", len(pods), len(palette.matches))
+		}
+		if got := palette.SelectedPod(); got == nil || got.Namespace != "cache" {
+			t.Errorf("Expected pods ordered by namespace, got %+v", got)
+		}
+	})
+
+	t.Run("fuzzy_matches_across_namespaces", func(t *testing.T) {
+		palette := newPalette()
+		typeText(palette, "payapi")
+
+		if len(palette.matches) == 0 {
+			t.Fatal("Expected a match for payapi")
+		}
+		if got := palette.SelectedPod(); got.Name != "api-7d9f8-x2k4p" {
+			t.Errorf("Expected the payments api pod to rank first, got %s/%s", got.Namespace, got.Name)
+		}
+		for _, match := range palette.matches {
+			if match.pod.Name == "redis-0" {
+				t.Error("redis-0 should not match payapi")
+			}
+		}
+	})
+
+	t.Run("enter_jumps_to_pod", func(t *testing.T) {
+		palette := newPalette()
+		typeText(palette, "server")
+
+		_, cmd := palette.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		if cmd == nil {
+			t.Fatal("Expected enter to return a command")
+		}
+		msg, ok := cmd().(tui.PodJumpMsg)
+		if !ok {
+			t.Fatalf("Expected a PodJumpMsg, got %T", cmd())
+		}
+		if msg.Pod.Namespace != "platform" || msg.Pod.Name != "api-server-0" {
+			t.Errorf("Unexpected pod %s/%s", msg.Pod.Namespace, msg.Pod.Name)
+		}
+	})
+
+	t.Run("esc_closes", func(t *testing.T) {
+		palette := newPalette()
+		_, cmd := palette.Update(tea.KeyMsg{Type: tea.KeyEsc})
+		if cmd == nil {
+			t.Fatal("Expected esc to return a command")
+		}
+		if _, ok := cmd().(tui.PaletteClosedMsg); !ok {
+			t.Errorf("Expected a PaletteClosedMsg, got %T", cmd())
+		}
+	})
+
+	t.Run("view", func(t *testing.T) {
+		palette := NewPodPalette(80, 20)
+		palette.Update(tui.PaletteOpenedMsg{})
+		if !strings.Contains(palette.View(), "Loading pods") {
+			t.Error("Expected a loading message before pods arrive")
+		}
+
+		palette.Update(tui.AllPodsLoadedMsg{Pods: pods})
+		view := palette.View()
+		if !strings.Contains(view, "4/4 pods") {
+			t.Error("Expected the result count in the view")
+		}
+	})
+}
@@ -0,0 +1,316 @@
+package components
+
+import (
+	"cmp"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/duration"
+
+	"kubeoptic/internal/services"
+	"kubeoptic/internal/tui/styles"
+)
+
+// podTableHeaderHeight is the number of lines the column headings take
+const podTableHeaderHeight = 1
+
+// podColumn is a column of the pod table after NAME. Columns are laid out at
+// their minimum width and grow towards their maximum when space allows.
+type podColumn struct {
+	id       string
+	title    string
+	minWidth int
+	maxWidth int
+	width    int
+	value    func(services.Pod) string
+
+	// cell renders the column itself, for columns that draw more than text
+	cell func(pod services.Pod, width int) string
+}
+
+// podColumns lists every column in its default order
+var podColumns = []podColumn{
+	{id: "status", title: "STATUS", minWidth: 10, maxWidth: 18, value: podStatusText},
+	{id: "ready", title: "READY", minWidth: 5, maxWidth: 5, value: func(p services.Pod) string {
+		return fmt.Sprintf("%d/%d", p.ReadyContainers, p.TotalContainers)
+	}},
+	{id: "restarts", title: "RESTARTS", minWidth: 8, maxWidth: 8, value: func(p services.Pod) string {
+		return fmt.Sprintf("%d", p.Restarts)
+	}},
+	{id: "age", title: "AGE", minWidth: 5, maxWidth: 7, value: func(p services.Pod) string {
+		return podAge(p.CreatedAt, time.Now())
+	}},
+	{id: "ip", title: "IP", minWidth: 11, maxWidth: 15, value: func(p services.Pod) string {
+		return valueOrNone(p.PodIP)
+	}},
+	{id: "node", title: "NODE", minWidth: 10, maxWidth: 30, value: func(p services.Pod) string {
+		return valueOrNone(p.NodeName)
+	}},
+	{id: "qos", title: "QOS", minWidth: 10, maxWidth: 10, value: func(p services.Pod) string {
+		return valueOrNone(p.QOSClass)
+	}},
+}
+
+// podUsageBarWidth is the width of the bars in the resource usage columns
+const podUsageBarWidth = 5
+
+// podUsageColumns returns the CPU and memory columns shown while metrics are
+// available, reading usage from the given function. They aren't in
+// podColumns, so they are neither picked nor saved like the others.
+func podUsageColumns(usageOf func(services.Pod) (services.ResourceAmounts, bool), theme styles.Theme) []podColumn {
+	column := func(id, title string, minWidth int, amount func(services.ResourceAmounts) int64, format func(int64) string) podColumn {
+		return podColumn{id: id, title: title, minWidth: minWidth, maxWidth: 22, cell: func(pod services.Pod, width int) string {
+			usage, ok := usageOf(pod)
+			if !ok {
+				return fitCell("-", width)
+			}
+			return usageCell(amount(usage), amount(pod.Requests), amount(pod.Limits), format, width, theme)
+		}}
+	}
+	return []podColumn{
+		column(sortByCPU, "CPU", 11, func(r services.ResourceAmounts) int64 { return r.CPU }, formatCPU),
+		column(sortByMemory, "MEM", 12, func(r services.ResourceAmounts) int64 { return r.Memory }, formatMemory),
+	}
+}
+
+// usageCell shows usage with the percentage of the request and limit it
+// amounts to, dropping the percentages when short of space, and a bar filled
+// against the limit, or the request when there is no limit
+func usageCell(used, request, limit int64, format func(int64) string, width int, theme styles.Theme) string {
+	textWidth := width - podUsageBarWidth - 1
+	text := fmt.Sprintf("%-6s %s/%s", format(used), percentOf(used, request), percentOf(used, limit))
+	if len([]rune(text)) > textWidth {
+		text = format(used)
+	}
+
+	of := limit
+	if of == 0 {
+		of = request
+	}
+	bar := strings.Repeat(" ", podUsageBarWidth)
+	if of > 0 {
+		bar = styles.CreateProgressBar(int(used), int(of), podUsageBarWidth, theme)
+	}
+	return fitCell(text, textWidth) + " " + bar
+}
+
+// percentOf formats used as a percentage of an amount, or "-" for none
+func percentOf(used, of int64) string {
+	if of == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%d%%", used*100/of)
+}
+
+// formatCPU formats millicores the way kubectl top does
+func formatCPU(millicores int64) string {
+	return fmt.Sprintf("%dm", millicores)
+}
+
+// formatMemory formats bytes in binary units
+func formatMemory(bytes int64) string {
+	switch {
+	case bytes >= 1<<30:
+		return fmt.Sprintf("%.1fGi", float64(bytes)/(1<<30))
+	case bytes >= 1<<20:
+		return fmt.Sprintf("%dMi", bytes>>20)
+	default:
+		return fmt.Sprintf("%dKi", bytes>>10)
+	}
+}
+
+// podColumnIDs returns the ids of columns, in order
+func podColumnIDs(columns []podColumn) []string {
+	ids := make([]string, len(columns))
+	for i, column := range columns {
+		ids[i] = column.id
+	}
+	return ids
+}
+
+// podColumnsByID resolves column ids to columns, skipping unknown and
+// repeated ids. Nil ids select every column.
+func podColumnsByID(ids []string) []podColumn {
+	if ids == nil {
+		return podColumns
+	}
+
+	columns := make([]podColumn, 0, len(ids))
+	seen := make(map[string]bool)
+	for _, id := range ids {
+		for _, column := range podColumns {
+			if column.id == id && !seen[id] {
+				columns = append(columns, column)
+				seen[id] = true
+			}
+		}
+	}
+	return columns
+}
+
+// podTableLayout picks the visible columns that fit in the layout's width,
+// keeping their order, sizes them and returns them with the width left for
+// NAME. The layout decides how much NAME keeps before columns are dropped,
+// and how much it takes before they grow.
+func podTableLayout(layout styles.LayoutConfig, visible []podColumn) ([]podColumn, int) {
+	var columns []podColumn
+	remaining := layout.Width
+	for _, column := range visible {
+		if remaining-column.minWidth-1 < layout.PodNameMinWidth {
+			break
+		}
+		column.width = column.minWidth
+		columns = append(columns, column)
+		remaining -= column.width + 1
+	}
+
+	// Widen columns with space NAME doesn't need; anything left goes to NAME
+	spare := remaining - layout.PodNameComfortWidth
+	for i := range columns {
+		if spare <= 0 {
+			break
+		}
+		grow := min(spare, columns[i].maxWidth-columns[i].width)
+		columns[i].width += grow
+		remaining -= grow
+		spare -= grow
+	}
+
+	if remaining < 1 {
+		remaining = 1
+	}
+	return columns, remaining
+}
+
+// Pod table sort keys
+const (
+	sortByName     = "name"
+	sortByStatus   = "status"
+	sortByRestarts = "restarts"
+	sortByAge      = "age"
+	sortByNode     = "node"
+	sortByCPU      = "cpu"
+	sortByMemory   = "memory"
+)
+
+// podSortKeys maps keys pressed in the pod list to the column they sort by
+var podSortKeys = map[string]string{
+	"N": sortByName,
+	"S": sortByStatus,
+	"R": sortByRestarts,
+	"A": sortByAge,
+	"O": sortByNode,
+	"C": sortByCPU,
+	"M": sortByMemory,
+}
+
+// sortPods returns pods ordered by a sort key, or in API order when the key
+// is empty. Ties are broken by name so rows don't jump around on updates.
+// Resource usage, which may be nil, orders pods by CPU and memory.
+func sortPods(pods []services.Pod, sortBy string, descending bool, usage map[string]services.ResourceAmounts) []services.Pod {
+	sorted := make([]services.Pod, len(pods))
+	copy(sorted, pods)
+	if sortBy == "" {
+		return sorted
+	}
+
+	compare := func(a, b services.Pod) int {
+		switch sortBy {
+		case sortByStatus:
+			return strings.Compare(podStatusText(a), podStatusText(b))
+		case sortByRestarts:
+			return a.Restarts - b.Restarts
+		case sortByAge:
+			// Ascending age lists the youngest pods first
+			return b.CreatedAt.Compare(a.CreatedAt)
+		case sortByNode:
+			return strings.Compare(a.NodeName, b.NodeName)
+		case sortByCPU:
+			return cmp.Compare(usage[podKey(a)].CPU, usage[podKey(b)].CPU)
+		case sortByMemory:
+			return cmp.Compare(usage[podKey(a)].Memory, usage[podKey(b)].Memory)
+		default:
+			return 0
+		}
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		c := compare(sorted[i], sorted[j])
+		if c == 0 {
+			c = strings.Compare(sorted[i].Name, sorted[j].Name)
+		}
+		if descending {
+			return c > 0
+		}
+		return c < 0
+	})
+	return sorted
+}
+
+// podKey identifies a pod by namespace and name
+func podKey(pod services.Pod) string {
+	return pod.Namespace + "/" + pod.Name
+}
+
+// podHealth maps a pod's displayed status onto the phase it looks like, so
+// a Running pod in CrashLoopBackOff is shown as failing
+func podHealth(pod services.Pod) services.PodStatus {
+	status := pod.DisplayStatus
+	switch {
+	case status == "":
+		return pod.Status
+	case status == "Running":
+		return services.PodRunning
+	case status == "Completed" || status == "Succeeded":
+		return services.PodSucceeded
+	case status == "Unknown":
+		return services.PodUnknown
+	case status == "Pending" || status == "ContainerCreating" || status == "PodInitializing" || status == "Terminating":
+		return services.PodPending
+	case strings.HasPrefix(status, "Init:"):
+		// Init:1/2 is progress, anything else is an init container failing
+		if strings.Contains(status, "/") {
+			return services.PodPending
+		}
+		return services.PodFailed
+	default:
+		return services.PodFailed
+	}
+}
+
+// podStatusText returns the kubectl-style status, falling back to the phase
+func podStatusText(pod services.Pod) string {
+	if pod.DisplayStatus != "" {
+		return pod.DisplayStatus
+	}
+	return string(pod.Status)
+}
+
+// podAge formats the time since created like kubectl's AGE column
+func podAge(created, now time.Time) string {
+	if created.IsZero() {
+		return "<unknown>"
+	}
+	return duration.HumanDuration(now.Sub(created))
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return "<none>"
+	}
+	return value
+}
+
+// fitCell pads or truncates text to exactly width cells
+func fitCell(text string, width int) string {
+	runes := []rune(text)
+	if len(runes) > width {
+		if width <= 1 {
+			return string(runes[:width])
+		}
+		return string(runes[:width-1]) + "…"
+	}
+	return text + strings.Repeat(" ", width-len(runes))
+}
@@ -0,0 +1,382 @@
+package components
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"k8s.io/apimachinery/pkg/util/duration"
+
+	"kubeoptic/internal/services"
+	"kubeoptic/internal/tui"
+)
+
+const (
+	// portForwardListHeaderHeight is the number of lines the title and
+	// column headings take
+	portForwardListHeaderHeight = 2
+
+	// portForwardFooterHeight is the line of key hints, or of the input for
+	// a new forward
+	portForwardFooterHeight = 2
+
+	portForwardLocalWidth   = 16
+	portForwardAgeWidth     = 7
+	portForwardTrafficWidth = 9
+	portForwardStatusWidth  = 20
+	portForwardTargetMin    = 16
+)
+
+// portForwardItem is a row of the port-forward panel
+type portForwardItem struct {
+	forward *services.PortForward
+}
+
+// FilterValue implements list.Item interface for filtering
+func (p portForwardItem) FilterValue() string {
+	return p.forward.Namespace + "/" + p.forward.Pod
+}
+
+// PortForwardList shows the running port-forwards with their local ports
+// and traffic, and stops them or starts new ones
+type PortForwardList struct {
+	list      list.Model
+	delegate  *portForwardDelegate
+	forwards  []*services.PortForward
+	namespace string
+
+	// input takes a new forward typed the way kubectl port-forward takes
+	// one, such as "svc/api 8080:80"
+	input    textinput.Model
+	adding   bool
+	inputErr error
+
+	focused bool
+	width   int
+	height  int
+}
+
+// NewPortForwardList creates a new port-forward panel
+func NewPortForwardList(width, height int) *PortForwardList {
+	delegate := newPortForwardDelegate()
+
+	l := list.New([]list.Item{}, delegate, width, max(height-portForwardListHeaderHeight-portForwardFooterHeight, 0))
+	l.Title = "Port forwards"
+	l.SetShowTitle(false)
+	l.SetShowStatusBar(true)
+	l.SetFilteringEnabled(false)
+	l.SetShowHelp(false)
+	l.SetStatusBarItemName("forward", "forwards")
+
+	input := textinput.New()
+	input.Prompt = "Forward: "
+	input.Placeholder = "svc/name 8080:80 or pod/name 8080:80"
+	input.CharLimit = 256
+
+	return &PortForwardList{
+		list:     l,
+		delegate: delegate,
+		input:    input,
+		width:    width,
+		height:   height,
+	}
+}
+
+// Init implements tea.Model interface
+func (pl *PortForwardList) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model interface
+func (pl *PortForwardList) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		pl.SetSize(msg.Width, msg.Height)
+		return pl, nil
+
+	case tea.KeyMsg:
+		if pl.adding {
+			return pl, pl.handleInputKey(msg)
+		}
+		switch msg.String() {
+		case "x", "d", "delete":
+			if forward := pl.SelectedForward(); forward != nil {
+				return pl, func() tea.Msg { return tui.PortForwardStopMsg{Forward: forward} }
+			}
+			return pl, nil
+		case "n":
+			pl.adding = true
+			pl.inputErr = nil
+			pl.input.SetValue("")
+			return pl, pl.input.Focus()
+		}
+
+	case tui.PortForwardsChangedMsg:
+		pl.forwards = msg.Forwards
+		if msg.Namespace != "" {
+			pl.namespace = msg.Namespace
+		}
+		pl.refresh()
+		return pl, nil
+	}
+
+	var cmd tea.Cmd
+	pl.list, cmd = pl.list.Update(msg)
+	return pl, cmd
+}
+
+// handleInputKey edits the new forward, starting it on enter
+func (pl *PortForwardList) handleInputKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyEsc:
+		pl.adding = false
+		pl.input.Blur()
+		return nil
+	case tea.KeyEnter:
+		request, err := parsePortForwardTarget(pl.input.Value(), pl.namespace)
+		if err != nil {
+			pl.inputErr = err
+			return nil
+		}
+		pl.adding = false
+		pl.input.Blur()
+		return func() tea.Msg { return request }
+	}
+
+	var cmd tea.Cmd
+	pl.input, cmd = pl.input.Update(msg)
+	pl.inputErr = nil
+	return cmd
+}
+
+// parsePortForwardTarget reads a forward the way kubectl port-forward takes
+// one: "svc/api 8080:80", "pod/api-7d9f 8080" or a bare pod name. A Service
+// may leave out the ports to forward its first port. "-n namespace" forwards
+// to another namespace than the one shown.
+func parsePortForwardTarget(text, namespace string) (tui.PortForwardRequestedMsg, error) {
+	request := tui.PortForwardRequestedMsg{Namespace: namespace}
+
+	var args []string
+	fields := strings.Fields(text)
+	for i := 0; i < len(fields); i++ {
+		if fields[i] == "-n" || fields[i] == "--namespace" {
+			if i+1 == len(fields) {
+				return request, fmt.Errorf("%s needs a namespace", fields[i])
+			}
+			request.Namespace = fields[i+1]
+			i++
+			continue
+		}
+		args = append(args, fields[i])
+	}
+	if len(args) == 0 || len(args) > 2 {
+		return request, fmt.Errorf("expected a pod or service and its ports, such as svc/api 8080:80")
+	}
+
+	kind, name, found := strings.Cut(args[0], "/")
+	if !found {
+		kind, name = "pod", args[0]
+	}
+	switch strings.ToLower(kind) {
+	case "pod", "pods", "po":
+		request.Pod = name
+	case "svc", "service", "services":
+		request.Service = name
+	default:
+		return request, fmt.Errorf("can only forward to a pod or service, not %s", kind)
+	}
+	if name == "" {
+		return request, fmt.Errorf("missing the name of the %s", kind)
+	}
+
+	if len(args) == 1 {
+		if request.Pod != "" {
+			return request, fmt.Errorf("missing the ports to forward to pod %s", name)
+		}
+		return request, nil
+	}
+	local, remote, err := parsePortPair(args[1])
+	if err != nil {
+		return request, err
+	}
+	request.LocalPort, request.RemotePort = local, remote
+	return request, nil
+}
+
+// refresh lists the forwards in the order they were started
+func (pl *PortForwardList) refresh() {
+	items := make([]list.Item, len(pl.forwards))
+	for i, forward := range pl.forwards {
+		items[i] = portForwardItem{forward: forward}
+	}
+	replaceItems(&pl.list, items, func(item list.Item) string {
+		if f, ok := item.(portForwardItem); ok {
+			return fmt.Sprintf("%p", f.forward)
+		}
+		return ""
+	})
+}
+
+// SelectedForward returns the highlighted forward
+func (pl *PortForwardList) SelectedForward() *services.PortForward {
+	if item, ok := pl.list.SelectedItem().(portForwardItem); ok {
+		return item.forward
+	}
+	return nil
+}
+
+// View implements tea.Model interface
+func (pl *PortForwardList) View() string {
+	titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("86")).Bold(true)
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	columns := fitCell("  LOCAL", portForwardLocalWidth+2) +
+		" " + fitCell("TARGET", portForwardTargetWidth(pl.list.Width())) +
+		" " + fitCell("AGE", portForwardAgeWidth) +
+		" " + fitCell("SENT", portForwardTrafficWidth) +
+		" " + fitCell("RECEIVED", portForwardTrafficWidth) +
+		" " + fitCell("STATUS", portForwardStatusWidth)
+
+	body := pl.list.View()
+	if len(pl.forwards) == 0 {
+		body = lipgloss.NewStyle().Height(max(pl.height-portForwardListHeaderHeight-portForwardFooterHeight, 0)).
+			Render(hintStyle.Render("  No port-forwards. Press p on a pod, or n to forward to a service."))
+	}
+
+	footer := hintStyle.Render("n: new forward • x: stop • esc: back")
+	if pl.adding {
+		footer = pl.input.View()
+		if pl.inputErr != nil {
+			footer += "\n" + pl.delegate.styles.failed.Render(pl.inputErr.Error())
+		}
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		titleStyle.Render("Port forwards"),
+		hintStyle.Bold(true).Render(columns),
+		body,
+		footer,
+	)
+}
+
+// Focus sets the component as focused
+func (pl *PortForwardList) Focus() tea.Cmd {
+	pl.focused = true
+	return nil
+}
+
+// Blur removes focus from the component
+func (pl *PortForwardList) Blur() tea.Cmd {
+	pl.focused = false
+	pl.adding = false
+	pl.input.Blur()
+	return nil
+}
+
+// IsFocused returns whether the component is focused
+func (pl *PortForwardList) IsFocused() bool {
+	return pl.focused
+}
+
+// CapturingInput reports whether a new forward is being typed
+func (pl *PortForwardList) CapturingInput() bool {
+	return pl.adding
+}
+
+// SetSize updates the component size
+func (pl *PortForwardList) SetSize(width, height int) {
+	pl.width = width
+	pl.height = height
+	pl.list.SetSize(width, max(height-portForwardListHeaderHeight-portForwardFooterHeight, 0))
+	pl.input.Width = max(width-len(pl.input.Prompt)-2, 1)
+}
+
+// GetSize returns the current component size
+func (pl *PortForwardList) GetSize() (int, int) {
+	return pl.width, pl.height
+}
+
+// portForwardTargetWidth returns the width of the TARGET column, which takes
+// what the other columns leave
+func portForwardTargetWidth(width int) int {
+	fixed := portForwardLocalWidth + 2 + 1 + 1 + portForwardAgeWidth + 1 + 2*(portForwardTrafficWidth+1) + portForwardStatusWidth
+	return max(width-fixed, portForwardTargetMin)
+}
+
+// formatByteCount formats an amount of traffic
+func formatByteCount(bytes int64) string {
+	if bytes < 1<<10 {
+		return fmt.Sprintf("%dB", bytes)
+	}
+	return formatMemory(bytes)
+}
+
+// portForwardDelegate renders forwards as table rows, with ended ones
+// showing why
+type portForwardDelegate struct {
+	styles portForwardDelegateStyles
+}
+
+type portForwardDelegateStyles struct {
+	normal   lipgloss.Style
+	selected lipgloss.Style
+	muted    lipgloss.Style
+	running  lipgloss.Style
+	failed   lipgloss.Style
+}
+
+func newPortForwardDelegate() *portForwardDelegate {
+	return &portForwardDelegate{
+		styles: portForwardDelegateStyles{
+			normal:   lipgloss.NewStyle().Foreground(lipgloss.Color("252")),
+			selected: lipgloss.NewStyle().Foreground(lipgloss.Color("86")).Bold(true),
+			muted:    lipgloss.NewStyle().Foreground(lipgloss.Color("240")),
+			running:  lipgloss.NewStyle().Foreground(lipgloss.Color("46")),  // Green
+			failed:   lipgloss.NewStyle().Foreground(lipgloss.Color("196")), // Red
+		},
+	}
+}
+
+func (d *portForwardDelegate) Height() int  { return 1 }
+func (d *portForwardDelegate) Spacing() int { return 0 }
+
+func (d *portForwardDelegate) Update(msg tea.Msg, m *list.Model) tea.Cmd {
+	return nil
+}
+
+func (d *portForwardDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	item, ok := listItem.(portForwardItem)
+	if !ok {
+		return
+	}
+	forward := item.forward
+
+	textStyle, mutedStyle, statusStyle := d.styles.normal, d.styles.muted, d.styles.running
+	status := "Running"
+	select {
+	case <-forward.Done():
+		status, statusStyle = "Ended", d.styles.failed
+		if err := forward.Err(); err != nil {
+			status = "Ended: " + err.Error()
+		}
+	default:
+	}
+	if index == m.Index() {
+		textStyle, mutedStyle = d.styles.selected, d.styles.selected
+	}
+
+	local := fmt.Sprintf("localhost:%d", forward.LocalPort)
+	target := fmt.Sprintf("%s/%s:%d", forward.Namespace, forward.Pod, forward.RemotePort)
+	row := textStyle.Render(fitCell("  "+local, portForwardLocalWidth+2)) +
+		" " + textStyle.Render(fitCell(target, portForwardTargetWidth(m.Width()))) +
+		" " + mutedStyle.Render(fitCell(duration.HumanDuration(time.Since(forward.StartedAt)), portForwardAgeWidth)) +
+		" " + textStyle.Render(fitCell(formatByteCount(forward.BytesSent()), portForwardTrafficWidth)) +
+		" " + textStyle.Render(fitCell(formatByteCount(forward.BytesReceived()), portForwardTrafficWidth)) +
+		" " + statusStyle.Render(fitCell(status, portForwardStatusWidth))
+	fmt.Fprint(w, row)
+}
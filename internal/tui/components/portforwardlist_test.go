@@ -0,0 +1,144 @@
+package components
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"kubeoptic/internal/services"
+	"kubeoptic/internal/tui"
+)
+
+func TestPortForwardList(t *testing.T) {
+	forwards := []*services.PortForward{
+		{
+			PortForwardRequest: services.PortForwardRequest{Namespace: "payments", Pod: "api-7d9f", LocalPort: 8080, RemotePort: 8080},
+			StartedAt:          time.Now().Add(-5 * time.Minute),
+		},
+		{
+			PortForwardRequest: services.PortForwardRequest{Namespace: "payments", Pod: "db-0", LocalPort: 15432, RemotePort: 5432},
+			StartedAt:          time.Now(),
+		},
+	}
+	typeKeys := func(pl *PortForwardList, keys ...string) tea.Cmd {
+		var cmd tea.Cmd
+		for _, k := range keys {
+			msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+			switch k {
+			case "enter":
+				msg = tea.KeyMsg{Type: tea.KeyEnter}
+			case "esc":
+				msg = tea.KeyMsg{Type: tea.KeyEsc}
+			case "down":
+				msg = tea.KeyMsg{Type: tea.KeyDown}
+			case " ":
+				msg = tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")}
+			}
+			_, cmd = pl.Update(msg)
+		}
+		return cmd
+	}
+	newList := func() *PortForwardList {
+		pl := NewPortForwardList(140, 20)
+		pl.Update(tui.PortForwardsChangedMsg{Forwards: forwards, Namespace: "payments"})
+		return pl
+	}
+
+	t.Run("lists_forwards", func(t *testing.T) {
+		view := newList().View()
+		for _, want := range []string{"LOCAL", "TARGET", "SENT", "RECEIVED", "localhost:8080", "payments/api-7d9f:8080", "localhost:15432", "payments/db-0:5432", "0B", "Running", "5m"} {
+			if !strings.Contains(view, want) {
+				t.Errorf("Expected %q in the view:\n%s", want, view)
+			}
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		pl := NewPortForwardList(140, 20)
+		if view := pl.View(); !strings.Contains(view, "No port-forwards") {
+			t.Errorf("Expected a hint when nothing is forwarded, got:\n%s", view)
+		}
+	})
+
+	t.Run("stops_selected", func(t *testing.T) {
+		pl := newList()
+		cmd := typeKeys(pl, "down", "x")
+		if cmd == nil {
+			t.Fatal("Expected x to stop the forward")
+		}
+		if msg, ok := cmd().(tui.PortForwardStopMsg); !ok || msg.Forward != forwards[1] {
+			t.Errorf("Expected the selected forward to be stopped, got %+v", msg)
+		}
+	})
+
+	t.Run("new_forward", func(t *testing.T) {
+		pl := newList()
+		typeKeys(pl, "n")
+		if !pl.CapturingInput() {
+			t.Fatal("Expected n to open the input")
+		}
+		cmd := typeKeys(pl, append(strings.Split("svc/api 9000:80", ""), "enter")...)
+		if cmd == nil {
+			t.Fatal("Expected enter to start a forward")
+		}
+		want := tui.PortForwardRequestedMsg{Namespace: "payments", Service: "api", LocalPort: 9000, RemotePort: 80}
+		if msg := cmd(); msg != want {
+			t.Errorf("Expected %+v, got %+v", want, msg)
+		}
+		if pl.CapturingInput() {
+			t.Error("Expected the input to close")
+		}
+	})
+
+	t.Run("invalid_forward", func(t *testing.T) {
+		pl := newList()
+		cmd := typeKeys(pl, append([]string{"n"}, append(strings.Split("deploy/api 80", ""), "enter")...)...)
+		if cmd != nil {
+			t.Error("Expected an invalid forward to start nothing")
+		}
+		if !pl.CapturingInput() || !strings.Contains(pl.View(), "can only forward to a pod or service") {
+			t.Errorf("Expected the error under the input, got:\n%s", pl.View())
+		}
+		typeKeys(pl, "esc")
+		if pl.CapturingInput() {
+			t.Error("Expected esc to close the input")
+		}
+	})
+}
+
+func TestParsePortForwardTarget(t *testing.T) {
+	tests := []struct {
+		text    string
+		want    tui.PortForwardRequestedMsg
+		wantErr bool
+	}{
+		{text: "svc/api 8080:80", want: tui.PortForwardRequestedMsg{Namespace: "default", Service: "api", LocalPort: 8080, RemotePort: 80}},
+		{text: "service/api", want: tui.PortForwardRequestedMsg{Namespace: "default", Service: "api"}},
+		{text: "pod/api-7d9f 8080", want: tui.PortForwardRequestedMsg{Namespace: "default", Pod: "api-7d9f", LocalPort: 8080, RemotePort: 8080}},
+		{text: "api-7d9f :8080", want: tui.PortForwardRequestedMsg{Namespace: "default", Pod: "api-7d9f", RemotePort: 8080}},
+		{text: "-n payments svc/api 80", want: tui.PortForwardRequestedMsg{Namespace: "payments", Service: "api", LocalPort: 80, RemotePort: 80}},
+		{text: "pod/api-7d9f", wantErr: true},
+		{text: "svc/api 8080:http", wantErr: true},
+		{text: "svc/api 70000", wantErr: true},
+		{text: "svc/ 80", wantErr: true},
+		{text: "svc/api 80 -n", wantErr: true},
+		{text: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, err := parsePortForwardTarget(tt.text, "default")
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected an error, got %+v", got)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("Expected %+v, got %+v (%v)", tt.want, got, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package components
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"kubeoptic/internal/services"
+	"kubeoptic/internal/tui"
+)
+
+// portForwardPicker chooses a port of a pod to forward to, from the ports
+// its containers declare, and the local port to forward from. The ports
+// are typed as "local:remote", prefilled from the declared port chosen.
+type portForwardPicker struct {
+	pod    services.Pod
+	ports  []services.ContainerPort
+	cursor int
+	input  textinput.Model
+	err    error
+}
+
+func newPortForwardPicker(pod services.Pod) *portForwardPicker {
+	input := textinput.New()
+	input.Prompt = "Ports: "
+	input.Placeholder = "local:remote"
+	input.CharLimit = 16
+	input.Focus()
+
+	picker := &portForwardPicker{pod: pod, input: input}
+	for _, port := range pod.Ports {
+		// Port-forwarding only carries TCP
+		if port.Protocol == "" || port.Protocol == "TCP" {
+			picker.ports = append(picker.ports, port)
+		}
+	}
+	picker.fill()
+	return picker
+}
+
+// fill types the chosen declared port into the input, forwarded from the
+// same local port
+func (p *portForwardPicker) fill() {
+	if p.cursor < len(p.ports) {
+		port := p.ports[p.cursor].Port
+		p.input.SetValue(fmt.Sprintf("%d:%d", port, port))
+		p.input.CursorEnd()
+	}
+}
+
+// handleKey moves through the declared ports and edits the typed ones. It
+// reports whether the picker is done, with the command starting the
+// forward if one was chosen.
+func (p *portForwardPicker) handleKey(msg tea.KeyMsg) (bool, tea.Cmd) {
+	switch msg.String() {
+	case "up":
+		if p.cursor > 0 {
+			p.cursor--
+			p.fill()
+		}
+		return false, nil
+	case "down":
+		if p.cursor < len(p.ports)-1 {
+			p.cursor++
+			p.fill()
+		}
+		return false, nil
+	case "enter":
+		local, remote, err := parsePortPair(p.input.Value())
+		if err != nil {
+			p.err = err
+			return false, nil
+		}
+		request := tui.PortForwardRequestedMsg{
+			Namespace:  p.pod.Namespace,
+			Pod:        p.pod.Name,
+			LocalPort:  local,
+			RemotePort: remote,
+		}
+		return true, func() tea.Msg { return request }
+	case "esc":
+		return true, nil
+	}
+
+	var cmd tea.Cmd
+	p.input, cmd = p.input.Update(msg)
+	p.err = nil
+	return false, cmd
+}
+
+// parsePortPair reads ports the way kubectl port-forward takes them: "8080"
+// forwards the same port, "9000:80" local port 9000 to port 80 and ":80" a
+// free local port to port 80
+func parsePortPair(text string) (local, remote int, err error) {
+	text = strings.TrimSpace(text)
+	localText, remoteText, found := strings.Cut(text, ":")
+	if !found {
+		remoteText = localText
+	}
+
+	if remote, err = parsePort(remoteText); err != nil || remote == 0 {
+		return 0, 0, fmt.Errorf("invalid remote port in %q", text)
+	}
+	if localText == "" {
+		return 0, remote, nil
+	}
+	if local, err = parsePort(localText); err != nil {
+		return 0, 0, fmt.Errorf("invalid local port in %q", text)
+	}
+	return local, remote, nil
+}
+
+func parsePort(text string) (int, error) {
+	port, err := strconv.Atoi(text)
+	if err != nil || port < 0 || port > 65535 {
+		return 0, fmt.Errorf("invalid port %q", text)
+	}
+	return port, nil
+}
+
+// view renders the declared ports with the typed ports below them
+func (p *portForwardPicker) view(width int, styles podDelegateStyles) string {
+	titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("86")).Bold(true)
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+
+	lines := []string{titleStyle.Render("Port-forward to " + p.pod.Name), ""}
+	if len(p.ports) == 0 {
+		lines = append(lines, mutedStyle.Render("  No ports declared; type the port to forward to"))
+	}
+	for i, port := range p.ports {
+		text := fmt.Sprintf("%d", port.Port)
+		if port.Name != "" {
+			text += " (" + port.Name + ")"
+		}
+		text += "  " + port.Container
+		if i == p.cursor {
+			lines = append(lines, styles.selected.Render("> "+text))
+		} else {
+			lines = append(lines, styles.normal.Render("  "+text))
+		}
+	}
+
+	lines = append(lines, "", p.input.View())
+	if p.err != nil {
+		lines = append(lines, errorStyle.Render(p.err.Error()))
+	}
+	lines = append(lines, "", mutedStyle.Render("enter: forward • ↑/↓: declared ports • esc: cancel"))
+
+	return lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("86")).
+		Width(max(width-2, 0)).
+		Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
@@ -0,0 +1,434 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"kubeoptic/internal/services"
+	"kubeoptic/internal/tui"
+	"kubeoptic/internal/tui/styles"
+)
+
+// rolloutHistoryChromeHeight is the number of lines around the revision
+// rows: title, rollout status, column headings, the image diff's heading
+// and the footer, with a blank line above the diff
+const rolloutHistoryChromeHeight = 6
+
+// rolloutRevisionWidth and rolloutAgeWidth are the widths of the REVISION
+// and AGE columns; CHANGE-CAUSE and IMAGES share what is left
+const (
+	rolloutRevisionWidth = 14
+	rolloutAgeWidth      = 5
+)
+
+// RolloutHistory shows the revisions of a Deployment, StatefulSet or
+// DaemonSet, newest first, with what the highlighted revision changed in
+// the images of its containers. A revision can be rolled back to, and the
+// progress of the workload's rollout is shown as it happens.
+type RolloutHistory struct {
+	workload  services.Workload
+	revisions []services.Revision
+	cursor    int
+	offset    int
+	status    *services.RolloutStatus
+	loading   bool
+	err       error
+
+	focused bool
+	width   int
+	height  int
+
+	theme  styles.Theme
+	styles rolloutHistoryStyles
+}
+
+type rolloutHistoryStyles struct {
+	title    lipgloss.Style
+	header   lipgloss.Style
+	muted    lipgloss.Style
+	normal   lipgloss.Style
+	selected lipgloss.Style
+	current  lipgloss.Style
+	progress lipgloss.Style
+	done     lipgloss.Style
+	failed   lipgloss.Style
+	added    lipgloss.Style
+	removed  lipgloss.Style
+}
+
+// NewRolloutHistory creates a new revision history view
+func NewRolloutHistory(width, height int) *RolloutHistory {
+	theme := styles.DefaultTheme()
+	return &RolloutHistory{
+		width:  width,
+		height: height,
+		theme:  theme,
+		styles: rolloutHistoryStyles{
+			title:    lipgloss.NewStyle().Bold(true).Foreground(theme.Primary).Padding(0, 1),
+			header:   lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Bold(true),
+			muted:    lipgloss.NewStyle().Foreground(lipgloss.Color("240")),
+			normal:   lipgloss.NewStyle().Foreground(lipgloss.Color("252")),
+			selected: lipgloss.NewStyle().Background(lipgloss.Color("62")).Foreground(lipgloss.Color("230")),
+			current:  lipgloss.NewStyle().Foreground(lipgloss.Color("86")),
+			progress: lipgloss.NewStyle().Foreground(theme.Warning),
+			done:     lipgloss.NewStyle().Foreground(theme.Success),
+			failed:   lipgloss.NewStyle().Foreground(theme.Error),
+			added:    lipgloss.NewStyle().Foreground(lipgloss.Color("114")),
+			removed:  lipgloss.NewStyle().Foreground(lipgloss.Color("196")),
+		},
+	}
+}
+
+// Init implements tea.Model interface
+func (rh *RolloutHistory) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model interface
+func (rh *RolloutHistory) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		rh.SetSize(msg.Width, msg.Height)
+		return rh, nil
+
+	case tea.KeyMsg:
+		return rh, rh.handleKeyPress(msg)
+
+	case tui.RolloutHistoryRequestedMsg:
+		if msg.Workload == nil {
+			return rh, nil
+		}
+		// Another workload starts from the top with nothing shown
+		if !rh.shows(msg.Workload.Namespace, msg.Workload.Kind, msg.Workload.Name) {
+			rh.revisions = nil
+			rh.cursor, rh.offset = 0, 0
+			rh.status = nil
+			rh.err = nil
+		}
+		rh.workload = *msg.Workload
+		return rh, nil
+
+	case tui.RolloutHistoryLoadedMsg:
+		rh.err = msg.Error
+		if msg.Error == nil {
+			rh.setRevisions(msg.Revisions)
+		}
+		return rh, nil
+
+	case tui.RolloutStatusMsg:
+		if msg.Error != nil || !rh.shows(msg.Namespace, msg.Kind, msg.Name) {
+			return rh, nil
+		}
+		// A rollout settling has made a revision current, maybe a new one
+		settled := rh.status != nil && !rh.status.Done && msg.Status.Done
+		status := msg.Status
+		rh.status = &status
+		if settled {
+			return rh, rh.reload()
+		}
+		return rh, nil
+
+	case tui.LoadingStartedMsg:
+		if msg.Component == tui.LoadingRollout {
+			rh.loading = true
+		}
+		return rh, nil
+
+	case tui.LoadingCompletedMsg:
+		if msg.Component == tui.LoadingRollout {
+			rh.loading = false
+		}
+		return rh, nil
+	}
+
+	return rh, nil
+}
+
+// shows reports whether the history is of the given workload
+func (rh *RolloutHistory) shows(namespace, kind, name string) bool {
+	return rh.workload.Namespace == namespace && rh.workload.Kind == kind && rh.workload.Name == name
+}
+
+// setRevisions lists revisions newest first, keeping the highlighted one
+func (rh *RolloutHistory) setRevisions(revisions []services.Revision) {
+	var selected int64
+	if revision := rh.SelectedRevision(); revision != nil {
+		selected = revision.Number
+	}
+
+	rh.revisions = make([]services.Revision, len(revisions))
+	for i, revision := range revisions {
+		rh.revisions[len(revisions)-1-i] = revision
+	}
+
+	rh.cursor = 0
+	for i, revision := range rh.revisions {
+		if revision.Number == selected {
+			rh.cursor = i
+		}
+	}
+	rh.scrollToCursor()
+}
+
+func (rh *RolloutHistory) handleKeyPress(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "up", "k":
+		rh.moveCursor(-1)
+	case "down", "j":
+		rh.moveCursor(1)
+	case "home", "g":
+		rh.moveCursor(-len(rh.revisions))
+	case "end", "G":
+		rh.moveCursor(len(rh.revisions))
+
+	case "enter", "u":
+		// Roll back to the highlighted revision, once confirmed
+		revision := rh.SelectedRevision()
+		if revision == nil || revision.Current {
+			return nil
+		}
+		request := tui.ActionRequestedMsg{
+			Action:    tui.ActionRollback,
+			Namespace: rh.workload.Namespace,
+			Kind:      rh.workload.Kind,
+			Name:      rh.workload.Name,
+			Revision:  revision.Number,
+		}
+		return func() tea.Msg { return request }
+
+	case "r":
+		return rh.reload()
+	}
+	return nil
+}
+
+// reload fetches the history of the shown workload again
+func (rh *RolloutHistory) reload() tea.Cmd {
+	if rh.workload.Name == "" {
+		return nil
+	}
+	workload := rh.workload
+	return func() tea.Msg {
+		return tui.RolloutHistoryRequestedMsg{Workload: &workload}
+	}
+}
+
+func (rh *RolloutHistory) moveCursor(step int) {
+	if len(rh.revisions) == 0 {
+		return
+	}
+	rh.cursor = min(max(rh.cursor+step, 0), len(rh.revisions)-1)
+	rh.scrollToCursor()
+}
+
+// scrollToCursor keeps the highlighted revision among the rows shown
+func (rh *RolloutHistory) scrollToCursor() {
+	rows := rh.visibleRows()
+	if rh.cursor < rh.offset {
+		rh.offset = rh.cursor
+	}
+	if rh.cursor >= rh.offset+rows {
+		rh.offset = rh.cursor - rows + 1
+	}
+	rh.offset = max(rh.offset, 0)
+}
+
+// visibleRows is how many revisions are listed: half the lines the chrome
+// leaves, the other half going to the image diff
+func (rh *RolloutHistory) visibleRows() int {
+	return max((rh.height-rolloutHistoryChromeHeight)/2, 1)
+}
+
+// SelectedRevision returns the highlighted revision
+func (rh *RolloutHistory) SelectedRevision() *services.Revision {
+	if rh.cursor < len(rh.revisions) {
+		return &rh.revisions[rh.cursor]
+	}
+	return nil
+}
+
+// View implements tea.Model interface
+func (rh *RolloutHistory) View() string {
+	title := "History: " + strings.ToLower(rh.workload.Kind) + " " + rh.workload.Namespace + "/" + rh.workload.Name
+	if rh.loading {
+		title += " (loading...)"
+	}
+
+	lines := []string{rh.styles.title.Render(title), rh.renderStatus()}
+	switch {
+	case rh.err != nil:
+		lines = append(lines, styles.ErrorMessageStyles(rh.theme, rh.width).Render(fmt.Sprintf("Error: %v", rh.err)))
+	case rh.revisions == nil:
+		lines = append(lines, rh.styles.muted.Render("Loading revision history..."))
+	case len(rh.revisions) == 0:
+		lines = append(lines, rh.styles.muted.Render("No revisions kept"))
+	default:
+		lines = append(lines, rh.renderTable()...)
+		lines = append(lines, "")
+		lines = append(lines, rh.renderDiff()...)
+	}
+
+	body := lipgloss.NewStyle().Height(max(rh.height-1, 0)).Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+	footer := rh.styles.muted.Render("↑/↓ select • enter/u roll back to revision • r reload • esc back")
+	return lipgloss.JoinVertical(lipgloss.Left, body, footer)
+}
+
+// renderStatus shows how far the workload's rollout has got
+func (rh *RolloutHistory) renderStatus() string {
+	switch {
+	case rh.status == nil:
+		return rh.styles.muted.Render(" Rollout status unknown")
+	case rh.status.Failed:
+		return rh.styles.failed.Render(" ✗ " + rh.status.Message)
+	case rh.status.Done:
+		return rh.styles.done.Render(" ✓ " + rh.status.Message)
+	default:
+		return rh.styles.progress.Render(" ⟳ " + rh.status.Message)
+	}
+}
+
+// renderTable lists the revisions shown, with their column headings
+func (rh *RolloutHistory) renderTable() []string {
+	causeWidth, imagesWidth := rh.columnWidths()
+	lines := []string{rh.styles.header.Render(fitCell("  REVISION", rolloutRevisionWidth) + " " +
+		fitCell("AGE", rolloutAgeWidth) + " " + fitCell("CHANGE-CAUSE", causeWidth) + " " + fitCell("IMAGES", imagesWidth))}
+
+	end := min(rh.offset+rh.visibleRows(), len(rh.revisions))
+	for i := rh.offset; i < end; i++ {
+		revision := rh.revisions[i]
+		number := fmt.Sprintf("  %d", revision.Number)
+		if revision.Current {
+			number += " current"
+		}
+		cause := revision.ChangeCause
+		if cause == "" {
+			cause = "<none>"
+		}
+		images := make([]string, len(revision.Images))
+		for j, image := range revision.Images {
+			images[j] = image.Image
+		}
+
+		row := fitCell(number, rolloutRevisionWidth) + " " +
+			fitCell(podAge(revision.CreatedAt, time.Now()), rolloutAgeWidth) + " " +
+			fitCell(cause, causeWidth) + " " +
+			fitCell(strings.Join(images, ","), imagesWidth)
+
+		style := rh.styles.normal
+		switch {
+		case i == rh.cursor:
+			style = rh.styles.selected
+		case revision.Current:
+			style = rh.styles.current
+		}
+		lines = append(lines, style.Render(row))
+	}
+	return lines
+}
+
+// columnWidths splits the width left after REVISION and AGE between
+// CHANGE-CAUSE and IMAGES
+func (rh *RolloutHistory) columnWidths() (cause, images int) {
+	remaining := max(rh.width-rolloutRevisionWidth-rolloutAgeWidth-3, 2)
+	cause = remaining * 2 / 5
+	return cause, remaining - cause
+}
+
+// renderDiff shows how the highlighted revision changed the images of the
+// containers from the revision before it
+func (rh *RolloutHistory) renderDiff() []string {
+	revision := rh.SelectedRevision()
+	if revision == nil {
+		return nil
+	}
+	if rh.cursor == len(rh.revisions)-1 {
+		lines := []string{rh.styles.header.Render(fmt.Sprintf("Images of revision %d, the oldest kept", revision.Number))}
+		for _, image := range revision.Images {
+			lines = append(lines, rh.styles.normal.Render("  "+image.Container+": "+image.Image))
+		}
+		return lines
+	}
+
+	previous := rh.revisions[rh.cursor+1]
+	lines := []string{rh.styles.header.Render(fmt.Sprintf("Image changes from revision %d", previous.Number))}
+	changes := imageChanges(previous.Images, revision.Images)
+	if len(changes) == 0 {
+		return append(lines, rh.styles.muted.Render("  No image changes"))
+	}
+	for _, change := range changes {
+		switch {
+		case change.from == "":
+			lines = append(lines, rh.styles.added.Render("  + "+change.container+": "+change.to))
+		case change.to == "":
+			lines = append(lines, rh.styles.removed.Render("  - "+change.container+": "+change.from))
+		default:
+			lines = append(lines, rh.styles.normal.Render("  ~ "+change.container+": "+change.from+" → "+change.to))
+		}
+	}
+	return lines
+}
+
+// imageChange is a container whose image differs between two revisions;
+// from is empty for an added container and to for a removed one
+type imageChange struct {
+	container string
+	from      string
+	to        string
+}
+
+// imageChanges compares the images of the containers of two revisions, in
+// the order of the later revision's containers, then the removed ones
+func imageChanges(before, after []services.ContainerImage) []imageChange {
+	previous := make(map[string]string, len(before))
+	for _, image := range before {
+		previous[image.Container] = image.Image
+	}
+	kept := make(map[string]bool, len(after))
+
+	var changes []imageChange
+	for _, image := range after {
+		kept[image.Container] = true
+		if previous[image.Container] != image.Image {
+			changes = append(changes, imageChange{container: image.Container, from: previous[image.Container], to: image.Image})
+		}
+	}
+	for _, image := range before {
+		if !kept[image.Container] {
+			changes = append(changes, imageChange{container: image.Container, from: image.Image})
+		}
+	}
+	return changes
+}
+
+// Focus sets the component as focused
+func (rh *RolloutHistory) Focus() tea.Cmd {
+	rh.focused = true
+	return nil
+}
+
+// Blur removes focus from the component
+func (rh *RolloutHistory) Blur() tea.Cmd {
+	rh.focused = false
+	return nil
+}
+
+// IsFocused returns whether the component is focused
+func (rh *RolloutHistory) IsFocused() bool {
+	return rh.focused
+}
+
+// SetSize updates the component size
+func (rh *RolloutHistory) SetSize(width, height int) {
+	rh.width = width
+	rh.height = height
+	rh.scrollToCursor()
+}
+
+// GetSize returns the current component size
+func (rh *RolloutHistory) GetSize() (int, int) {
+	return rh.width, rh.height
+}
@@ -0,0 +1,155 @@
+package components
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"kubeoptic/internal/services"
+	"kubeoptic/internal/tui"
+)
+
+func TestRolloutHistory(t *testing.T) {
+	workload := &services.Workload{Kind: services.KindDeployment, Namespace: "payments", Name: "api"}
+	created := time.Now().Add(-3 * time.Hour)
+	revisions := []services.Revision{
+		{Number: 1, Name: "api-6b7f", CreatedAt: created, ChangeCause: "initial release",
+			Images: []services.ContainerImage{{Container: "api", Image: "api:1.0"}, {Container: "proxy", Image: "envoy:1.27"}}},
+		{Number: 2, Name: "api-7c8d", CreatedAt: created,
+			Images: []services.ContainerImage{{Container: "api", Image: "api:1.1"}, {Container: "metrics", Image: "exporter:0.3"}}},
+		{Number: 3, Name: "api-8d9e", CreatedAt: created, ChangeCause: "bump to 1.2", Current: true,
+			Images: []services.ContainerImage{{Container: "api", Image: "api:1.2"}, {Container: "metrics", Image: "exporter:0.3"}}},
+	}
+
+	newHistory := func() *RolloutHistory {
+		history := NewRolloutHistory(120, 30)
+		history.Update(tui.RolloutHistoryRequestedMsg{Workload: workload})
+		history.Update(tui.RolloutHistoryLoadedMsg{Revisions: revisions})
+		return history
+	}
+	key := func(history *RolloutHistory, k string) tea.Cmd {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		switch k {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "down":
+			msg = tea.KeyMsg{Type: tea.KeyDown}
+		}
+		_, cmd := history.Update(msg)
+		return cmd
+	}
+
+	t.Run("newest_first", func(t *testing.T) {
+		history := newHistory()
+		view := history.View()
+		for _, want := range []string{"History: deployment payments/api", "REVISION", "CHANGE-CAUSE", "3 current", "bump to 1.2", "<none>", "initial release"} {
+			if !strings.Contains(view, want) {
+				t.Errorf("Expected %q in the view:\n%s", want, view)
+			}
+		}
+		if strings.Index(view, "bump to 1.2") > strings.Index(view, "initial release") {
+			t.Error("Expected the newest revision first")
+		}
+		if got := history.SelectedRevision(); got == nil || got.Number != 3 {
+			t.Errorf("Expected the newest revision highlighted, got %+v", got)
+		}
+	})
+
+	t.Run("image_diff", func(t *testing.T) {
+		history := newHistory()
+		if view := history.View(); !strings.Contains(view, "Image changes from revision 2") || !strings.Contains(view, "api: api:1.1 → api:1.2") {
+			t.Errorf("Expected the image change from revision 2:\n%s", view)
+		}
+
+		key(history, "down")
+		view := history.View()
+		for _, want := range []string{"Image changes from revision 1", "api: api:1.0 → api:1.1", "+ metrics: exporter:0.3", "- proxy: envoy:1.27"} {
+			if !strings.Contains(view, want) {
+				t.Errorf("Expected %q in the diff:\n%s", want, view)
+			}
+		}
+
+		key(history, "down")
+		if view := history.View(); !strings.Contains(view, "the oldest kept") || !strings.Contains(view, "proxy: envoy:1.27") {
+			t.Errorf("Expected the images of the oldest revision:\n%s", view)
+		}
+	})
+
+	t.Run("rollback", func(t *testing.T) {
+		history := newHistory()
+		if cmd := key(history, "enter"); cmd != nil {
+			t.Error("Expected no rollback to the current revision")
+		}
+
+		key(history, "down")
+		cmd := key(history, "enter")
+		if cmd == nil {
+			t.Fatal("Expected enter to ask to roll back")
+		}
+		msg, ok := cmd().(tui.ActionRequestedMsg)
+		if !ok || msg.Action != tui.ActionRollback || msg.Kind != services.KindDeployment || msg.Name != "api" || msg.Revision != 2 {
+			t.Errorf("Expected a request to roll back to revision 2, got %+v", msg)
+		}
+	})
+
+	t.Run("reload_keeps_selection", func(t *testing.T) {
+		history := newHistory()
+		key(history, "down")
+		history.Update(tui.RolloutHistoryRequestedMsg{Workload: workload})
+		history.Update(tui.RolloutHistoryLoadedMsg{Revisions: append(revisions, services.Revision{Number: 4, Name: "api-9e0f"})})
+		if got := history.SelectedRevision(); got == nil || got.Number != 2 {
+			t.Errorf("Expected revision 2 to stay highlighted, got %+v", got)
+		}
+	})
+
+	t.Run("rollout_status", func(t *testing.T) {
+		history := newHistory()
+		progress := tui.RolloutStatusMsg{Namespace: "payments", Kind: services.KindDeployment, Name: "api",
+			Status: services.RolloutStatus{Message: "Waiting for rollout to finish: 1 out of 3 new replicas have been updated"}}
+		if _, cmd := history.Update(progress); cmd != nil {
+			t.Error("Expected no reload while rolling out")
+		}
+		if !strings.Contains(history.View(), "1 out of 3 new replicas") {
+			t.Error("Expected the rollout's progress in the view")
+		}
+
+		// Another workload's rollout isn't shown
+		other := progress
+		other.Name, other.Status.Message = "worker", "worker rolling out"
+		history.Update(other)
+		if strings.Contains(history.View(), "worker rolling out") {
+			t.Error("Expected only the shown workload's rollout")
+		}
+
+		// Settling reloads the history for the revision now current
+		done := progress
+		done.Status = services.RolloutStatus{Message: `deployment "api" successfully rolled out`, Done: true}
+		_, cmd := history.Update(done)
+		if cmd == nil {
+			t.Fatal("Expected the history to reload once the rollout settles")
+		}
+		if msg, ok := cmd().(tui.RolloutHistoryRequestedMsg); !ok || msg.Workload.Name != "api" {
+			t.Errorf("Expected a reload of api, got %+v", cmd())
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		history := NewRolloutHistory(120, 30)
+		history.Update(tui.RolloutHistoryRequestedMsg{Workload: workload})
+		history.Update(tui.RolloutHistoryLoadedMsg{Error: errors.New("forbidden")})
+		if !strings.Contains(history.View(), "forbidden") {
+			t.Error("Expected the error in the view")
+		}
+	})
+
+	t.Run("another_workload_starts_empty", func(t *testing.T) {
+		history := newHistory()
+		history.Update(tui.RolloutHistoryRequestedMsg{Workload: &services.Workload{Kind: services.KindStatefulSet, Namespace: "payments", Name: "db"}})
+		if history.SelectedRevision() != nil || !strings.Contains(history.View(), "Loading revision history") {
+			t.Error("Expected the previous workload's revisions to be dropped")
+		}
+	})
+}
@@ -0,0 +1,185 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// textSearch is the search of the scrollable text views: a search bar, the
+// query typed into it, the lines matching the query and the match shown.
+// Matching ignores case.
+type textSearch struct {
+	searchInput   textinput.Model
+	searchMode    bool
+	searchQuery   string
+	searchResults []int // line numbers with matches
+	currentResult int
+	searchHistory []string
+}
+
+func newTextSearch(placeholder string) textSearch {
+	searchInput := textinput.New()
+	searchInput.Placeholder = placeholder
+	searchInput.CharLimit = 256
+
+	return textSearch{
+		searchInput:   searchInput,
+		searchHistory: make([]string, 0, maxSearchHistory),
+	}
+}
+
+func (s *textSearch) enterSearchMode() {
+	s.searchMode = true
+	s.searchInput.Focus()
+}
+
+func (s *textSearch) exitSearchMode() {
+	s.searchMode = false
+	s.searchInput.Blur()
+}
+
+// handleSearchKey feeds a key typed while the search bar is open, searching
+// as the query is typed. Enter keeps the query and esc leaves it as typed so
+// far; both close the bar. It reports whether the query changed.
+func (s *textSearch) handleSearchKey(msg tea.KeyMsg) (bool, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		return s.commitSearch(), nil
+
+	case tea.KeyEsc:
+		s.exitSearchMode()
+		return false, nil
+	}
+
+	var cmd tea.Cmd
+	s.searchInput, cmd = s.searchInput.Update(msg)
+	if s.searchInput.Value() == s.searchQuery {
+		return false, cmd
+	}
+	s.searchQuery = s.searchInput.Value()
+	return true, cmd
+}
+
+// commitSearch closes the search bar keeping the typed query, if any, in the
+// history. It reports whether there was a query.
+func (s *textSearch) commitSearch() bool {
+	defer s.exitSearchMode()
+	query := s.searchInput.Value()
+	if query == "" {
+		return false
+	}
+	s.searchQuery = query
+	s.addToSearchHistory(query)
+	return true
+}
+
+// matches reports whether a line contains the query
+func (s *textSearch) matches(line string) bool {
+	return s.searchQuery != "" && strings.Contains(strings.ToLower(line), strings.ToLower(s.searchQuery))
+}
+
+// findMatches records the lines that contain the query, starting over at
+// the first of them
+func (s *textSearch) findMatches(lines []string) {
+	s.searchResults = make([]int, 0)
+	s.currentResult = 0
+	if s.searchQuery == "" {
+		return
+	}
+	for i, line := range lines {
+		if s.matches(line) {
+			s.searchResults = append(s.searchResults, i)
+			if len(s.searchResults) == maxSearchResults {
+				break
+			}
+		}
+	}
+}
+
+func (s *textSearch) nextMatch(vp *viewport.Model) {
+	if len(s.searchResults) == 0 {
+		return
+	}
+	s.currentResult = (s.currentResult + 1) % len(s.searchResults)
+	s.showMatch(vp)
+}
+
+func (s *textSearch) prevMatch(vp *viewport.Model) {
+	if len(s.searchResults) == 0 {
+		return
+	}
+	s.currentResult = (s.currentResult - 1 + len(s.searchResults)) % len(s.searchResults)
+	s.showMatch(vp)
+}
+
+// showMatch scrolls the current match to the middle of the viewport
+func (s *textSearch) showMatch(vp *viewport.Model) {
+	if s.currentResult < 0 || s.currentResult >= len(s.searchResults) {
+		return
+	}
+
+	lineNum := s.searchResults[s.currentResult]
+	vp.SetYOffset(max(lineNum-vp.Height/2, 0))
+}
+
+func (s *textSearch) resetSearch() {
+	s.searchQuery = ""
+	s.searchResults = make([]int, 0)
+	s.currentResult = 0
+	s.searchInput.SetValue("")
+}
+
+func (s *textSearch) addToSearchHistory(query string) {
+	// Add to history if not already present
+	for _, existing := range s.searchHistory {
+		if existing == query {
+			return
+		}
+	}
+
+	s.searchHistory = append(s.searchHistory, query)
+	if len(s.searchHistory) > maxSearchHistory {
+		s.searchHistory = s.searchHistory[1:]
+	}
+}
+
+// highlightMatches renders each occurrence of the query in a line with the
+// given style
+func (s *textSearch) highlightMatches(line string, style lipgloss.Style) string {
+	if s.searchQuery == "" {
+		return line
+	}
+
+	lower, query := strings.ToLower(line), strings.ToLower(s.searchQuery)
+	if len(lower) != len(line) {
+		// Case folding moved the byte offsets; match the query as typed
+		return strings.ReplaceAll(line, s.searchQuery, style.Render(s.searchQuery))
+	}
+
+	var b strings.Builder
+	for {
+		i := strings.Index(lower, query)
+		if i < 0 {
+			break
+		}
+		b.WriteString(line[:i])
+		b.WriteString(style.Render(line[i : i+len(query)]))
+		line, lower = line[i+len(query):], lower[i+len(query):]
+	}
+	b.WriteString(line)
+	return b.String()
+}
+
+// searchBar renders the search bar with the position of the current match
+func (s *textSearch) searchBar(style lipgloss.Style) string {
+	input := style.Render(searchPrompt) + s.searchInput.View()
+	if len(s.searchResults) > 0 {
+		input += style.Render(fmt.Sprintf(" (%d/%d)", s.currentResult+1, len(s.searchResults)))
+	}
+	return input
+}
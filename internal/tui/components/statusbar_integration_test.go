@@ -21,8 +21,8 @@ func TestStatusBarIntegration_WindowResize(t *testing.T) {
 		t.Error("Status bar should not return commands on window resize")
 	}
 
-	if updatedStatusBar.width != 120 {
-		t.Errorf("Expected width 120 after resize, got %d", updatedStatusBar.width)
+	if updatedStatusBar.(*StatusBar).width != 120 {
+		t.Errorf("Expected width 120 after resize, got %d", updatedStatusBar.(*StatusBar).width)
 	}
 
 	// Test that the view respects the new width
@@ -0,0 +1,489 @@
+package components
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"kubeoptic/internal/services"
+	"kubeoptic/internal/tui"
+)
+
+// workloadListHeaderHeight is the number of lines the kind tabs and column
+// headings take
+const workloadListHeaderHeight = 2
+
+// workloadNameMinWidth is the narrowest NAME column before columns are dropped
+const workloadNameMinWidth = 20
+
+// workloadKinds lists the kinds of the workload view in tab order
+var workloadKinds = []string{
+	services.KindDeployment,
+	services.KindStatefulSet,
+	services.KindDaemonSet,
+	services.KindJob,
+	services.KindCronJob,
+}
+
+// workloadColumn is a column of the workload table after NAME
+type workloadColumn struct {
+	title string
+	width int
+	value func(services.Workload) string
+}
+
+var (
+	workloadReadyColumn = workloadColumn{title: "READY", width: 7, value: func(w services.Workload) string {
+		return fmt.Sprintf("%d/%d", w.Ready, w.Desired)
+	}}
+	workloadUpToDateColumn = workloadColumn{title: "UP-TO-DATE", width: 10, value: func(w services.Workload) string {
+		return fmt.Sprintf("%d", w.UpToDate)
+	}}
+	workloadAvailableColumn = workloadColumn{title: "AVAILABLE", width: 9, value: func(w services.Workload) string {
+		return fmt.Sprintf("%d", w.Available)
+	}}
+	workloadStatusColumn = workloadColumn{title: "STATUS", width: 11, value: func(w services.Workload) string {
+		return w.Status
+	}}
+	workloadAgeColumn = workloadColumn{title: "AGE", width: 5, value: func(w services.Workload) string {
+		return podAge(w.CreatedAt, time.Now())
+	}}
+)
+
+// workloadColumns returns the columns shown for a kind, like kubectl get
+func workloadColumns(kind string) []workloadColumn {
+	switch kind {
+	case services.KindDaemonSet:
+		return []workloadColumn{
+			{title: "DESIRED", width: 7, value: func(w services.Workload) string { return fmt.Sprintf("%d", w.Desired) }},
+			{title: "READY", width: 5, value: func(w services.Workload) string { return fmt.Sprintf("%d", w.Ready) }},
+			workloadUpToDateColumn, workloadAvailableColumn, workloadStatusColumn, workloadAgeColumn,
+		}
+	case services.KindStatefulSet:
+		return []workloadColumn{workloadReadyColumn, workloadUpToDateColumn, workloadStatusColumn, workloadAgeColumn}
+	case services.KindJob:
+		return []workloadColumn{
+			{title: "COMPLETIONS", width: 11, value: func(w services.Workload) string {
+				return fmt.Sprintf("%d/%d", w.Ready, w.Desired)
+			}},
+			{title: "ACTIVE", width: 6, value: func(w services.Workload) string { return fmt.Sprintf("%d", w.Available) }},
+			workloadStatusColumn, workloadAgeColumn,
+		}
+	case services.KindCronJob:
+		return []workloadColumn{
+			{title: "SCHEDULE", width: 14, value: func(w services.Workload) string { return w.Schedule }},
+			{title: "ACTIVE", width: 6, value: func(w services.Workload) string { return fmt.Sprintf("%d", w.Ready) }},
+			{title: "LAST SCHEDULE", width: 13, value: func(w services.Workload) string {
+				if w.LastSchedule.IsZero() {
+					return "<none>"
+				}
+				return podAge(w.LastSchedule, time.Now())
+			}},
+			workloadStatusColumn, workloadAgeColumn,
+		}
+	default:
+		return []workloadColumn{workloadReadyColumn, workloadUpToDateColumn, workloadAvailableColumn, workloadStatusColumn, workloadAgeColumn}
+	}
+}
+
+// workloadTableLayout keeps the columns that fit next to NAME, returning
+// them with the width left for NAME
+func workloadTableLayout(width int, columns []workloadColumn) ([]workloadColumn, int) {
+	var visible []workloadColumn
+	remaining := width
+	for _, column := range columns {
+		if remaining-column.width-1 < workloadNameMinWidth {
+			break
+		}
+		visible = append(visible, column)
+		remaining -= column.width + 1
+	}
+	return visible, max(remaining, 1)
+}
+
+// workloadListItem is a row of the workload view
+type workloadListItem struct {
+	workload services.Workload
+}
+
+// FilterValue implements list.Item interface for filtering
+func (w workloadListItem) FilterValue() string {
+	return w.workload.Name
+}
+
+// WorkloadList shows the Deployments, StatefulSets, DaemonSets, Jobs or
+// CronJobs of a namespace with their rollout status
+type WorkloadList struct {
+	list      list.Model
+	delegate  *workloadDelegate
+	kind      string
+	namespace string
+	focused   bool
+	width     int
+	height    int
+
+	// Replica count typed for the workload being scaled, in place of the
+	// kind tabs while scaling
+	scaling    *services.Workload
+	scaleInput textinput.Model
+	scaleErr   error
+}
+
+// NewWorkloadList creates a new workload list component
+func NewWorkloadList(width, height int) *WorkloadList {
+	delegate := &workloadDelegate{styles: newPodDelegate().styles, kind: services.KindDeployment}
+
+	l := list.New([]list.Item{}, delegate, width, max(height-workloadListHeaderHeight, 0))
+	l.Title = "Workloads"
+	l.SetShowTitle(false)
+	l.SetShowStatusBar(true)
+	l.SetFilteringEnabled(true)
+	l.SetShowHelp(true)
+
+	scaleInput := textinput.New()
+	scaleInput.CharLimit = 6
+
+	return &WorkloadList{
+		list:       l,
+		delegate:   delegate,
+		kind:       services.KindDeployment,
+		width:      width,
+		height:     height,
+		scaleInput: scaleInput,
+	}
+}
+
+// Init implements tea.Model interface
+func (wl *WorkloadList) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model interface
+func (wl *WorkloadList) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		wl.SetSize(msg.Width, msg.Height)
+		return wl, nil
+
+	case tea.KeyMsg:
+		if wl.scaling != nil {
+			return wl, wl.handleScaleKey(msg)
+		}
+		if wl.list.FilterState() == list.Filtering {
+			break
+		}
+		switch msg.String() {
+		case "left", "h":
+			return wl, wl.selectKind(-1)
+		case "right", "l":
+			return wl, wl.selectKind(1)
+		case "enter":
+			if item, ok := wl.list.SelectedItem().(workloadListItem); ok {
+				workload := item.workload
+				return wl, func() tea.Msg {
+					return tui.WorkloadDrillDownMsg{Workload: &workload}
+				}
+			}
+			return wl, nil
+		case "y":
+			if item, ok := wl.list.SelectedItem().(workloadListItem); ok {
+				workload := item.workload
+				return wl, func() tea.Msg {
+					return tui.ManifestRequestedMsg{Kind: workload.Kind, Namespace: workload.Namespace, Name: workload.Name}
+				}
+			}
+			return wl, nil
+		case "s":
+			return wl, wl.startScaling()
+		case "r":
+			if workload := wl.SelectedWorkload(); workload != nil && hasRollout(workload.Kind) {
+				request := tui.ActionRequestedMsg{
+					Action:    tui.ActionRestart,
+					Namespace: workload.Namespace,
+					Kind:      workload.Kind,
+					Name:      workload.Name,
+				}
+				return wl, func() tea.Msg { return request }
+			}
+			return wl, nil
+		case "H":
+			if workload := wl.SelectedWorkload(); workload != nil && hasRollout(workload.Kind) {
+				return wl, func() tea.Msg {
+					return tui.RolloutHistoryRequestedMsg{Workload: workload}
+				}
+			}
+			return wl, nil
+		}
+
+	case tui.WorkloadListLoadedMsg:
+		if msg.Error != nil {
+			return wl, nil
+		}
+		// Another kind or namespace starts from the top with no filter
+		if msg.Kind != wl.kind || msg.Namespace != wl.namespace {
+			wl.list.ResetFilter()
+			wl.list.ResetSelected()
+		}
+		wl.setKind(msg.Kind)
+		wl.namespace = msg.Namespace
+		wl.SetWorkloads(msg.Workloads)
+		return wl, nil
+
+	case tui.LoadingStartedMsg:
+		if msg.Component == tui.LoadingWorkloadList {
+			return wl, wl.list.StartSpinner()
+		}
+		return wl, nil
+
+	case tui.LoadingCompletedMsg:
+		if msg.Component == tui.LoadingWorkloadList {
+			wl.list.StopSpinner()
+		}
+		return wl, nil
+	}
+
+	var cmd tea.Cmd
+	wl.list, cmd = wl.list.Update(msg)
+	return wl, cmd
+}
+
+// selectKind moves to the previous or next kind tab
+func (wl *WorkloadList) selectKind(step int) tea.Cmd {
+	current := 0
+	for i, kind := range workloadKinds {
+		if kind == wl.kind {
+			current = i
+		}
+	}
+	next := (current + step + len(workloadKinds)) % len(workloadKinds)
+	wl.setKind(workloadKinds[next])
+	wl.SetWorkloads(nil)
+
+	kind := wl.kind
+	return func() tea.Msg {
+		return tui.WorkloadKindSelectedMsg{Kind: kind}
+	}
+}
+
+// startScaling asks for the replica count of the selected Deployment or
+// StatefulSet, starting from its desired count
+func (wl *WorkloadList) startScaling() tea.Cmd {
+	workload := wl.SelectedWorkload()
+	if workload == nil || (workload.Kind != services.KindDeployment && workload.Kind != services.KindStatefulSet) {
+		return nil
+	}
+	wl.scaling = workload
+	wl.scaleErr = nil
+	wl.scaleInput.Prompt = fmt.Sprintf("Scale %s to: ", workload.Name)
+	wl.scaleInput.SetValue(strconv.Itoa(workload.Desired))
+	wl.scaleInput.CursorEnd()
+	return wl.scaleInput.Focus()
+}
+
+// handleScaleKey edits the typed replica count, asking to scale to it on
+// enter
+func (wl *WorkloadList) handleScaleKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyEsc:
+		wl.stopScaling()
+		return nil
+	case tea.KeyEnter:
+		replicas, err := strconv.ParseInt(strings.TrimSpace(wl.scaleInput.Value()), 10, 32)
+		if err != nil || replicas < 0 {
+			wl.scaleErr = fmt.Errorf("invalid replica count %q", wl.scaleInput.Value())
+			return nil
+		}
+		request := tui.ActionRequestedMsg{
+			Action:    tui.ActionScale,
+			Namespace: wl.scaling.Namespace,
+			Kind:      wl.scaling.Kind,
+			Name:      wl.scaling.Name,
+			Replicas:  int32(replicas),
+		}
+		wl.stopScaling()
+		return func() tea.Msg { return request }
+	}
+
+	var cmd tea.Cmd
+	wl.scaleInput, cmd = wl.scaleInput.Update(msg)
+	wl.scaleErr = nil
+	return cmd
+}
+
+func (wl *WorkloadList) stopScaling() {
+	wl.scaling = nil
+	wl.scaleErr = nil
+	wl.scaleInput.Blur()
+}
+
+// hasRollout reports whether a kind of workload rolls out its pod template,
+// keeping a history of revisions
+func hasRollout(kind string) bool {
+	switch kind {
+	case services.KindDeployment, services.KindStatefulSet, services.KindDaemonSet:
+		return true
+	}
+	return false
+}
+
+func (wl *WorkloadList) setKind(kind string) {
+	wl.kind = kind
+	wl.delegate.kind = kind
+}
+
+// SetWorkloads replaces the listed workloads, keeping the selection
+func (wl *WorkloadList) SetWorkloads(workloads []services.Workload) {
+	items := make([]list.Item, len(workloads))
+	for i, workload := range workloads {
+		items[i] = workloadListItem{workload: workload}
+	}
+	replaceItems(&wl.list, items, func(item list.Item) string {
+		if w, ok := item.(workloadListItem); ok {
+			return w.workload.Kind + "/" + w.workload.Name
+		}
+		return ""
+	})
+}
+
+// Kind returns the kind of workload shown
+func (wl *WorkloadList) Kind() string {
+	return wl.kind
+}
+
+// View implements tea.Model interface
+func (wl *WorkloadList) View() string {
+	tabs := make([]string, len(workloadKinds))
+	for i, kind := range workloadKinds {
+		style := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Padding(0, 1)
+		if kind == wl.kind {
+			style = style.Foreground(lipgloss.Color("86")).Bold(true).Underline(true)
+		}
+		tabs[i] = style.Render(kind + "s")
+	}
+
+	columns, nameWidth := workloadTableLayout(wl.list.Width(), workloadColumns(wl.kind))
+	header := fitCell("  NAME", nameWidth)
+	for _, column := range columns {
+		header += " " + fitCell(column.title, column.width)
+	}
+	headerStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Bold(true)
+
+	top := lipgloss.JoinHorizontal(lipgloss.Top, tabs...)
+	if wl.scaling != nil {
+		top = wl.scaleInput.View()
+		if wl.scaleErr != nil {
+			top += "  " + lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render(wl.scaleErr.Error())
+		}
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		top,
+		headerStyle.Render(header),
+		wl.list.View(),
+	)
+}
+
+// SelectedWorkload returns the highlighted workload
+func (wl *WorkloadList) SelectedWorkload() *services.Workload {
+	if item, ok := wl.list.SelectedItem().(workloadListItem); ok {
+		return &item.workload
+	}
+	return nil
+}
+
+// Focus sets the component as focused
+func (wl *WorkloadList) Focus() tea.Cmd {
+	wl.focused = true
+	return nil
+}
+
+// Blur removes focus from the component
+func (wl *WorkloadList) Blur() tea.Cmd {
+	wl.focused = false
+	wl.stopScaling()
+	return nil
+}
+
+// IsFocused returns whether the component is focused
+func (wl *WorkloadList) IsFocused() bool {
+	return wl.focused
+}
+
+// CapturingInput reports whether keys are being typed into the filter or
+// the replica count
+func (wl *WorkloadList) CapturingInput() bool {
+	return wl.scaling != nil || wl.list.FilterState() == list.Filtering
+}
+
+// SetSize updates the component size
+func (wl *WorkloadList) SetSize(width, height int) {
+	wl.width = width
+	wl.height = height
+	wl.list.SetSize(width, max(height-workloadListHeaderHeight, 0))
+}
+
+// GetSize returns the current component size
+func (wl *WorkloadList) GetSize() (int, int) {
+	return wl.width, wl.height
+}
+
+// workloadDelegate renders workloads as table rows colored by status
+type workloadDelegate struct {
+	styles podDelegateStyles
+	kind   string
+}
+
+func (d *workloadDelegate) Height() int  { return 1 }
+func (d *workloadDelegate) Spacing() int { return 0 }
+
+func (d *workloadDelegate) Update(msg tea.Msg, m *list.Model) tea.Cmd {
+	return nil
+}
+
+func (d *workloadDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	item, ok := listItem.(workloadListItem)
+	if !ok {
+		return
+	}
+
+	statusStyle := d.statusStyle(item.workload.Status)
+	cellStyle := d.styles.normal
+	nameStyle := statusStyle
+	if index == m.Index() {
+		statusStyle = d.styles.selected
+		cellStyle = d.styles.selected
+		nameStyle = d.styles.selected
+	}
+
+	columns, nameWidth := workloadTableLayout(m.Width(), workloadColumns(d.kind))
+	row := nameStyle.Render(fitCell("  "+item.workload.Name, nameWidth))
+	for _, column := range columns {
+		style := cellStyle
+		if column.title == "STATUS" {
+			style = statusStyle
+		}
+		row += " " + style.Render(fitCell(column.value(item.workload), column.width))
+	}
+	fmt.Fprint(w, row)
+}
+
+// statusStyle colors a rollout status like the matching pod phase
+func (d *workloadDelegate) statusStyle(status string) lipgloss.Style {
+	switch strings.ToLower(status) {
+	case "complete", "scheduled":
+		return d.styles.running
+	case "progressing", "running":
+		return d.styles.pending
+	case "failed":
+		return d.styles.failed
+	default:
+		return d.styles.unknown
+	}
+}
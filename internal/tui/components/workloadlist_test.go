@@ -0,0 +1,200 @@
+package components
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"kubeoptic/internal/services"
+	"kubeoptic/internal/tui"
+)
+
+func TestWorkloadList(t *testing.T) {
+	created := time.Now().Add(-5 * 24 * time.Hour)
+	deployments := []services.Workload{
+		{Kind: services.KindDeployment, Name: "api", Namespace: "payments", Desired: 3, Ready: 3, UpToDate: 3, Available: 3,
+			Status: "Complete", Selector: "app=api", CreatedAt: created},
+		{Kind: services.KindDeployment, Name: "worker", Namespace: "payments", Desired: 2, Ready: 1, UpToDate: 1, Available: 1,
+			Status: "Progressing", Selector: "app=worker", CreatedAt: created},
+	}
+
+	newList := func() *WorkloadList {
+		workloadList := NewWorkloadList(120, 20)
+		workloadList.Update(tui.WorkloadListLoadedMsg{
+			Kind:      services.KindDeployment,
+			Namespace: "payments",
+			Workloads: deployments,
+		})
+		return workloadList
+	}
+
+	t.Run("shows_rollout_status", func(t *testing.T) {
+		view := newList().View()
+		for _, want := range []string{"Deployments", "READY", "UP-TO-DATE", "AVAILABLE", "api", "3/3", "Progressing", "5d"} {
+			if !strings.Contains(view, want) {
+				t.Errorf("Expected %q in the view", want)
+			}
+		}
+	})
+
+	t.Run("columns_follow_kind", func(t *testing.T) {
+		workloadList := NewWorkloadList(120, 20)
+		workloadList.Update(tui.WorkloadListLoadedMsg{
+			Kind:      services.KindCronJob,
+			Namespace: "payments",
+			Workloads: []services.Workload{
+				{Kind: services.KindCronJob, Name: "nightly", Schedule: "0 2 * * *", Status: "Scheduled", CreatedAt: created},
+			},
+		})
+
+		view := workloadList.View()
+		for _, want := range []string{"SCHEDULE", "LAST SCHEDULE", "0 2 * * *", "<none>"} {
+			if !strings.Contains(view, want) {
+				t.Errorf("Expected %q in the CronJob view", want)
+			}
+		}
+		if strings.Contains(view, "UP-TO-DATE") {
+			t.Error("CronJobs have no UP-TO-DATE column")
+		}
+	})
+
+	t.Run("narrow_drops_columns", func(t *testing.T) {
+		columns, nameWidth := workloadTableLayout(40, workloadColumns(services.KindDeployment))
+		if len(columns) == 0 || len(columns) == 5 {
+			t.Fatalf("Expected some but not all columns at width 40, got %d", len(columns))
+		}
+		if nameWidth < workloadNameMinWidth {
+			t.Errorf("Expected NAME to keep at least %d columns, got %d", workloadNameMinWidth, nameWidth)
+		}
+	})
+
+	t.Run("arrows_switch_kind", func(t *testing.T) {
+		workloadList := newList()
+
+		_, cmd := workloadList.Update(tea.KeyMsg{Type: tea.KeyRight})
+		if cmd == nil {
+			t.Fatal("Expected right to return a command")
+		}
+		msg, ok := cmd().(tui.WorkloadKindSelectedMsg)
+		if !ok || msg.Kind != services.KindStatefulSet {
+			t.Errorf("Expected StatefulSets next, got %+v", cmd())
+		}
+
+		_, cmd = workloadList.Update(tea.KeyMsg{Type: tea.KeyLeft})
+		_, cmd = workloadList.Update(tea.KeyMsg{Type: tea.KeyLeft})
+		if msg, ok := cmd().(tui.WorkloadKindSelectedMsg); !ok || msg.Kind != services.KindCronJob {
+			t.Errorf("Expected left to wrap around to CronJobs, got %+v", cmd())
+		}
+	})
+
+	t.Run("enter_drills_down", func(t *testing.T) {
+		workloadList := newList()
+		workloadList.Update(tea.KeyMsg{Type: tea.KeyDown})
+
+		_, cmd := workloadList.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		if cmd == nil {
+			t.Fatal("Expected enter to return a command")
+		}
+		msg, ok := cmd().(tui.WorkloadDrillDownMsg)
+		if !ok || msg.Workload == nil {
+			t.Fatalf("Expected a WorkloadDrillDownMsg, got %T", cmd())
+		}
+		if msg.Workload.Name != "worker" || msg.Workload.Selector != "app=worker" {
+			t.Errorf("Unexpected workload %+v", msg.Workload)
+		}
+	})
+
+	t.Run("reload_keeps_selection", func(t *testing.T) {
+		workloadList := newList()
+		workloadList.Update(tea.KeyMsg{Type: tea.KeyDown})
+		workloadList.Update(tui.WorkloadListLoadedMsg{
+			Kind:      services.KindDeployment,
+			Namespace: "payments",
+			Workloads: append([]services.Workload{{Kind: services.KindDeployment, Name: "admin"}}, deployments...),
+		})
+
+		if got := workloadList.SelectedWorkload(); got == nil || got.Name != "worker" {
+			t.Errorf("Expected the selection to stay on worker, got %+v", got)
+		}
+	})
+
+	t.Run("scale_prompt", func(t *testing.T) {
+		workloadList := newList()
+		workloadList.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+		if !workloadList.CapturingInput() {
+			t.Fatal("Expected s to ask for the replica count")
+		}
+		if view := workloadList.View(); !strings.Contains(view, "Scale api to: 3") {
+			t.Errorf("Expected the prompt prefilled with the desired count:\n%s", view)
+		}
+
+		workloadList.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+		workloadList.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+		if _, cmd := workloadList.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil || !workloadList.CapturingInput() {
+			t.Fatal("Expected an invalid count to be refused")
+		}
+		if !strings.Contains(workloadList.View(), "invalid replica count") {
+			t.Error("Expected the invalid count to be reported")
+		}
+
+		workloadList.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+		workloadList.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("5")})
+		_, cmd := workloadList.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		if cmd == nil || workloadList.CapturingInput() {
+			t.Fatal("Expected enter to ask to scale")
+		}
+		msg, ok := cmd().(tui.ActionRequestedMsg)
+		if !ok || msg.Action != tui.ActionScale || msg.Name != "api" || msg.Replicas != 5 {
+			t.Errorf("Expected a request to scale api to 5, got %+v", msg)
+		}
+	})
+
+	t.Run("scale_cancelled", func(t *testing.T) {
+		workloadList := newList()
+		workloadList.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+		if _, cmd := workloadList.Update(tea.KeyMsg{Type: tea.KeyEsc}); cmd != nil || workloadList.CapturingInput() {
+			t.Error("Expected esc to cancel scaling")
+		}
+		if !strings.Contains(workloadList.View(), "Deployments") {
+			t.Error("Expected the kind tabs back")
+		}
+	})
+
+	t.Run("rollout_keys", func(t *testing.T) {
+		workloadList := newList()
+		_, cmd := workloadList.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+		if cmd == nil {
+			t.Fatal("Expected r to return a command")
+		}
+		if msg, ok := cmd().(tui.ActionRequestedMsg); !ok || msg.Action != tui.ActionRestart || msg.Kind != services.KindDeployment || msg.Name != "api" {
+			t.Errorf("Expected a request to restart api, got %+v", cmd())
+		}
+
+		_, cmd = workloadList.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("H")})
+		if cmd == nil {
+			t.Fatal("Expected H to return a command")
+		}
+		if msg, ok := cmd().(tui.RolloutHistoryRequestedMsg); !ok || msg.Workload == nil || msg.Workload.Name != "api" {
+			t.Errorf("Expected the history of api, got %+v", cmd())
+		}
+	})
+
+	t.Run("jobs_have_no_rollout", func(t *testing.T) {
+		workloadList := NewWorkloadList(120, 20)
+		workloadList.Update(tui.WorkloadListLoadedMsg{
+			Kind:      services.KindJob,
+			Namespace: "payments",
+			Workloads: []services.Workload{{Kind: services.KindJob, Name: "migrate", Namespace: "payments"}},
+		})
+		for _, k := range []string{"s", "r", "H"} {
+			if _, cmd := workloadList.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}); cmd != nil {
+				t.Errorf("Expected %s to do nothing for a Job", k)
+			}
+		}
+		if workloadList.CapturingInput() {
+			t.Error("Expected Jobs not to be scaled")
+		}
+	})
+}
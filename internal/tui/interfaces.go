@@ -23,15 +23,11 @@ type AppStateManager interface {
 
 	// Selection state
 	GetSelectedContext() string
-	SetSelectedContext(string)
 	GetSelectedNamespace() string
-	SetSelectedNamespace(string)
 	GetSelectedPod() *services.Pod
-	SetSelectedPod(*services.Pod)
 
 	// Search state
 	GetPodSearchQuery() string
-	SetPodSearchQuery(string)
 	GetFilteredPods() []services.Pod
 
 	// Log state
@@ -73,6 +69,12 @@ type Searchable interface {
 	GetSearchResults() []interface{}
 }
 
+// InputCapturer defines interface for components with a text input
+// Used so global key bindings don't take keys that are being typed
+type InputCapturer interface {
+	CapturingInput() bool
+}
+
 // Resizable defines interface for components that need to handle window resize
 // Used for responsive layout management
 type Resizable interface {
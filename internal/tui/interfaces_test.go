@@ -97,13 +97,9 @@ type mockAppStateManager struct{}
 func (m *mockAppStateManager) GetCurrentView() models.ViewType { return 0 }
 func (m *mockAppStateManager) SetCurrentView(models.ViewType)  {}
 func (m *mockAppStateManager) GetSelectedContext() string      { return "" }
-func (m *mockAppStateManager) SetSelectedContext(string)       {}
 func (m *mockAppStateManager) GetSelectedNamespace() string    { return "" }
-func (m *mockAppStateManager) SetSelectedNamespace(string)     {}
 func (m *mockAppStateManager) GetSelectedPod() *services.Pod   { return nil }
-func (m *mockAppStateManager) SetSelectedPod(*services.Pod)    {}
 func (m *mockAppStateManager) GetPodSearchQuery() string       { return "" }
-func (m *mockAppStateManager) SetPodSearchQuery(string)        {}
 func (m *mockAppStateManager) GetFilteredPods() []services.Pod { return nil }
 func (m *mockAppStateManager) GetLogBuffer() []string          { return nil }
 func (m *mockAppStateManager) IsFollowing() bool               { return false }
@@ -75,7 +75,7 @@ func (n *NavigationState) NavigateToView(view models.ViewType) tea.Cmd {
 			n.focusedPanel = FocusContext
 		case models.NamespaceView:
 			n.focusedPanel = FocusNamespace
-		case models.PodView:
+		case models.PodView, models.WorkloadView, models.EventView, models.DescribeView, models.ManifestView, models.PortForwardView, models.RolloutView, models.FilesView, models.EditView:
 			n.focusedPanel = FocusPod
 		case models.LogView:
 			n.focusedPanel = FocusLog
@@ -100,9 +100,13 @@ func (n *NavigationState) NavigateBack() tea.Cmd {
 
 	// Determine where to go back to based on current view
 	switch n.currentView {
-	case models.LogView:
+	case models.LogView, models.DescribeView, models.ManifestView, models.FilesView:
 		targetView = models.PodView
-	case models.PodView:
+	case models.RolloutView:
+		targetView = models.WorkloadView
+	case models.EditView:
+		targetView = models.ManifestView
+	case models.PodView, models.WorkloadView, models.EventView, models.PortForwardView:
 		targetView = models.NamespaceView
 	case models.NamespaceView:
 		targetView = models.ContextView
@@ -37,6 +37,7 @@ const (
 	Warning        = lipgloss.Color("#F0E68C")
 	Success        = lipgloss.Color("#98FB98")
 	Info           = lipgloss.Color("#61DAFB")
+	Production     = lipgloss.Color("#FF5F00")
 )
 
 // Theme represents a color theme for the TUI
@@ -51,6 +52,10 @@ type Theme struct {
 	Warning    lipgloss.Color
 	Success    lipgloss.Color
 	Info       lipgloss.Color
+
+	// Production marks borders and the status bar while connected to a
+	// production context
+	Production lipgloss.Color
 }
 
 // DefaultTheme returns the default color theme
@@ -66,6 +71,7 @@ func DefaultTheme() Theme {
 		Warning:    Warning,
 		Success:    Success,
 		Info:       Info,
+		Production: Production,
 	}
 }
 
@@ -82,6 +88,7 @@ func DarkTheme() Theme {
 		Warning:    PrimaryYellow,
 		Success:    PrimaryGreen,
 		Info:       PrimaryBlue,
+		Production: Production,
 	}
 }
 
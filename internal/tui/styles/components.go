@@ -90,6 +90,10 @@ type LogViewerStyles struct {
 	Timestamp  lipgloss.Style
 	ScrollBar  lipgloss.Style
 	EmptyState lipgloss.Style
+
+	// Kubernetes events shown between log lines
+	NormalEvent  lipgloss.Style
+	WarningEvent lipgloss.Style
 }
 
 // NewLogViewerStyles creates styles for the log viewer
@@ -131,6 +135,15 @@ func NewLogViewerStyles(theme Theme, width, height int, focused bool) LogViewerS
 			Foreground(Gray).
 			Width(20),
 
+		NormalEvent: lipgloss.NewStyle().
+			Foreground(theme.Info).
+			Italic(true),
+
+		WarningEvent: lipgloss.NewStyle().
+			Foreground(theme.Warning).
+			Bold(true).
+			Italic(true),
+
 		ScrollBar: lipgloss.NewStyle().
 			Background(theme.Border).
 			Foreground(theme.Primary),
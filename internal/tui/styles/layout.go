@@ -27,6 +27,11 @@ const (
 	MinLogViewerHeight = 15
 	MinLogViewerWidth  = 50
 
+	// Pod table NAME column: the least it keeps before other columns are
+	// dropped, and the most it takes before they grow
+	PodNameMinWidth     = 24
+	PodNameComfortWidth = 48
+
 	// Responsive breakpoints
 	SmallScreenWidth  = 80
 	MediumScreenWidth = 120
@@ -45,6 +50,9 @@ type LayoutConfig struct {
 	PodListWidth       int
 	LogViewerWidth     int
 	LogViewerHeight    int
+
+	PodNameMinWidth     int
+	PodNameComfortWidth int
 }
 
 // NewLayoutConfig creates a layout configuration for given screen dimensions
@@ -66,18 +74,24 @@ func NewLayoutConfig(width, height int) LayoutConfig {
 		config.NamespaceListWidth = width - 4
 		config.PodListWidth = width - 4
 		config.LogViewerWidth = width - 4
+		config.PodNameMinWidth = PodNameMinWidth * 2 / 3
+		config.PodNameComfortWidth = PodNameMinWidth
 	} else if config.IsMediumScreen {
 		// Medium screens: 3-column layout
 		config.ContextListWidth = width / 4
 		config.NamespaceListWidth = width / 4
 		config.PodListWidth = width / 2
 		config.LogViewerWidth = width - 4
+		config.PodNameMinWidth = PodNameMinWidth
+		config.PodNameComfortWidth = PodNameComfortWidth
 	} else {
 		// Large screens: optimized 3-column layout
 		config.ContextListWidth = width / 5
 		config.NamespaceListWidth = width / 5
 		config.PodListWidth = (width * 3) / 5
 		config.LogViewerWidth = width - 4
+		config.PodNameMinWidth = PodNameMinWidth
+		config.PodNameComfortWidth = PodNameComfortWidth + PodNameMinWidth/2
 	}
 
 	// Calculate log viewer height (reserve space for header and status)
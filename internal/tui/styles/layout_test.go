@@ -130,6 +130,25 @@ func TestGetPanelWidth(t *testing.T) {
 	}
 }
 
+func TestLayoutConfigPodNameWidths(t *testing.T) {
+	tests := []struct {
+		width       int
+		wantMin     int
+		wantComfort int
+	}{
+		{60, 16, 24},  // Small screen: NAME gives way to columns
+		{100, 24, 48}, // Medium screen: base widths
+		{180, 24, 60}, // Large screen: long names shown in full first
+	}
+
+	for _, test := range tests {
+		config := NewLayoutConfig(test.width, 30)
+		if config.PodNameMinWidth != test.wantMin || config.PodNameComfortWidth != test.wantComfort {
+			t.Errorf("width %d: pod NAME widths = %d, %d, want %d, %d", test.width, config.PodNameMinWidth, config.PodNameComfortWidth, test.wantMin, test.wantComfort)
+		}
+	}
+}
+
 func TestGetMainViewHeight(t *testing.T) {
 	tests := []struct {
 		height   int
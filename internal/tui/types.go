@@ -35,8 +35,53 @@ const (
 type ContextsLoadedMsg = messages.ContextsLoadedMsg
 type NamespacesLoadedMsg = messages.NamespacesLoadedMsg
 type PodsLoadedMsg = messages.PodsLoadedMsg
+type KubeconfigLoadedMsg = messages.KubeconfigLoadedMsg
+type KubeconfigReloadedMsg = messages.KubeconfigReloadedMsg
 type ContextSelectedMsg = messages.ContextSelectedMsg
 type PodSelectedMsg = messages.PodSelectedMsg
+type PodTableChangedMsg = messages.PodTableChangedMsg
+type WorkloadsLoadedMsg = messages.WorkloadsLoadedMsg
+type WorkloadSelectedMsg = messages.WorkloadSelectedMsg
+type WorkloadListLoadedMsg = messages.WorkloadListLoadedMsg
+type WorkloadKindSelectedMsg = messages.WorkloadKindSelectedMsg
+type WorkloadDrillDownMsg = messages.WorkloadDrillDownMsg
+type EventsLoadedMsg = messages.EventsLoadedMsg
+type EventsRequestedMsg = messages.EventsRequestedMsg
+type LogEventsToggledMsg = messages.LogEventsToggledMsg
+type DescribeRequestedMsg = messages.DescribeRequestedMsg
+type PodDescribedMsg = messages.PodDescribedMsg
+type ManifestRequestedMsg = messages.ManifestRequestedMsg
+type ManifestLoadedMsg = messages.ManifestLoadedMsg
+type EditRequestedMsg = messages.EditRequestedMsg
+type EditorRequestedMsg = messages.EditorRequestedMsg
+type EditorClosedMsg = messages.EditorClosedMsg
+type EditCheckedMsg = messages.EditCheckedMsg
+type EditAppliedMsg = messages.EditAppliedMsg
+type MetricsLoadedMsg = messages.MetricsLoadedMsg
+type ExecRequestedMsg = messages.ExecRequestedMsg
+type ExecFinishedMsg = messages.ExecFinishedMsg
+type DebugContainerStartedMsg = messages.DebugContainerStartedMsg
+type ActionRequestedMsg = messages.ActionRequestedMsg
+type RolloutStartedMsg = messages.RolloutStartedMsg
+type RolloutStatusMsg = messages.RolloutStatusMsg
+type RolloutHistoryRequestedMsg = messages.RolloutHistoryRequestedMsg
+type RolloutHistoryLoadedMsg = messages.RolloutHistoryLoadedMsg
+type PortForwardRequestedMsg = messages.PortForwardRequestedMsg
+type PortForwardStartedMsg = messages.PortForwardStartedMsg
+type PortForwardEndedMsg = messages.PortForwardEndedMsg
+type PortForwardStopMsg = messages.PortForwardStopMsg
+type PortForwardsChangedMsg = messages.PortForwardsChangedMsg
+type FilesRequestedMsg = messages.FilesRequestedMsg
+type FilesLoadedMsg = messages.FilesLoadedMsg
+type DownloadRequestedMsg = messages.DownloadRequestedMsg
+type DownloadStartedMsg = messages.DownloadStartedMsg
+type DownloadEndedMsg = messages.DownloadEndedMsg
+type DownloadCancelMsg = messages.DownloadCancelMsg
+type DownloadsChangedMsg = messages.DownloadsChangedMsg
+type AllPodsLoadedMsg = messages.AllPodsLoadedMsg
+type PaletteOpenedMsg = messages.PaletteOpenedMsg
+type PaletteClosedMsg = messages.PaletteClosedMsg
+type PodJumpMsg = messages.PodJumpMsg
 
 // Log Messages
 type LogChunkMsg = messages.LogChunkMsg
@@ -64,5 +109,38 @@ type ClearStatusMsg = messages.ClearStatusMsg
 type InitCompleteMsg = messages.InitCompleteMsg
 type ShutdownMsg = messages.ShutdownMsg
 type RefreshDataMsg = messages.RefreshDataMsg
+type KubeconfigTickMsg = messages.KubeconfigTickMsg
+type MetricsTickMsg = messages.MetricsTickMsg
+type PortForwardTickMsg = messages.PortForwardTickMsg
+type RolloutTickMsg = messages.RolloutTickMsg
+type DownloadTickMsg = messages.DownloadTickMsg
+type ResourcesChangedMsg = messages.ResourcesChangedMsg
 type LoadingStartedMsg = messages.LoadingStartedMsg
 type LoadingCompletedMsg = messages.LoadingCompletedMsg
+
+const (
+	LoadingNamespaces   = messages.LoadingNamespaces
+	LoadingPods         = messages.LoadingPods
+	LoadingLogs         = messages.LoadingLogs
+	LoadingAllPods      = messages.LoadingAllPods
+	LoadingWorkloads    = messages.LoadingWorkloads
+	LoadingWorkloadList = messages.LoadingWorkloadList
+	LoadingEvents       = messages.LoadingEvents
+	LoadingDescribe     = messages.LoadingDescribe
+	LoadingManifest     = messages.LoadingManifest
+	LoadingMetrics      = messages.LoadingMetrics
+	LoadingRollout      = messages.LoadingRollout
+	LoadingFiles        = messages.LoadingFiles
+	LoadingEdit         = messages.LoadingEdit
+)
+
+// Actions that change the cluster
+const (
+	ActionDeletePod      = messages.ActionDeletePod
+	ActionForceDeletePod = messages.ActionForceDeletePod
+	ActionRestart        = messages.ActionRestart
+	ActionScale          = messages.ActionScale
+	ActionRollback       = messages.ActionRollback
+	ActionDebug          = messages.ActionDebug
+	ActionEdit           = messages.ActionEdit
+)
@@ -1,3 +1,174 @@
 package config
 
-// TODO: Implement application configuration
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Config holds user preferences that persist between runs
+type Config struct {
+	PodTable PodTable `json:"podTable"`
+	Safety   Safety   `json:"safety"`
+	Debug    Debug    `json:"debug"`
+}
+
+// PodTable configures the columns, sort order and grouping of the pod list
+type PodTable struct {
+	// Columns lists the visible columns after NAME, in display order.
+	// Nil shows every column; an empty list shows only NAME.
+	Columns         []string `json:"columns"`
+	SortBy          string   `json:"sortBy,omitempty"`
+	SortDescending  bool     `json:"sortDescending,omitempty"`
+	GroupByWorkload bool     `json:"groupByWorkload,omitempty"`
+}
+
+// DefaultDebugImage is the image debug containers run when none is configured
+const DefaultDebugImage = "busybox:1.36"
+
+// Debug configures the ephemeral containers added to pods to debug them
+type Debug struct {
+	// Image is the image debug containers run, which should have a shell
+	Image string `json:"image,omitempty"`
+}
+
+// ImageOrDefault returns the configured image, or the default without one
+func (d Debug) ImageOrDefault() string {
+	if d.Image == "" {
+		return DefaultDebugImage
+	}
+	return d.Image
+}
+
+// DefaultProductionContexts are the patterns marking production contexts
+// when none are configured
+var DefaultProductionContexts = []string{"*prod*"}
+
+// Safety guards clusters against changes made by mistake. Context patterns
+// match context names, where * stands for any run of characters.
+type Safety struct {
+	// ReadOnly disables every action that changes a cluster
+	ReadOnly bool `json:"readOnly,omitempty"`
+
+	// ReadOnlyContexts are always read-only
+	ReadOnlyContexts []string `json:"readOnlyContexts,omitempty"`
+
+	// ProductionContexts are shown in the production colour, and their name
+	// must be typed to confirm a change. Nil uses the defaults; an empty
+	// list marks no context.
+	ProductionContexts []string `json:"productionContexts"`
+}
+
+// IsReadOnly reports whether changes to the named context are disabled
+func (s Safety) IsReadOnly(context string) bool {
+	return s.ReadOnly || MatchContext(s.ReadOnlyContexts, context)
+}
+
+// IsProduction reports whether the named context is a production one
+func (s Safety) IsProduction(context string) bool {
+	patterns := s.ProductionContexts
+	if patterns == nil {
+		patterns = DefaultProductionContexts
+	}
+	return MatchContext(patterns, context)
+}
+
+// MatchContext reports whether a context name matches any of the patterns.
+// Matching ignores case.
+func MatchContext(patterns []string, context string) bool {
+	for _, pattern := range patterns {
+		if matchPattern(strings.ToLower(pattern), strings.ToLower(context)) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPattern matches a name against a pattern where * stands for any run
+// of characters, including slashes as in EKS context names
+func matchPattern(pattern, name string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == name
+	}
+
+	first, last := parts[0], parts[len(parts)-1]
+	if !strings.HasPrefix(name, first) {
+		return false
+	}
+	name = name[len(first):]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(name, part)
+		if i < 0 {
+			return false
+		}
+		name = name[i+len(part):]
+	}
+	return strings.HasSuffix(name, last)
+}
+
+// Default returns the configuration used when no config file exists
+func Default() *Config {
+	return &Config{}
+}
+
+// DefaultPath returns the config file location, kubeoptic/config.yaml under
+// the user's config directory (e.g. ~/.config on Linux)
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate config directory: %w", err)
+	}
+	return filepath.Join(dir, "kubeoptic", "config.yaml"), nil
+}
+
+// Load reads the config file at path, returning the defaults when it doesn't exist
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return Default(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config %s: %w", path, err)
+	}
+
+	cfg := Default()
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Save writes the config to path, creating its directory if needed. The file
+// is replaced atomically so a crash never leaves a truncated config behind.
+func Save(path string, cfg *Config) error {
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".config-*.yaml")
+	if err != nil {
+		return fmt.Errorf("failed to write config %s: %w", path, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write config %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write config %s: %w", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write config %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadMissingFile(t *testing.T) {
+	cfg, err := Load(filepath.Join(t.TempDir(), "config.yaml"))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.PodTable.Columns != nil {
+		t.Errorf("Expected default columns to be nil, got %v", cfg.PodTable.Columns)
+	}
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "kubeoptic", "config.yaml")
+
+	tests := []struct {
+		name string
+		cfg  Config
+	}{
+		{
+			name: "columns and sort",
+			cfg: Config{PodTable: PodTable{
+				Columns:         []string{"status", "age", "node"},
+				SortBy:          "restarts",
+				SortDescending:  true,
+				GroupByWorkload: true,
+			}},
+		},
+		{
+			name: "all columns hidden",
+			cfg:  Config{PodTable: PodTable{Columns: []string{}}},
+		},
+		{
+			name: "safety",
+			cfg: Config{Safety: Safety{
+				ReadOnly:           true,
+				ReadOnlyContexts:   []string{"*-dr"},
+				ProductionContexts: []string{},
+			}},
+		},
+		{
+			name: "debug image",
+			cfg:  Config{Debug: Debug{Image: "nicolaka/netshoot:v0.13"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := Save(path, &test.cfg); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+
+			loaded, err := Load(path)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if !reflect.DeepEqual(*loaded, test.cfg) {
+				t.Errorf("Load() = %+v, want %+v", *loaded, test.cfg)
+			}
+		})
+	}
+}
+
+func TestLoadInvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("podTable:\n  colums: [age]\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Load(path); err == nil {
+		t.Error("Expected an error for an unknown field")
+	}
+}
+
+func TestSafety(t *testing.T) {
+	safety := Safety{ReadOnlyContexts: []string{"audit-*", "*:cluster/payments*"}}
+
+	tests := []struct {
+		context    string
+		readOnly   bool
+		production bool
+	}{
+		{context: "minikube"},
+		{context: "gke_acme_europe-west1_prod-eu", production: true},
+		{context: "Production", production: true},
+		{context: "audit-staging", readOnly: true},
+		{context: "arn:aws:eks:eu-west-1:123456789012:cluster/payments-prod", readOnly: true, production: true},
+		{context: "staging-audit"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.context, func(t *testing.T) {
+			if got := safety.IsReadOnly(tt.context); got != tt.readOnly {
+				t.Errorf("IsReadOnly() = %v, want %v", got, tt.readOnly)
+			}
+			if got := safety.IsProduction(tt.context); got != tt.production {
+				t.Errorf("IsProduction() = %v, want %v", got, tt.production)
+			}
+		})
+	}
+
+	t.Run("global_read_only", func(t *testing.T) {
+		if !(Safety{ReadOnly: true}).IsReadOnly("minikube") {
+			t.Error("Expected read-only mode to cover every context")
+		}
+	})
+
+	t.Run("no_production_contexts", func(t *testing.T) {
+		if (Safety{ProductionContexts: []string{}}).IsProduction("prod") {
+			t.Error("Expected an empty list to mark no context")
+		}
+	})
+}
+
+func TestMatchContext(t *testing.T) {
+	tests := []struct {
+		pattern, context string
+		want             bool
+	}{
+		{"prod", "prod", true},
+		{"prod", "prod-eu", false},
+		{"prod*", "prod-eu", true},
+		{"*-eu", "prod-eu", true},
+		{"*-eu", "prod-us", false},
+		{"p*d*eu", "prod-eu", true},
+		{"a*b*b", "ab", false},
+		{"*", "anything/at:all", true},
+	}
+	for _, tt := range tests {
+		if got := MatchContext([]string{tt.pattern}, tt.context); got != tt.want {
+			t.Errorf("MatchContext(%q, %q) = %v, want %v", tt.pattern, tt.context, got, tt.want)
+		}
+	}
+}
+
+func TestDebugImage(t *testing.T) {
+	if got := (Debug{}).ImageOrDefault(); got != DefaultDebugImage {
+		t.Errorf("Expected the default image, got %q", got)
+	}
+	if got := (Debug{Image: "nicolaka/netshoot"}).ImageOrDefault(); got != "nicolaka/netshoot" {
+		t.Errorf("Expected the configured image, got %q", got)
+	}
+}